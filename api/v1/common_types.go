@@ -167,3 +167,7 @@ const (
 	// Used to track which nodes have been updated to the current template version
 	AnnotationTemplateGeneration = "lynq.sh/template-generation"
 )
+
+// AnnotationPaused pauses a LynqNode when set to "true": its resources are not applied (or
+// corrected for drift) until the annotation is removed. Deleting the node still cleans up.
+const AnnotationPaused = "lynq.sh/paused"
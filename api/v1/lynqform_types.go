@@ -62,6 +62,56 @@ type RolloutConfig struct {
 	ProgressDeadlineSeconds int32 `json:"progressDeadlineSeconds,omitempty"`
 }
 
+// RowSelectorOperator is the operator of a row selector requirement
+// +kubebuilder:validation:Enum=In;NotIn;Exists;DoesNotExist
+type RowSelectorOperator string
+
+const (
+	// RowSelectorOpIn matches rows whose value is one of the values
+	RowSelectorOpIn RowSelectorOperator = "In"
+	// RowSelectorOpNotIn matches rows whose value is none of the values (or that have no value)
+	RowSelectorOpNotIn RowSelectorOperator = "NotIn"
+	// RowSelectorOpExists matches rows that have a non-empty value
+	RowSelectorOpExists RowSelectorOperator = "Exists"
+	// RowSelectorOpDoesNotExist matches rows that have no value
+	RowSelectorOpDoesNotExist RowSelectorOperator = "DoesNotExist"
+)
+
+// RowSelectorRequirement matches a row value against a set of values
+type RowSelectorRequirement struct {
+	// Key is the template variable to match: an extraValueMappings key, or "uid"
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	Key string `json:"key"`
+
+	// Operator is one of In, NotIn, Exists and DoesNotExist
+	// +kubebuilder:validation:Required
+	Operator RowSelectorOperator `json:"operator"`
+
+	// Values must be non-empty for In and NotIn, and empty for Exists and DoesNotExist
+	// +optional
+	Values []string `json:"values,omitempty"`
+}
+
+// RowSelector selects the hub rows a LynqForm applies to, like a label selector on the row values
+// All given matchValues, matchExpressions and the expression must match (AND).
+type RowSelector struct {
+	// MatchValues matches rows whose values equal the given ones, e.g. {plan: enterprise}
+	// Keys are extraValueMappings keys, or "uid".
+	// +optional
+	MatchValues map[string]string `json:"matchValues,omitempty"`
+
+	// MatchExpressions are set-based requirements on the row values
+	// +optional
+	MatchExpressions []RowSelectorRequirement `json:"matchExpressions,omitempty"`
+
+	// Expression is a Go template that selects the row when it renders "true",
+	// e.g. {{ and (eq .plan "enterprise") (ne .region "cn") }}
+	// It takes the variables of resource templates.
+	// +optional
+	Expression string `json:"expression,omitempty"`
+}
+
 // RolloutStatus tracks the progress of a template rollout
 type RolloutStatus struct {
 	// Phase is the current phase of the rollout
@@ -215,6 +265,24 @@ type LynqFormSpec struct {
 	// all nodes from updating simultaneously
 	// +optional
 	Rollout *RolloutConfig `json:"rollout,omitempty"`
+
+	// NodeNameTemplate renders the names of this form's LynqNodes, e.g. "{{ .uid | sha1sum | trunc 12 }}-{{ .templateRef }}"
+	// It takes the variables of resource templates and overrides the hub's nodeNameTemplate.
+	// Rendered names that are not valid object names are lowercased, cleaned up and given a hash suffix.
+	// Default: "{uid}-{form name}", which requires uids that are valid in object names and labels
+	// +optional
+	NodeNameTemplate string `json:"nodeNameTemplate,omitempty"`
+
+	// RowSelector limits this form to the hub rows it matches; other rows get no LynqNode of this form
+	// Nodes of rows that stop matching are deleted like nodes of deactivated rows.
+	// Default: every active row
+	// +optional
+	RowSelector *RowSelector `json:"rowSelector,omitempty"`
+
+	// Suspend freezes this form's LynqNodes: the hub does not create, update or delete them, so
+	// template changes are not rolled out until it is set back to false
+	// +optional
+	Suspend bool `json:"suspend,omitempty"`
 }
 
 // LynqFormStatus defines the observed state of LynqForm.
@@ -250,6 +318,7 @@ type LynqFormStatus struct {
 // +kubebuilder:printcolumn:name="Rollout",type="string",JSONPath=".status.rollout.phase",description="Rollout phase"
 // +kubebuilder:printcolumn:name="Updating",type="integer",JSONPath=".status.rollout.updatingNodes",description="Nodes currently updating"
 // +kubebuilder:printcolumn:name="Applied",type="string",JSONPath=".status.conditions[?(@.type=='Applied')].status",description="Applied status"
+// +kubebuilder:printcolumn:name="Suspended",type="boolean",JSONPath=".spec.suspend",description="Node changes suspended",priority=1
 // +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
 
 // LynqForm is the Schema for the lynqforms API.
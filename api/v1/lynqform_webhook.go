@@ -153,6 +153,11 @@ func (v *LynqFormValidator) validateLynqForm(ctx context.Context, tmpl *LynqForm
 		return warnings, fmt.Errorf("ignoreFields validation failed: %w", err)
 	}
 
+	// 7. Validate rowSelector
+	if err := v.validateRowSelector(tmpl.Spec.RowSelector); err != nil {
+		return warnings, fmt.Errorf("rowSelector validation failed: %w", err)
+	}
+
 	return warnings, nil
 }
 
@@ -329,6 +334,32 @@ func (v *LynqFormValidator) validateIgnoreFields(tmpl *LynqForm) error {
 	return nil
 }
 
+// validateRowSelector validates the requirement values and the expression syntax; the keys are
+// extra values of the hub, which are only known to the controller
+func (v *LynqFormValidator) validateRowSelector(selector *RowSelector) error {
+	if selector == nil {
+		return nil
+	}
+	for i, req := range selector.MatchExpressions {
+		switch req.Operator {
+		case RowSelectorOpIn, RowSelectorOpNotIn:
+			if len(req.Values) == 0 {
+				return fmt.Errorf("matchExpressions[%d] (%s %s) requires values", i, req.Key, req.Operator)
+			}
+		case RowSelectorOpExists, RowSelectorOpDoesNotExist:
+			if len(req.Values) > 0 {
+				return fmt.Errorf("matchExpressions[%d] (%s %s) must not have values", i, req.Key, req.Operator)
+			}
+		default:
+			return fmt.Errorf("matchExpressions[%d] has unknown operator %q", i, req.Operator)
+		}
+	}
+	if err := template.NewEngine().Validate(selector.Expression); err != nil {
+		return fmt.Errorf("invalid expression: %w", err)
+	}
+	return nil
+}
+
 // contains checks if a string is in a slice
 func contains(slice []string, item string) bool {
 	for _, s := range slice {
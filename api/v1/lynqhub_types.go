@@ -24,13 +24,177 @@ import (
 // NOTE: json tags are required.  Any new fields you add must have json tags for the fields to be serialized.
 
 // SourceType defines the type of external data source
-// +kubebuilder:validation:Enum=mysql
+// +kubebuilder:validation:Enum=mysql;postgresql;sql;http;configmap;inline;plugin
 type SourceType string
 
 const (
-	SourceTypeMySQL SourceType = "mysql"
+	SourceTypeMySQL      SourceType = "mysql"
+	SourceTypePostgreSQL SourceType = "postgresql"
+	SourceTypeSQL        SourceType = "sql"
+	SourceTypeHTTP       SourceType = "http"
+	SourceTypeConfigMap  SourceType = "configmap"
+	SourceTypeInline     SourceType = "inline"
+	SourceTypePlugin     SourceType = "plugin"
 )
 
+// RowFormat defines the encoding of rows stored in a ConfigMap
+// +kubebuilder:validation:Enum=csv;json;yaml
+type RowFormat string
+
+const (
+	RowFormatCSV  RowFormat = "csv"
+	RowFormatJSON RowFormat = "json"
+	RowFormatYAML RowFormat = "yaml"
+)
+
+// ExtraValueType defines the type an extra value is exposed as to templates
+// +kubebuilder:validation:Enum=string;int;float;bool;json;auto
+type ExtraValueType string
+
+const (
+	ExtraValueTypeString ExtraValueType = "string"
+	ExtraValueTypeInt    ExtraValueType = "int"
+	ExtraValueTypeFloat  ExtraValueType = "float"
+	ExtraValueTypeBool   ExtraValueType = "bool"
+	// ExtraValueTypeJSON decodes the value as JSON, exposing objects and arrays as nested variables
+	ExtraValueTypeJSON ExtraValueType = "json"
+	// ExtraValueTypeAuto uses the column type of SQL sources and the JSON type of http sources
+	ExtraValueTypeAuto ExtraValueType = "auto"
+)
+
+// PostgreSQLSSLMode defines the libpq-compatible sslmode used for PostgreSQL connections
+// +kubebuilder:validation:Enum=disable;allow;prefer;require;verify-ca;verify-full
+type PostgreSQLSSLMode string
+
+const (
+	PostgreSQLSSLModeDisable    PostgreSQLSSLMode = "disable"
+	PostgreSQLSSLModeAllow      PostgreSQLSSLMode = "allow"
+	PostgreSQLSSLModePrefer     PostgreSQLSSLMode = "prefer"
+	PostgreSQLSSLModeRequire    PostgreSQLSSLMode = "require"
+	PostgreSQLSSLModeVerifyCA   PostgreSQLSSLMode = "verify-ca"
+	PostgreSQLSSLModeVerifyFull PostgreSQLSSLMode = "verify-full"
+)
+
+// MySQLTLSMode defines whether and how TLS is negotiated with a MySQL server
+// +kubebuilder:validation:Enum=disable;preferred;required;verify-ca;verify-full
+type MySQLTLSMode string
+
+const (
+	// MySQLTLSModeDisable uses an unencrypted connection
+	MySQLTLSModeDisable MySQLTLSMode = "disable"
+	// MySQLTLSModePreferred uses TLS when the server supports it, without verifying the certificate
+	MySQLTLSModePreferred MySQLTLSMode = "preferred"
+	// MySQLTLSModeRequired requires TLS without verifying the certificate
+	MySQLTLSModeRequired MySQLTLSMode = "required"
+	// MySQLTLSModeVerifyCA requires TLS and verifies the certificate chain, but not the host name
+	MySQLTLSModeVerifyCA MySQLTLSMode = "verify-ca"
+	// MySQLTLSModeVerifyFull requires TLS and verifies the certificate chain and host name
+	MySQLTLSModeVerifyFull MySQLTLSMode = "verify-full"
+)
+
+// RowFilterOperator defines the comparison applied by a row filter
+// +kubebuilder:validation:Enum=eq;ne;lt;le;gt;ge;in;notIn;like;isNull;isNotNull
+type RowFilterOperator string
+
+const (
+	RowFilterOperatorEq        RowFilterOperator = "eq"
+	RowFilterOperatorNe        RowFilterOperator = "ne"
+	RowFilterOperatorLt        RowFilterOperator = "lt"
+	RowFilterOperatorLe        RowFilterOperator = "le"
+	RowFilterOperatorGt        RowFilterOperator = "gt"
+	RowFilterOperatorGe        RowFilterOperator = "ge"
+	RowFilterOperatorIn        RowFilterOperator = "in"
+	RowFilterOperatorNotIn     RowFilterOperator = "notIn"
+	RowFilterOperatorLike      RowFilterOperator = "like"
+	RowFilterOperatorIsNull    RowFilterOperator = "isNull"
+	RowFilterOperatorIsNotNull RowFilterOperator = "isNotNull"
+)
+
+// RowFilter is a single column predicate pushed down into the source query
+// Values are always sent as bound query parameters, never interpolated into SQL
+type RowFilter struct {
+	// Column is the column to compare
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Pattern=`^[A-Za-z_][A-Za-z0-9_]*$`
+	Column string `json:"column"`
+
+	// Operator is the comparison to apply
+	// +kubebuilder:validation:Required
+	Operator RowFilterOperator `json:"operator"`
+
+	// Value is the operand for eq, ne, lt, le, gt, ge and like
+	// +optional
+	Value string `json:"value,omitempty"`
+
+	// Values are the operands for in and notIn
+	// +optional
+	Values []string `json:"values,omitempty"`
+}
+
+// DatabaseTLS references PEM-encoded TLS material stored in Secrets
+type DatabaseTLS struct {
+	// CASecretRef references a Secret key containing the CA certificate used to verify the server
+	// +optional
+	CASecretRef *SecretRef `json:"caSecretRef,omitempty"`
+
+	// ClientCertSecretRef references a Secret key containing the client certificate (mutual TLS)
+	// Must be set together with clientKeySecretRef
+	// +optional
+	ClientCertSecretRef *SecretRef `json:"clientCertSecretRef,omitempty"`
+
+	// ClientKeySecretRef references a Secret key containing the client private key (mutual TLS)
+	// Must be set together with clientCertSecretRef
+	// +optional
+	ClientKeySecretRef *SecretRef `json:"clientKeySecretRef,omitempty"`
+}
+
+// ConnectionPool configures the database connection pool
+type ConnectionPool struct {
+	// MaxOpenConns is the maximum number of open connections
+	// Default: 25
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	MaxOpenConns int32 `json:"maxOpenConns,omitempty"`
+
+	// MaxIdleConns is the maximum number of idle connections kept in the pool
+	// Default: 5
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	MaxIdleConns int32 `json:"maxIdleConns,omitempty"`
+
+	// ConnMaxLifetime is the maximum time a connection may be reused (e.g. 5m, 1h)
+	// Default: 5m
+	// +optional
+	// +kubebuilder:validation:Pattern=`^[0-9]+(s|m|h)$`
+	ConnMaxLifetime string `json:"connMaxLifetime,omitempty"`
+}
+
+// MySQLReadPreference selects which MySQL endpoints are read from first
+// +kubebuilder:validation:Enum=replica;primary
+type MySQLReadPreference string
+
+const (
+	// MySQLReadPreferenceReplica reads from the first healthy replica, falling back to the primary hosts
+	MySQLReadPreferenceReplica MySQLReadPreference = "replica"
+	// MySQLReadPreferencePrimary reads from the first healthy primary host, falling back to the replicas
+	MySQLReadPreferencePrimary MySQLReadPreference = "primary"
+)
+
+// MySQLEndpoint is an additional MySQL server address
+type MySQLEndpoint struct {
+	// Host is the MySQL server hostname or IP
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	Host string `json:"host"`
+
+	// Port is the MySQL server port
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	// +kubebuilder:default=3306
+	Port int32 `json:"port,omitempty"`
+}
+
 // MySQLSource defines MySQL connection parameters
 type MySQLSource struct {
 	// Host is the MySQL server hostname or IP
@@ -44,6 +208,21 @@ type MySQLSource struct {
 	// +kubebuilder:default=3306
 	Port int32 `json:"port"`
 
+	// FailoverHosts are further primary hosts, tried in order when host is unavailable
+	// +optional
+	FailoverHosts []MySQLEndpoint `json:"failoverHosts,omitempty"`
+
+	// Replicas are read replicas; every sync reads from the first healthy endpoint in
+	// the order given by readPreference
+	// +optional
+	Replicas []MySQLEndpoint `json:"replicas,omitempty"`
+
+	// ReadPreference selects whether replicas or primary hosts are tried first
+	// Default: replica
+	// +optional
+	// +kubebuilder:default=replica
+	ReadPreference MySQLReadPreference `json:"readPreference,omitempty"`
+
 	// Username is the MySQL username
 	// +kubebuilder:validation:Required
 	Username string `json:"username"`
@@ -59,6 +238,322 @@ type MySQLSource struct {
 	// Table is the MySQL table name containing node data
 	// +kubebuilder:validation:Required
 	Table string `json:"table"`
+
+	// Filter restricts the rows read from the table; all predicates must match
+	// Example: [{column: region, operator: eq, value: eu-west-1}]
+	// +optional
+	Filter []RowFilter `json:"filter,omitempty"`
+
+	// TLSMode controls whether and how TLS is negotiated with the server
+	// Default: disable
+	// +optional
+	// +kubebuilder:default=disable
+	TLSMode MySQLTLSMode `json:"tlsMode,omitempty"`
+
+	// TLS references the CA certificate and client certificate/key used for TLS connections
+	// The CA is used for verify-ca and verify-full; the client certificate enables mutual TLS
+	// +optional
+	TLS *DatabaseTLS `json:"tls,omitempty"`
+
+	// Params are additional go-sql-driver/mysql DSN parameters
+	// Example: {timeout: 5s, readTimeout: 30s, charset: utf8mb4}
+	// tls and parseTime are managed by the operator and cannot be set here
+	// +optional
+	Params map[string]string `json:"params,omitempty"`
+
+	// Pool configures the connection pool
+	// +optional
+	Pool *ConnectionPool `json:"pool,omitempty"`
+}
+
+// PostgreSQLSource defines PostgreSQL connection parameters
+type PostgreSQLSource struct {
+	// Host is the PostgreSQL server hostname or IP
+	// +kubebuilder:validation:Required
+	Host string `json:"host"`
+
+	// Port is the PostgreSQL server port
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	// +kubebuilder:default=5432
+	Port int32 `json:"port"`
+
+	// Username is the PostgreSQL username
+	// +kubebuilder:validation:Required
+	Username string `json:"username"`
+
+	// PasswordRef references a Secret containing the PostgreSQL password
+	// +optional
+	PasswordRef *SecretRef `json:"passwordRef,omitempty"`
+
+	// Database is the PostgreSQL database name
+	// +kubebuilder:validation:Required
+	Database string `json:"database"`
+
+	// Schema is the schema containing the node table
+	// Default: public
+	// +optional
+	// +kubebuilder:default=public
+	Schema string `json:"schema,omitempty"`
+
+	// Table is the PostgreSQL table or view name containing node data
+	// +kubebuilder:validation:Required
+	Table string `json:"table"`
+
+	// Filter restricts the rows read from the table; all predicates must match
+	// +optional
+	Filter []RowFilter `json:"filter,omitempty"`
+
+	// SSLMode controls whether and how TLS is negotiated with the server
+	// Default: prefer
+	// +optional
+	// +kubebuilder:default=prefer
+	SSLMode PostgreSQLSSLMode `json:"sslMode,omitempty"`
+
+	// TLS references the CA certificate and client certificate/key used for TLS connections
+	// The CA is used for verify-ca and verify-full; the client certificate enables mutual TLS
+	// +optional
+	TLS *DatabaseTLS `json:"tls,omitempty"`
+}
+
+// SQLDialect defines the SQL syntax (identifier quoting and bind parameters) of a sql source
+// +kubebuilder:validation:Enum=mysql;postgresql;sqlite
+type SQLDialect string
+
+const (
+	SQLDialectMySQL      SQLDialect = "mysql"
+	SQLDialectPostgreSQL SQLDialect = "postgresql"
+	SQLDialectSQLite     SQLDialect = "sqlite"
+)
+
+// SQLSource defines a connection through a database/sql driver compiled into the operator
+type SQLSource struct {
+	// Driver is the registered database/sql driver name, e.g. sqlite, mysql or pgx
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	Driver string `json:"driver"`
+
+	// Dialect selects identifier quoting and bind parameters
+	// Default: inferred from the driver name (mysql; pgx and postgres; sqlite and sqlite3)
+	// +optional
+	Dialect SQLDialect `json:"dialect,omitempty"`
+
+	// DSN is the driver-specific data source name, e.g. file:/data/tenants.db for sqlite
+	// Use dsnSecretRef instead when the DSN contains credentials
+	// +optional
+	DSN string `json:"dsn,omitempty"`
+
+	// DSNSecretRef references a Secret key holding the DSN
+	// +optional
+	DSNSecretRef *SecretRef `json:"dsnSecretRef,omitempty"`
+
+	// Params are appended to the DSN as URL query parameters
+	// Example: {mode: ro, _pragma: busy_timeout(5000)}
+	// +optional
+	Params map[string]string `json:"params,omitempty"`
+
+	// Table is the table or view name containing node data
+	// +kubebuilder:validation:Required
+	Table string `json:"table"`
+
+	// Filter restricts the rows read from the table; all predicates must match
+	// +optional
+	Filter []RowFilter `json:"filter,omitempty"`
+
+	// Pool configures the connection pool
+	// +optional
+	Pool *ConnectionPool `json:"pool,omitempty"`
+}
+
+// HTTPAuthType defines how requests to an HTTP datasource are authenticated
+// +kubebuilder:validation:Enum=none;bearer;basic
+type HTTPAuthType string
+
+const (
+	HTTPAuthTypeNone   HTTPAuthType = "none"
+	HTTPAuthTypeBearer HTTPAuthType = "bearer"
+	HTTPAuthTypeBasic  HTTPAuthType = "basic"
+)
+
+// HTTPPaginationType defines how an HTTP datasource walks through result pages
+// +kubebuilder:validation:Enum=none;cursor;page
+type HTTPPaginationType string
+
+const (
+	HTTPPaginationTypeNone   HTTPPaginationType = "none"
+	HTTPPaginationTypeCursor HTTPPaginationType = "cursor"
+	HTTPPaginationTypePage   HTTPPaginationType = "page"
+)
+
+// HTTPAuth defines credentials sent with every request to an HTTP datasource
+type HTTPAuth struct {
+	// Type is the authentication scheme
+	// Default: none
+	// +optional
+	// +kubebuilder:default=none
+	Type HTTPAuthType `json:"type,omitempty"`
+
+	// TokenRef references a Secret containing the bearer token (type: bearer)
+	// +optional
+	TokenRef *SecretRef `json:"tokenRef,omitempty"`
+
+	// Username is the basic auth username (type: basic)
+	// +optional
+	Username string `json:"username,omitempty"`
+
+	// PasswordRef references a Secret containing the basic auth password (type: basic)
+	// +optional
+	PasswordRef *SecretRef `json:"passwordRef,omitempty"`
+}
+
+// HTTPPagination defines how to request subsequent pages from an HTTP datasource
+type HTTPPagination struct {
+	// Type is the pagination strategy
+	// - none: a single request returns all items
+	// - cursor: the next cursor is read from the response and sent as a query parameter
+	// - page: a page number query parameter is incremented until a page returns no items
+	// Default: none
+	// +optional
+	// +kubebuilder:default=none
+	Type HTTPPaginationType `json:"type,omitempty"`
+
+	// CursorPath is the JSONPath of the next cursor in the response body (type: cursor)
+	// Pagination stops when the cursor is missing, null or empty
+	// Example: "$.meta.next_cursor"
+	// +optional
+	CursorPath string `json:"cursorPath,omitempty"`
+
+	// CursorParam is the query parameter used to send the cursor (type: cursor)
+	// Default: cursor
+	// +optional
+	CursorParam string `json:"cursorParam,omitempty"`
+
+	// PageParam is the query parameter used to send the page number (type: page)
+	// Default: page
+	// +optional
+	PageParam string `json:"pageParam,omitempty"`
+
+	// StartPage is the number of the first page (type: page)
+	// Default: 1
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	StartPage *int32 `json:"startPage,omitempty"`
+
+	// PageSizeParam is the query parameter used to send PageSize
+	// +optional
+	PageSizeParam string `json:"pageSizeParam,omitempty"`
+
+	// PageSize is the number of items requested per page
+	// Only sent when pageSizeParam is set
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	PageSize int32 `json:"pageSize,omitempty"`
+
+	// MaxPages limits the number of requests per sync to guard against pagination loops
+	// The sync fails (instead of returning partial results) when the limit is reached
+	// Default: 1000
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	MaxPages int32 `json:"maxPages,omitempty"`
+}
+
+// HTTPSource defines an HTTP endpoint returning node data as JSON
+// Value mappings are JSONPath expressions evaluated against each item (e.g. "$.id" or "billing.plan")
+type HTTPSource struct {
+	// URL is the endpoint to GET (query parameters are preserved)
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Pattern=`^https?://`
+	URL string `json:"url"`
+
+	// Headers are additional request headers (e.g. Accept or API version headers)
+	// +optional
+	Headers map[string]string `json:"headers,omitempty"`
+
+	// Auth configures request authentication
+	// +optional
+	Auth *HTTPAuth `json:"auth,omitempty"`
+
+	// ItemsPath is the JSONPath of the item array in the response body
+	// Default: "$" (the response body is the array)
+	// +optional
+	// +kubebuilder:default="$"
+	ItemsPath string `json:"itemsPath,omitempty"`
+
+	// Pagination configures how subsequent pages are requested
+	// +optional
+	Pagination *HTTPPagination `json:"pagination,omitempty"`
+
+	// Timeout is the timeout of a single request
+	// Default: 30s
+	// +optional
+	// +kubebuilder:validation:Pattern=`^[0-9]+(ms|s|m)$`
+	// +kubebuilder:default="30s"
+	Timeout string `json:"timeout,omitempty"`
+
+	// TLS references the CA certificate and client certificate/key used for HTTPS requests
+	// +optional
+	TLS *DatabaseTLS `json:"tls,omitempty"`
+}
+
+// ConfigMapSource defines a ConfigMap key holding node rows
+// CSV data must start with a header line; JSON and YAML data must be a list of objects.
+type ConfigMapSource struct {
+	// Name is the ConfigMap name (in the same namespace as the LynqHub)
+	// +kubebuilder:validation:Required
+	Name string `json:"name"`
+
+	// Key is the ConfigMap data key containing the rows
+	// +kubebuilder:validation:Required
+	Key string `json:"key"`
+
+	// Format is the encoding of the rows
+	// Default: inferred from the key extension (.csv, .json, .yaml/.yml)
+	// +optional
+	Format RowFormat `json:"format,omitempty"`
+}
+
+// InlineSource defines node rows embedded in the LynqHub spec
+type InlineSource struct {
+	// Rows is the list of rows; keys are column names referenced by value mappings
+	// +optional
+	Rows []map[string]string `json:"rows,omitempty"`
+}
+
+// PluginSource defines an out-of-process datasource plugin served over gRPC
+// The plugin returns raw rows; value mappings are JSONPath expressions evaluated against each row,
+// like for http sources.
+type PluginSource struct {
+	// Endpoint is the gRPC target of the plugin, e.g. unix:///var/run/lynq/cmdb.sock for a
+	// sidecar or cmdb-plugin.tools.svc:9000 for a Service
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	Endpoint string `json:"endpoint"`
+
+	// Table is passed to the plugin with every query; its meaning is plugin-specific
+	// +optional
+	Table string `json:"table,omitempty"`
+
+	// Config is passed to the plugin with every query
+	// +optional
+	Config map[string]string `json:"config,omitempty"`
+
+	// TokenRef references a Secret key sent as bearer token in the authorization metadata
+	// +optional
+	TokenRef *SecretRef `json:"tokenRef,omitempty"`
+
+	// Timeout is the timeout of a single query
+	// Default: 30s
+	// +optional
+	// +kubebuilder:validation:Pattern=`^[0-9]+(ms|s|m)$`
+	// +kubebuilder:default="30s"
+	Timeout string `json:"timeout,omitempty"`
+
+	// TLS enables TLS for the connection; the referenced Secrets are optional
+	// Without TLS the connection is plaintext, which is meant for unix sockets and
+	// in-cluster Services protected by network policies
+	// +optional
+	TLS *DatabaseTLS `json:"tls,omitempty"`
 }
 
 // DataSource defines the external data source configuration
@@ -73,12 +568,58 @@ type DataSource struct {
 	// +kubebuilder:default="30s"
 	SyncInterval string `json:"syncInterval"`
 
+	// UpdatedAtColumn enables incremental sync for mysql, postgresql and sql sources
+	// Only rows whose column value is at or after the watermark persisted in status are queried;
+	// rows changed to inactive are removed, and a periodic full resync catches deleted rows
+	// The column must be a DATETIME/TIMESTAMP updated on every row change
+	// +optional
+	UpdatedAtColumn string `json:"updatedAtColumn,omitempty"`
+
+	// FullResyncInterval is how often a full resync runs when updatedAtColumn is set
+	// Default: 1h
+	// +optional
+	// +kubebuilder:validation:Pattern=`^[0-9]+(s|m|h)$`
+	FullResyncInterval string `json:"fullResyncInterval,omitempty"`
+
+	// QueryTimeout bounds every datasource query of a sync, including relations and merge sources
+	// A query that does not finish in time fails the sync like an unreachable datasource.
+	// Default: 30s
+	// +optional
+	// +kubebuilder:validation:Pattern=`^[0-9]+(s|m|h)$`
+	QueryTimeout string `json:"queryTimeout,omitempty"`
+
 	// MySQL contains MySQL-specific configuration
 	// +optional
 	MySQL *MySQLSource `json:"mysql,omitempty"`
+
+	// PostgreSQL contains PostgreSQL-specific configuration
+	// +optional
+	PostgreSQL *PostgreSQLSource `json:"postgresql,omitempty"`
+
+	// SQL contains the configuration of a generic database/sql source
+	// +optional
+	SQL *SQLSource `json:"sql,omitempty"`
+
+	// HTTP contains HTTP/JSON endpoint configuration
+	// +optional
+	HTTP *HTTPSource `json:"http,omitempty"`
+
+	// ConfigMap contains the ConfigMap holding node rows
+	// Changes to the ConfigMap trigger an immediate sync
+	// +optional
+	ConfigMap *ConfigMapSource `json:"configMap,omitempty"`
+
+	// Inline contains node rows embedded in the spec
+	// +optional
+	Inline *InlineSource `json:"inline,omitempty"`
+
+	// Plugin contains the configuration of an out-of-process datasource plugin
+	// +optional
+	Plugin *PluginSource `json:"plugin,omitempty"`
 }
 
 // ValueMappings defines required column mappings
+// For http sources, column names are JSONPath expressions evaluated against each item
 type ValueMappings struct {
 	// UID is the column name for the node unique identifier
 	// +kubebuilder:validation:Required
@@ -91,8 +632,23 @@ type ValueMappings struct {
 	HostOrURL string `json:"hostOrUrl,omitempty"`
 
 	// Activate is the column name for the activation status
-	// +kubebuilder:validation:Required
-	Activate string `json:"activate"`
+	// Required unless activateExpression is set
+	// +optional
+	Activate string `json:"activate,omitempty"`
+
+	// ActiveValues lists the activate column values that mark a row active
+	// When empty, the default truthy values ("1", "true", "yes" in common casings) are used
+	// Mutually exclusive with activateExpression
+	// +optional
+	ActiveValues []string `json:"activeValues,omitempty"`
+
+	// ActivateExpression decides activation from one or more columns instead of the activate column
+	// Supports =, !=, <>, [NOT] IN (...), IS [NOT] NULL, AND, OR, NOT and parentheses,
+	// e.g. "status in ('active','trial')" or "deleted_at IS NULL"
+	// Values are compared as strings; rows where the expression is NULL are inactive
+	// Mutually exclusive with activeValues
+	// +optional
+	ActivateExpression string `json:"activateExpression,omitempty"`
 }
 
 // LynqHubSpec defines the desired state of LynqHub.
@@ -106,9 +662,350 @@ type LynqHubSpec struct {
 	ValueMappings ValueMappings `json:"valueMappings"`
 
 	// ExtraValueMappings defines additional custom column to variable mappings
-	// Keys become template variables, values are column names
+	// Keys become template variables, values are column names (JSONPath expressions for http sources)
 	// +optional
 	ExtraValueMappings map[string]string `json:"extraValueMappings,omitempty"`
+
+	// ExtraValueTypes declares the type of extra values, keyed like ExtraValueMappings
+	// Typed values reach templates as numbers or booleans instead of strings; values that are
+	// empty or cannot be converted stay strings. Unlisted values are strings.
+	// +optional
+	ExtraValueTypes map[string]ExtraValueType `json:"extraValueTypes,omitempty"`
+
+	// Relations load one-to-many child rows (mysql, postgresql and sql sources)
+	// Each relation becomes a list variable holding the matching child rows of a node
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	Relations []Relation `json:"relations,omitempty"`
+
+	// DeletionGuard limits how many LynqNodes a single sync may delete
+	// When a sync would exceed a limit, no nodes are deleted until the deletions are approved
+	// with the lynq.sh/approve-deletions annotation
+	// +optional
+	DeletionGuard *DeletionGuard `json:"deletionGuard,omitempty"`
+
+	// InvalidRowPolicy decides what happens to rows whose uid cannot identify a LynqNode:
+	// empty, duplicate, not a valid label value, or too long for the LynqNode name
+	// Rejected rows are listed in status.rejectedRows.
+	// Default: skip
+	// +optional
+	// +kubebuilder:default=skip
+	InvalidRowPolicy InvalidRowPolicy `json:"invalidRowPolicy,omitempty"`
+
+	// MergeSources join the rows of further datasources to the rows of spec.source by uid
+	// The nodes, their activation and relations come from spec.source. When several sources map
+	// the same extra value key, the first non-empty value wins, in the order spec.source, then
+	// mergeSources in list order.
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	MergeSources []MergeSource `json:"mergeSources,omitempty"`
+
+	// Retry controls how failed datasource syncs are retried: the backoff between syncs and
+	// the circuit breaker that stops querying a datasource that keeps failing
+	// +optional
+	Retry *SyncRetryPolicy `json:"retry,omitempty"`
+
+	// NodeNameTemplate renders the names of the hub's LynqNodes for forms without a nodeNameTemplate
+	// of their own, e.g. "{{ .uid }}-{{ .templateRef }}". Rendered names that are not valid object
+	// names are lowercased, cleaned up and given a hash suffix, so uids such as e-mail addresses can
+	// be used as they are.
+	// Default: "{uid}-{form name}", which requires uids that are valid in object names and labels
+	// +optional
+	NodeNameTemplate string `json:"nodeNameTemplate,omitempty"`
+
+	// Parallelism is the number of LynqNodes created or updated at the same time during a sync
+	// Rollout maxSkew limits still apply per LynqForm.
+	// Default: 1 (one node at a time)
+	// +optional
+	// +kubebuilder:default=1
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=64
+	Parallelism int32 `json:"parallelism,omitempty"`
+
+	// Suspend stops syncing the hub: the datasource is not queried and no LynqNode is created,
+	// updated or deleted until it is set back to false. Existing LynqNodes keep running.
+	// +optional
+	Suspend bool `json:"suspend,omitempty"`
+}
+
+// SyncRetryPolicy controls the retries of failed datasource syncs
+type SyncRetryPolicy struct {
+	// MaxBackoff caps the delay before the next sync after consecutive failures
+	// The delay starts at syncInterval and doubles with every failure, plus up to 20% jitter.
+	// Default: 10m
+	// +optional
+	// +kubebuilder:validation:Pattern=`^[0-9]+(s|m|h)$`
+	MaxBackoff string `json:"maxBackoff,omitempty"`
+
+	// CircuitBreakerThreshold is the number of consecutive failed syncs that opens the circuit breaker
+	// While the circuit is open, the datasource is not queried whatever triggers the reconcile.
+	// 0 disables the circuit breaker. Default: 5
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	CircuitBreakerThreshold *int32 `json:"circuitBreakerThreshold,omitempty"`
+
+	// CircuitBreakerCooldown is how long the circuit stays open before a single probe sync
+	// A successful probe closes the circuit; a failed one opens it for another cooldown.
+	// Default: 5m
+	// +optional
+	// +kubebuilder:validation:Pattern=`^[0-9]+(s|m|h)$`
+	CircuitBreakerCooldown string `json:"circuitBreakerCooldown,omitempty"`
+}
+
+// MergeSource is an additional datasource whose rows are joined to the hub's rows by uid
+type MergeSource struct {
+	// Name identifies the source in status and events
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MaxLength=63
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`
+	Name string `json:"name"`
+
+	// Source configures the datasource like spec.source
+	// It is read on every sync of the hub; syncInterval, updatedAtColumn and fullResyncInterval are not used.
+	// +kubebuilder:validation:Required
+	Source DataSource `json:"source"`
+
+	// UID is the column holding the uid that matches the hub's rows (a JSONPath expression for http sources)
+	// +kubebuilder:validation:Required
+	UID string `json:"uid"`
+
+	// ExtraValueMappings maps extra values to columns of this source
+	// Keys share the namespace of spec.extraValueMappings.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinProperties=1
+	ExtraValueMappings map[string]string `json:"extraValueMappings"`
+
+	// ExtraValueTypes declares the type of extra values, keyed like ExtraValueMappings
+	// +optional
+	ExtraValueTypes map[string]ExtraValueType `json:"extraValueTypes,omitempty"`
+
+	// Required drops the hub's rows that have no row in this source
+	// By default such rows are kept without the values of this source.
+	// +optional
+	Required bool `json:"required,omitempty"`
+}
+
+// MergeSourceHub returns a copy of the hub that reads the merge source as its only source, with the
+// merge source's uid and extra value mappings. Credentials, TLS material and rows of the merge source
+// are resolved like those of spec.source.
+func (r *LynqHub) MergeSourceHub(source MergeSource) *LynqHub {
+	hub := &LynqHub{ObjectMeta: *r.ObjectMeta.DeepCopy()}
+	hub.Spec.Source = *source.Source.DeepCopy()
+	hub.Spec.Source.UpdatedAtColumn = ""
+	hub.Spec.Source.FullResyncInterval = ""
+	hub.Spec.ValueMappings = ValueMappings{UID: source.UID}
+	hub.Spec.ExtraValueMappings = source.ExtraValueMappings
+	hub.Spec.ExtraValueTypes = source.ExtraValueTypes
+	return hub
+}
+
+// InvalidRowPolicy decides how rows with an unusable uid are handled
+// +kubebuilder:validation:Enum=skip;sanitize;fail
+type InvalidRowPolicy string
+
+const (
+	// InvalidRowPolicySkip drops invalid rows and syncs the rest
+	InvalidRowPolicySkip InvalidRowPolicy = "skip"
+	// InvalidRowPolicySanitize rewrites invalid uids into valid ones (lowercase, invalid characters
+	// replaced by "-", truncated); rows that stay invalid or collide are skipped
+	InvalidRowPolicySanitize InvalidRowPolicy = "sanitize"
+	// InvalidRowPolicyFail fails the sync while any row is invalid; LynqNodes keep their last synced data
+	InvalidRowPolicyFail InvalidRowPolicy = "fail"
+)
+
+// Relation maps the rows of a child table that reference a node to a list variable
+type Relation struct {
+	// Name is the template variable holding the list, e.g. domains for {{ range .domains }}
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Pattern=`^[A-Za-z_][A-Za-z0-9_]*$`
+	Name string `json:"name"`
+
+	// Table is the child table, in the same database (and schema) as the source table
+	// +kubebuilder:validation:Required
+	Table string `json:"table"`
+
+	// ForeignKey is the child column holding the node's uid value
+	// +kubebuilder:validation:Required
+	ForeignKey string `json:"foreignKey"`
+
+	// ValueMappings maps item field names to child columns; each list item exposes the fields
+	// as strings, e.g. {{ .host }} inside {{ range .domains }}
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinProperties=1
+	ValueMappings map[string]string `json:"valueMappings"`
+
+	// OrderBy is the child column that orders the items
+	// Default: the mapped columns, so that the order is stable across syncs
+	// +optional
+	OrderBy string `json:"orderBy,omitempty"`
+}
+
+// AnnotationApproveDeletions approves deletions blocked by the deletion guard when set to "true"
+// The approval is consumed (the annotation removed) by the next sync.
+const AnnotationApproveDeletions = "lynq.sh/approve-deletions"
+
+// DeletionGuard protects against mass deletion of LynqNodes, e.g. when the datasource
+// suddenly returns far fewer rows after a bad migration or a truncated table
+type DeletionGuard struct {
+	// MaxDeletions is the maximum number of LynqNodes a single sync may delete
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	MaxDeletions *int32 `json:"maxDeletions,omitempty"`
+
+	// MaxDeletionPercent is the maximum percentage of existing LynqNodes a single sync may delete
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=100
+	MaxDeletionPercent *int32 `json:"maxDeletionPercent,omitempty"`
+}
+
+// IncrementalSyncStatus tracks the state of watermark-based incremental sync
+type IncrementalSyncStatus struct {
+	// Watermark is the highest updatedAtColumn value applied so far
+	// The next incremental sync queries rows at or after this time
+	// +optional
+	Watermark *metav1.Time `json:"watermark,omitempty"`
+
+	// LastFullSyncTime is when the last full resync was applied
+	// +optional
+	LastFullSyncTime *metav1.Time `json:"lastFullSyncTime,omitempty"`
+
+	// Fingerprint identifies the hub generation and referencing LynqForms the watermark applies to
+	// A different fingerprint forces a full resync
+	// +optional
+	Fingerprint string `json:"fingerprint,omitempty"`
+}
+
+// DataSnapshotStatus describes the last row set successfully read from the datasource
+// While the datasource is unavailable, existing LynqNodes keep serving this data
+type DataSnapshotStatus struct {
+	// LastSuccessfulSyncTime is when the datasource was last queried successfully
+	// The age of the data served by LynqNodes is measured from this time
+	// +optional
+	LastSuccessfulSyncTime *metav1.Time `json:"lastSuccessfulSyncTime,omitempty"`
+
+	// RowCount is the number of active rows in the last successful sync
+	// +optional
+	RowCount int32 `json:"rowCount,omitempty"`
+
+	// Hash is a hash of the active rows from the last successful full sync
+	// It changes whenever the row data changes
+	// +optional
+	Hash string `json:"hash,omitempty"`
+
+	// ConsecutiveFailures is the number of failed syncs since the last successful sync
+	// +optional
+	ConsecutiveFailures int32 `json:"consecutiveFailures,omitempty"`
+
+	// LastFailureTime is when the last sync failed
+	// +optional
+	LastFailureTime *metav1.Time `json:"lastFailureTime,omitempty"`
+
+	// LastError is the error of the last failed sync (cleared on success)
+	// +optional
+	LastError string `json:"lastError,omitempty"`
+
+	// NextRetryTime is when the next sync runs after a failure (cleared on success)
+	// +optional
+	NextRetryTime *metav1.Time `json:"nextRetryTime,omitempty"`
+}
+
+// MergeSourceStatus reports the rows read from a merge source
+type MergeSourceStatus struct {
+	// Name is the merge source name
+	Name string `json:"name"`
+
+	// Rows is the number of rows read from the source
+	Rows int32 `json:"rows"`
+
+	// Matched is the number of the hub's rows that had a row in the source
+	Matched int32 `json:"matched"`
+}
+
+// Row rejection reasons
+const (
+	RowRejectedEmptyUID     = "EmptyUID"
+	RowRejectedDuplicateUID = "DuplicateUID"
+	RowRejectedInvalidUID   = "InvalidUID"
+	RowRejectedNameTooLong  = "NameTooLong"
+)
+
+// RejectedRow is a datasource row that was not synced because of its uid
+type RejectedRow struct {
+	// UID is the uid value as read from the datasource
+	// +optional
+	UID string `json:"uid,omitempty"`
+
+	// Reason is EmptyUID, DuplicateUID, InvalidUID or NameTooLong
+	Reason string `json:"reason"`
+
+	// Message describes why the uid was rejected
+	Message string `json:"message"`
+}
+
+// FormSyncStatus reports the LynqNodes of one referencing LynqForm
+type FormSyncStatus struct {
+	// Name is the LynqForm name
+	Name string `json:"name"`
+
+	// Desired is the number of rows the form applies to (selected by its rowSelector)
+	Desired int32 `json:"desired"`
+
+	// Ready is the number of the form's LynqNodes with Ready=True
+	Ready int32 `json:"ready"`
+
+	// Failed is the number of the form's LynqNodes with Ready=False
+	Failed int32 `json:"failed"`
+
+	// Throttled is the number of node creates and updates the last sync held back for rollout.maxSkew
+	// +optional
+	Throttled int32 `json:"throttled,omitempty"`
+}
+
+// SyncChanges counts the LynqNodes changed by a sync
+type SyncChanges struct {
+	// Created is the number of LynqNodes created
+	Created int32 `json:"created"`
+
+	// Updated is the number of LynqNodes updated
+	Updated int32 `json:"updated"`
+
+	// Deleted is the number of LynqNodes deleted
+	Deleted int32 `json:"deleted"`
+}
+
+// NodeWriteFailure is a LynqNode that a sync failed to create or update
+type NodeWriteFailure struct {
+	// UID is the uid of the node's row
+	UID string `json:"uid"`
+
+	// Form is the LynqForm of the node
+	Form string `json:"form"`
+
+	// Operation is Create or Update
+	Operation string `json:"operation"`
+
+	// Message is the error returned by the API server or the template renderer
+	Message string `json:"message"`
+}
+
+// SchemaIssue is a hub column mapping that does not match the source table schema
+type SchemaIssue struct {
+	// Field is the hub field the column comes from, e.g. "extraValueMappings.planId"
+	// +optional
+	Field string `json:"field,omitempty"`
+
+	// Column is the mapped column (empty when the table itself is missing)
+	// +optional
+	Column string `json:"column,omitempty"`
+
+	// Reason is TableNotFound, ColumnNotFound, TypeMismatch or NullableUID
+	Reason string `json:"reason"`
+
+	// Message describes the issue, e.g. "column `tenant_plan` not found in `tenants` (extraValueMappings.planId)"
+	Message string `json:"message"`
 }
 
 // LynqHubStatus defines the observed state of LynqHub.
@@ -122,7 +1019,8 @@ type LynqHubStatus struct {
 	ReferencingTemplates int32 `json:"referencingTemplates,omitempty"`
 
 	// Desired is the total number of LynqNode CRs that should exist
-	// Calculated as: ReferencingTemplates * ActiveRows
+	// Calculated as: ReferencingTemplates * ActiveRows, counting only the rows selected by
+	// each LynqForm's rowSelector
 	// +optional
 	Desired int32 `json:"desired,omitempty"`
 
@@ -134,6 +1032,72 @@ type LynqHubStatus struct {
 	// +optional
 	Failed int32 `json:"failed,omitempty"`
 
+	// Forms breaks Desired, Ready and Failed down by referencing LynqForm, as of the last successful sync
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	Forms []FormSyncStatus `json:"forms,omitempty"`
+
+	// LastSyncTime is when the last sync started, whether it succeeded or not
+	// +optional
+	LastSyncTime *metav1.Time `json:"lastSyncTime,omitempty"`
+
+	// LastSyncDuration is how long the last sync took, from querying the datasource to writing LynqNodes
+	// +optional
+	LastSyncDuration *metav1.Duration `json:"lastSyncDuration,omitempty"`
+
+	// LastSyncChanges counts the LynqNodes created, updated and deleted by the last sync
+	// +optional
+	LastSyncChanges *SyncChanges `json:"lastSyncChanges,omitempty"`
+
+	// LastSyncError is the error of the last sync: a datasource error, invalid rows halting the sync,
+	// or failed LynqNode writes. Empty when the last sync applied every change.
+	// The active and rejected row counts are status.snapshot.rowCount and status.rejectedRowCount.
+	// +optional
+	LastSyncError string `json:"lastSyncError,omitempty"`
+
+	// IncrementalSync is the incremental sync state (only when spec.source.updatedAtColumn is set)
+	// +optional
+	IncrementalSync *IncrementalSyncStatus `json:"incrementalSync,omitempty"`
+
+	// Snapshot describes the last successfully synced data and how stale it is
+	// +optional
+	Snapshot *DataSnapshotStatus `json:"snapshot,omitempty"`
+
+	// ActiveEndpoint is the datasource endpoint read by the last successful sync,
+	// e.g. "mysql-replica-0:3306 (replica)" (mysql sources)
+	// +optional
+	ActiveEndpoint string `json:"activeEndpoint,omitempty"`
+
+	// RejectedRows lists rows not synced because of their uid (at most 50)
+	// Full syncs replace the list; incremental syncs update the entries of the rows they read.
+	// +optional
+	RejectedRows []RejectedRow `json:"rejectedRows,omitempty"`
+
+	// RejectedRowCount is the number of rejected rows, including those not listed in RejectedRows
+	// +optional
+	RejectedRowCount int32 `json:"rejectedRowCount,omitempty"`
+
+	// MergeSources reports the rows read from each merge source by the last successful sync
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	MergeSources []MergeSourceStatus `json:"mergeSources,omitempty"`
+
+	// NodeWriteFailures lists the LynqNodes the last successful sync failed to create or update
+	// (at most 50). They are retried by the next sync.
+	// +optional
+	NodeWriteFailures []NodeWriteFailure `json:"nodeWriteFailures,omitempty"`
+
+	// NodeWriteFailureCount is the number of failed writes, including those not listed in NodeWriteFailures
+	// +optional
+	NodeWriteFailureCount int32 `json:"nodeWriteFailureCount,omitempty"`
+
+	// SchemaIssues lists the mapped columns that do not match the source table schema
+	// (mysql, postgresql and sql sources). Empty when the SchemaValid condition is True.
+	// +optional
+	SchemaIssues []SchemaIssue `json:"schemaIssues,omitempty"`
+
 	// Conditions represent the latest available observations of the hub's state
 	// +optional
 	// +patchMergeKey=type
@@ -148,6 +1112,8 @@ type LynqHubStatus struct {
 // +kubebuilder:printcolumn:name="Ready",type="integer",JSONPath=".status.ready",description="Number of ready nodes"
 // +kubebuilder:printcolumn:name="Failed",type="integer",JSONPath=".status.failed",description="Number of failed nodes"
 // +kubebuilder:printcolumn:name="Conditions",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].reason",description="Condition reason"
+// +kubebuilder:printcolumn:name="Last Sync",type="date",JSONPath=".status.snapshot.lastSuccessfulSyncTime",description="Last successful datasource sync",priority=1
+// +kubebuilder:printcolumn:name="Suspended",type="boolean",JSONPath=".spec.suspend",description="Syncing suspended",priority=1
 // +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
 
 // LynqHub is the Schema for the lynqhubs API.
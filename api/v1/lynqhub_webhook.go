@@ -19,23 +19,43 @@ package v1
 import (
 	"context"
 	"fmt"
+	"net"
+	"net/url"
+	"regexp"
+	"sort"
+	"strconv"
+	"time"
 
+	"k8s.io/apimachinery/pkg/api/equality"
 	"k8s.io/apimachinery/pkg/runtime"
 	ctrl "sigs.k8s.io/controller-runtime"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	"github.com/k8s-lynq/lynq/internal/activation"
+	"github.com/k8s-lynq/lynq/internal/fieldfilter"
+	"github.com/k8s-lynq/lynq/internal/template"
 )
 
 // log is for logging in this package.
 var lynqhublog = logf.Log.WithName("lynqhub-resource")
 
+// schemaCheckTimeout bounds how long admission waits for the datasource schema check
+const schemaCheckTimeout = 3 * time.Second
+
+// SchemaCheckFunc connects to the hub's datasource and returns one message per mapped column
+// that does not match the table schema
+// +kubebuilder:object:generate=false
+type SchemaCheckFunc func(ctx context.Context, hub *LynqHub) ([]string, error)
+
 // SetupWebhookWithManager sets up the webhook with the Manager.
-func (r *LynqHub) SetupWebhookWithManager(mgr ctrl.Manager) error {
+// schemaCheck is optional; when set, schema mismatches are returned as admission warnings.
+func (r *LynqHub) SetupWebhookWithManager(mgr ctrl.Manager, schemaCheck SchemaCheckFunc) error {
 	return ctrl.NewWebhookManagedBy(mgr).
 		For(r).
 		WithDefaulter(&LynqHubDefaulter{}).
-		WithValidator(&LynqHubValidator{}).
+		WithValidator(&LynqHubValidator{SchemaCheck: schemaCheck}).
 		Complete()
 }
 
@@ -60,9 +80,60 @@ func (d *LynqHubDefaulter) Default(ctx context.Context, obj runtime.Object) erro
 		registry.Spec.Source.SyncInterval = "30s"
 	}
 
-	// Set default MySQL port
-	if registry.Spec.Source.MySQL != nil && registry.Spec.Source.MySQL.Port == 0 {
-		registry.Spec.Source.MySQL.Port = 3306
+	// Set MySQL defaults
+	if mysql := registry.Spec.Source.MySQL; mysql != nil {
+		if mysql.Port == 0 {
+			mysql.Port = 3306
+		}
+		if mysql.TLSMode == "" {
+			mysql.TLSMode = MySQLTLSModeDisable
+		}
+		if mysql.ReadPreference == "" {
+			mysql.ReadPreference = MySQLReadPreferenceReplica
+		}
+		for i := range mysql.FailoverHosts {
+			if mysql.FailoverHosts[i].Port == 0 {
+				mysql.FailoverHosts[i].Port = 3306
+			}
+		}
+		for i := range mysql.Replicas {
+			if mysql.Replicas[i].Port == 0 {
+				mysql.Replicas[i].Port = 3306
+			}
+		}
+	}
+
+	// Set PostgreSQL defaults
+	if pg := registry.Spec.Source.PostgreSQL; pg != nil {
+		if pg.Port == 0 {
+			pg.Port = 5432
+		}
+		if pg.Schema == "" {
+			pg.Schema = "public"
+		}
+		if pg.SSLMode == "" {
+			pg.SSLMode = PostgreSQLSSLModePrefer
+		}
+	}
+
+	// Set HTTP defaults
+	if h := registry.Spec.Source.HTTP; h != nil {
+		if h.ItemsPath == "" {
+			h.ItemsPath = "$"
+		}
+		if h.Timeout == "" {
+			h.Timeout = "30s"
+		}
+		if h.Auth != nil && h.Auth.Type == "" {
+			h.Auth.Type = HTTPAuthTypeNone
+		}
+		if h.Pagination != nil && h.Pagination.Type == "" {
+			h.Pagination.Type = HTTPPaginationTypeNone
+		}
+	}
+
+	if registry.Spec.InvalidRowPolicy == "" {
+		registry.Spec.InvalidRowPolicy = InvalidRowPolicySkip
 	}
 
 	return nil
@@ -71,7 +142,11 @@ func (d *LynqHubDefaulter) Default(ctx context.Context, obj runtime.Object) erro
 // +kubebuilder:webhook:path=/validate-operator-lynq-sh-v1-lynqhub,mutating=false,failurePolicy=fail,sideEffects=None,groups=operator.lynq.sh,resources=lynqhubs,verbs=create;update,versions=v1,name=vlynqhub.kb.io,admissionReviewVersions=v1
 
 // LynqHubValidator handles validation for LynqHub
-type LynqHubValidator struct{}
+// +kubebuilder:object:generate=false
+type LynqHubValidator struct {
+	// SchemaCheck compares the column mappings to the source table when the database is reachable
+	SchemaCheck SchemaCheckFunc
+}
 
 var _ webhook.CustomValidator = &LynqHubValidator{}
 
@@ -84,7 +159,7 @@ func (v *LynqHubValidator) ValidateCreate(ctx context.Context, obj runtime.Objec
 
 	lynqhublog.Info("validate create", "name", registry.Name)
 
-	return v.validateLynqHub(ctx, registry)
+	return v.validateLynqHub(ctx, registry, true)
 }
 
 // ValidateUpdate implements webhook.Validator
@@ -93,10 +168,16 @@ func (v *LynqHubValidator) ValidateUpdate(ctx context.Context, oldObj, newObj ru
 	if !ok {
 		return nil, fmt.Errorf("expected LynqHub but got %T", newObj)
 	}
+	old, ok := oldObj.(*LynqHub)
+	if !ok {
+		return nil, fmt.Errorf("expected LynqHub but got %T", oldObj)
+	}
 
 	lynqhublog.Info("validate update", "name", registry.Name)
 
-	return v.validateLynqHub(ctx, registry)
+	// The schema check connects to the database; metadata writes such as the controller's
+	// finalizer and annotation updates must not wait for it
+	return v.validateLynqHub(ctx, registry, schemaInputsChanged(old, registry))
 }
 
 // ValidateDelete implements webhook.Validator
@@ -105,18 +186,37 @@ func (v *LynqHubValidator) ValidateDelete(ctx context.Context, obj runtime.Objec
 	return nil, nil
 }
 
-// validateLynqHub performs all validation checks
-//
-//nolint:unparam // ctx kept for future validation that may need context
-func (v *LynqHubValidator) validateLynqHub(ctx context.Context, registry *LynqHub) (admission.Warnings, error) {
+// validateLynqHub performs all validation checks; the datasource schema is only checked with checkSchema
+func (v *LynqHubValidator) validateLynqHub(ctx context.Context, registry *LynqHub, checkSchema bool) (admission.Warnings, error) {
 	var warnings admission.Warnings
 
 	// Validate required ValueMappings
 	if registry.Spec.ValueMappings.UID == "" {
 		return warnings, fmt.Errorf("valueMappings.uid is required")
 	}
-	if registry.Spec.ValueMappings.Activate == "" {
-		return warnings, fmt.Errorf("valueMappings.activate is required")
+	if err := validateActivation(registry); err != nil {
+		return warnings, err
+	}
+
+	if err := validateExtraValueTypes(registry); err != nil {
+		return warnings, err
+	}
+
+	if err := validateRelations(registry); err != nil {
+		return warnings, err
+	}
+
+	if guard := registry.Spec.DeletionGuard; guard != nil && guard.MaxDeletions == nil && guard.MaxDeletionPercent == nil {
+		warnings = append(warnings, "deletionGuard has neither maxDeletions nor maxDeletionPercent set and has no effect")
+	}
+
+	warnings = append(warnings, retryWarnings(registry)...)
+
+	if registry.Spec.NodeNameTemplate != "" {
+		sampleVars := template.Variables{"uid": "test-node", "hubId": registry.Name, "templateRef": "test-template"}
+		if _, err := template.NewEngine().Render(registry.Spec.NodeNameTemplate, sampleVars); err != nil {
+			return warnings, fmt.Errorf("invalid nodeNameTemplate: %w", err)
+		}
 	}
 
 	// Deprecation warning for hostOrUrl
@@ -127,6 +227,39 @@ func (v *LynqHubValidator) validateLynqHub(ctx context.Context, registry *LynqHu
 	}
 
 	// Validate source configuration
+	sourceWarnings, err := validateSource(registry)
+	warnings = append(warnings, sourceWarnings...)
+	if err != nil {
+		return warnings, err
+	}
+
+	mergeWarnings, err := validateMergeSources(registry)
+	warnings = append(warnings, mergeWarnings...)
+	if err != nil {
+		return warnings, err
+	}
+
+	if checkSchema {
+		warnings = append(warnings, v.schemaWarnings(ctx, registry)...)
+	}
+
+	return warnings, nil
+}
+
+// schemaInputsChanged reports whether an update changes what the schema check compares:
+// the source table and connection, or the column mappings
+func schemaInputsChanged(old, updated *LynqHub) bool {
+	return !equality.Semantic.DeepEqual(old.Spec.Source, updated.Spec.Source) ||
+		!equality.Semantic.DeepEqual(old.Spec.ValueMappings, updated.Spec.ValueMappings) ||
+		!equality.Semantic.DeepEqual(old.Spec.ExtraValueMappings, updated.Spec.ExtraValueMappings) ||
+		!equality.Semantic.DeepEqual(old.Spec.ExtraValueTypes, updated.Spec.ExtraValueTypes) ||
+		!equality.Semantic.DeepEqual(old.Spec.Relations, updated.Spec.Relations)
+}
+
+// validateSource validates spec.source against its type
+func validateSource(registry *LynqHub) (admission.Warnings, error) {
+	var warnings admission.Warnings
+
 	if registry.Spec.Source.Type == SourceTypeMySQL {
 		if registry.Spec.Source.MySQL == nil {
 			return warnings, fmt.Errorf("mysql configuration is required when source type is mysql")
@@ -143,7 +276,552 @@ func (v *LynqHubValidator) validateLynqHub(ctx context.Context, registry *LynqHu
 		if registry.Spec.Source.MySQL.Table == "" {
 			return warnings, fmt.Errorf("mysql.table is required")
 		}
+		if err := validateRowFilters("mysql.filter", registry.Spec.Source.MySQL.Filter); err != nil {
+			return warnings, err
+		}
+		mysqlWarnings, err := validateMySQLConnection(registry.Spec.Source.MySQL)
+		warnings = append(warnings, mysqlWarnings...)
+		if err != nil {
+			return warnings, err
+		}
+	}
+
+	if registry.Spec.Source.Type == SourceTypePostgreSQL {
+		pg := registry.Spec.Source.PostgreSQL
+		if pg == nil {
+			return warnings, fmt.Errorf("postgresql configuration is required when source type is postgresql")
+		}
+		if pg.Host == "" {
+			return warnings, fmt.Errorf("postgresql.host is required")
+		}
+		if pg.Username == "" {
+			return warnings, fmt.Errorf("postgresql.username is required")
+		}
+		if pg.Database == "" {
+			return warnings, fmt.Errorf("postgresql.database is required")
+		}
+		if pg.Table == "" {
+			return warnings, fmt.Errorf("postgresql.table is required")
+		}
+		if err := validateRowFilters("postgresql.filter", pg.Filter); err != nil {
+			return warnings, err
+		}
+		if err := validateDatabaseTLS("postgresql.tls", pg.TLS); err != nil {
+			return warnings, err
+		}
+		if pg.TLS != nil && pg.TLS.CASecretRef != nil &&
+			pg.SSLMode != PostgreSQLSSLModeVerifyCA && pg.SSLMode != PostgreSQLSSLModeVerifyFull {
+			warnings = append(warnings,
+				fmt.Sprintf("postgresql.tls.caSecretRef is only used to verify the server when sslMode is verify-ca or verify-full (current: %s)", pg.SSLMode))
+		}
+	}
+
+	if registry.Spec.Source.Type == SourceTypeSQL {
+		sqlWarnings, err := validateSQLSource(registry.Spec.Source.SQL)
+		warnings = append(warnings, sqlWarnings...)
+		if err != nil {
+			return warnings, err
+		}
+	}
+
+	// Incremental sync needs a SQL source with a timestamp column
+	if registry.Spec.Source.UpdatedAtColumn != "" {
+		switch registry.Spec.Source.Type {
+		case SourceTypeMySQL, SourceTypePostgreSQL, SourceTypeSQL:
+		default:
+			return warnings, fmt.Errorf("source.updatedAtColumn is not supported for source type %s", registry.Spec.Source.Type)
+		}
+	} else if registry.Spec.Source.FullResyncInterval != "" {
+		warnings = append(warnings, "source.fullResyncInterval has no effect without source.updatedAtColumn")
+	}
+
+	if registry.Spec.Source.Type == SourceTypeHTTP {
+		if err := validateHTTPSource(registry); err != nil {
+			return warnings, err
+		}
+	}
+
+	if registry.Spec.Source.Type == SourceTypePlugin {
+		if err := validatePluginSource(registry); err != nil {
+			return warnings, err
+		}
+	}
+
+	if registry.Spec.Source.Type == SourceTypeConfigMap {
+		cm := registry.Spec.Source.ConfigMap
+		if cm == nil {
+			return warnings, fmt.Errorf("configMap configuration is required when source type is configmap")
+		}
+		if cm.Name == "" {
+			return warnings, fmt.Errorf("configMap.name is required")
+		}
+		if cm.Key == "" {
+			return warnings, fmt.Errorf("configMap.key is required")
+		}
+	}
+
+	if registry.Spec.Source.Type == SourceTypeInline {
+		if registry.Spec.Source.Inline == nil {
+			return warnings, fmt.Errorf("inline configuration is required when source type is inline")
+		}
+		uidColumn := registry.Spec.ValueMappings.UID
+		for i, row := range registry.Spec.Source.Inline.Rows {
+			if row[uidColumn] == "" {
+				return warnings, fmt.Errorf("inline.rows[%d] has no value for the uid column %q", i, uidColumn)
+			}
+		}
+	}
+
+	return warnings, nil
+}
+
+// validateMergeSources validates each merge source like spec.source, with its own uid and extra value mappings
+func validateMergeSources(registry *LynqHub) (admission.Warnings, error) {
+	var warnings admission.Warnings
+	names := make(map[string]bool, len(registry.Spec.MergeSources))
+	relations := make(map[string]bool, len(registry.Spec.Relations))
+	for _, relation := range registry.Spec.Relations {
+		relations[relation.Name] = true
+	}
+
+	for _, source := range registry.Spec.MergeSources {
+		field := fmt.Sprintf("mergeSources[%s]", source.Name)
+		if source.Name == "" {
+			return warnings, fmt.Errorf("mergeSources[].name is required")
+		}
+		if names[source.Name] {
+			return warnings, fmt.Errorf("%s: name is used by another merge source", field)
+		}
+		names[source.Name] = true
+		if source.UID == "" {
+			return warnings, fmt.Errorf("%s.uid is required", field)
+		}
+		if len(source.ExtraValueMappings) == 0 {
+			return warnings, fmt.Errorf("%s.extraValueMappings must map at least one value", field)
+		}
+		for _, key := range sortedKeys(source.ExtraValueMappings) {
+			if relations[key] {
+				return warnings, fmt.Errorf("%s.extraValueMappings.%s is also a relation name", field, key)
+			}
+		}
+		if source.Source.UpdatedAtColumn != "" || source.Source.FullResyncInterval != "" {
+			warnings = append(warnings, fmt.Sprintf("%s: updatedAtColumn and fullResyncInterval are not used; merge sources are read in full on every sync", field))
+		}
+
+		hub := registry.MergeSourceHub(source)
+		if err := validateExtraValueTypes(hub); err != nil {
+			return warnings, fmt.Errorf("%s: %w", field, err)
+		}
+		sourceWarnings, err := validateSource(hub)
+		for _, warning := range sourceWarnings {
+			warnings = append(warnings, fmt.Sprintf("%s: %s", field, warning))
+		}
+		if err != nil {
+			return warnings, fmt.Errorf("%s: %w", field, err)
+		}
+	}
+	return warnings, nil
+}
+
+// retryWarnings reports retry settings that have no effect
+func retryWarnings(registry *LynqHub) admission.Warnings {
+	retry := registry.Spec.Retry
+	if retry == nil {
+		return nil
+	}
+	var warnings admission.Warnings
+	if retry.MaxBackoff != "" {
+		maxBackoff, maxErr := time.ParseDuration(retry.MaxBackoff)
+		syncInterval, syncErr := time.ParseDuration(registry.Spec.Source.SyncInterval)
+		if maxErr == nil && syncErr == nil && maxBackoff < syncInterval {
+			warnings = append(warnings, fmt.Sprintf("retry.maxBackoff %s is shorter than source.syncInterval %s; failed syncs are retried every %s",
+				retry.MaxBackoff, registry.Spec.Source.SyncInterval, registry.Spec.Source.SyncInterval))
+		}
+	}
+	if retry.CircuitBreakerThreshold != nil && *retry.CircuitBreakerThreshold == 0 && retry.CircuitBreakerCooldown != "" {
+		warnings = append(warnings, "retry.circuitBreakerCooldown has no effect when retry.circuitBreakerThreshold is 0")
+	}
+	return warnings
+}
+
+// sortedKeys returns the keys of a string map in sorted order
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// schemaWarnings returns the schema check messages for SQL sources
+// The check is best effort: an unreachable database, missing credentials or a check that
+// does not finish within schemaCheckTimeout produce no warnings and never block admission.
+func (v *LynqHubValidator) schemaWarnings(ctx context.Context, registry *LynqHub) admission.Warnings {
+	if v.SchemaCheck == nil {
+		return nil
+	}
+	switch registry.Spec.Source.Type {
+	case SourceTypeMySQL, SourceTypePostgreSQL, SourceTypeSQL:
+	default:
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, schemaCheckTimeout)
+	defer cancel()
+
+	// Connecting may not honor ctx; run the check in the background so admission is not held up
+	result := make(chan []string, 1)
+	hub := registry.DeepCopy()
+	go func() {
+		messages, err := v.SchemaCheck(ctx, hub)
+		if err != nil {
+			lynqhublog.V(1).Info("schema check skipped", "name", hub.Name, "error", err.Error())
+			messages = nil
+		}
+		result <- messages
+	}()
+
+	select {
+	case messages := <-result:
+		return messages
+	case <-ctx.Done():
+		lynqhublog.V(1).Info("schema check timed out", "name", registry.Name)
+		return nil
+	}
+}
+
+// validateHTTPSource validates the http source and checks that all value mappings are valid JSONPath
+func validateHTTPSource(registry *LynqHub) error {
+	h := registry.Spec.Source.HTTP
+	if h == nil {
+		return fmt.Errorf("http configuration is required when source type is http")
+	}
+	if h.URL == "" {
+		return fmt.Errorf("http.url is required")
+	}
+	u, err := url.Parse(h.URL)
+	if err != nil {
+		return fmt.Errorf("http.url is invalid: %w", err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return fmt.Errorf("http.url must use http or https (got %q)", u.Scheme)
+	}
+	if h.ItemsPath != "" {
+		if err := fieldfilter.ValidateJSONPath(h.ItemsPath); err != nil {
+			return fmt.Errorf("http.itemsPath: %w", err)
+		}
+	}
+
+	if auth := h.Auth; auth != nil {
+		switch auth.Type {
+		case HTTPAuthTypeBearer:
+			if auth.TokenRef == nil {
+				return fmt.Errorf("http.auth.tokenRef is required when auth type is bearer")
+			}
+		case HTTPAuthTypeBasic:
+			if auth.Username == "" {
+				return fmt.Errorf("http.auth.username is required when auth type is basic")
+			}
+		}
+	}
+
+	if p := h.Pagination; p != nil && p.Type == HTTPPaginationTypeCursor {
+		if p.CursorPath == "" {
+			return fmt.Errorf("http.pagination.cursorPath is required when pagination type is cursor")
+		}
+		if err := fieldfilter.ValidateJSONPath(p.CursorPath); err != nil {
+			return fmt.Errorf("http.pagination.cursorPath: %w", err)
+		}
+	}
+
+	if err := validateDatabaseTLS("http.tls", h.TLS); err != nil {
+		return err
+	}
+
+	// Value mappings are JSONPath expressions evaluated against each item
+	return validateJSONPathMappings(registry)
+}
+
+// validatePluginSource checks the plugin endpoint and the JSONPath value mappings
+func validatePluginSource(registry *LynqHub) error {
+	p := registry.Spec.Source.Plugin
+	if p == nil {
+		return fmt.Errorf("plugin configuration is required when source type is plugin")
+	}
+	if p.Endpoint == "" {
+		return fmt.Errorf("plugin.endpoint is required")
+	}
+	if err := validateDatabaseTLS("plugin.tls", p.TLS); err != nil {
+		return err
+	}
+
+	// Plugin rows are mapped like http items
+	return validateJSONPathMappings(registry)
+}
+
+// validateJSONPathMappings checks that all value mappings are valid JSONPath expressions
+func validateJSONPathMappings(registry *LynqHub) error {
+	mappings := map[string]string{
+		"valueMappings.uid": registry.Spec.ValueMappings.UID,
+	}
+	if registry.Spec.ValueMappings.Activate != "" {
+		mappings["valueMappings.activate"] = registry.Spec.ValueMappings.Activate
+	}
+	if registry.Spec.ValueMappings.HostOrURL != "" {
+		mappings["valueMappings.hostOrUrl"] = registry.Spec.ValueMappings.HostOrURL
+	}
+	for key, path := range registry.Spec.ExtraValueMappings {
+		mappings["extraValueMappings."+key] = path
+	}
+	fields := make([]string, 0, len(mappings))
+	for field := range mappings {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+	for _, field := range fields {
+		if err := fieldfilter.ValidateJSONPath(mappings[field]); err != nil {
+			return fmt.Errorf("%s: %w", field, err)
+		}
+	}
+
+	return nil
+}
+
+// filterColumnPattern restricts filter columns to plain identifiers
+var filterColumnPattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// validateActivation checks the activate column, active values and activate expression
+func validateActivation(registry *LynqHub) error {
+	vm := registry.Spec.ValueMappings
+	if vm.Activate == "" && vm.ActivateExpression == "" {
+		return fmt.Errorf("valueMappings.activate or valueMappings.activateExpression is required")
+	}
+	if len(vm.ActiveValues) > 0 && vm.Activate == "" {
+		return fmt.Errorf("valueMappings.activeValues requires valueMappings.activate")
+	}
+
+	rule, err := activation.New(vm.ActiveValues, vm.ActivateExpression)
+	if err != nil {
+		return fmt.Errorf("valueMappings: %w", err)
+	}
+
+	// SQL sources select expression columns by name, so nested paths are not allowed there
+	switch registry.Spec.Source.Type {
+	case SourceTypeMySQL, SourceTypePostgreSQL, SourceTypeSQL:
+		for _, column := range rule.Columns() {
+			if !filterColumnPattern.MatchString(column) {
+				return fmt.Errorf("valueMappings.activateExpression: column %q must be a plain column name (letters, digits and underscores)", column)
+			}
+		}
+	}
+	return nil
+}
+
+// validateExtraValueTypes checks that every typed extra value is mapped
+func validateExtraValueTypes(registry *LynqHub) error {
+	keys := make([]string, 0, len(registry.Spec.ExtraValueTypes))
+	for key := range registry.Spec.ExtraValueTypes {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		if _, ok := registry.Spec.ExtraValueMappings[key]; !ok {
+			return fmt.Errorf("extraValueTypes.%s: no extraValueMappings entry named %q", key, key)
+		}
+	}
+	return nil
+}
+
+// validateRelations checks relation names and mappings; relations need a SQL source
+func validateRelations(registry *LynqHub) error {
+	if len(registry.Spec.Relations) == 0 {
+		return nil
+	}
+	switch registry.Spec.Source.Type {
+	case SourceTypeMySQL, SourceTypePostgreSQL, SourceTypeSQL:
+	default:
+		return fmt.Errorf("relations are only supported for mysql, postgresql and sql sources")
+	}
+
+	names := make(map[string]bool, len(registry.Spec.Relations))
+	for i, relation := range registry.Spec.Relations {
+		field := fmt.Sprintf("relations[%d]", i)
+		if !filterColumnPattern.MatchString(relation.Name) {
+			return fmt.Errorf("%s.name %q must be a plain identifier (letters, digits and underscores)", field, relation.Name)
+		}
+		if names[relation.Name] {
+			return fmt.Errorf("%s.name %q is used by another relation", field, relation.Name)
+		}
+		names[relation.Name] = true
+		if _, ok := registry.Spec.ExtraValueMappings[relation.Name]; ok {
+			return fmt.Errorf("%s.name %q is also an extraValueMappings key", field, relation.Name)
+		}
+		if relation.Table == "" {
+			return fmt.Errorf("%s.table is required", field)
+		}
+		if relation.ForeignKey == "" {
+			return fmt.Errorf("%s.foreignKey is required", field)
+		}
+		if len(relation.ValueMappings) == 0 {
+			return fmt.Errorf("%s.valueMappings must map at least one column", field)
+		}
+		for key, column := range relation.ValueMappings {
+			if column == "" {
+				return fmt.Errorf("%s.valueMappings.%s: column is required", field, key)
+			}
+		}
+	}
+	return nil
+}
+
+// validateMySQLConnection checks the TLS, driver parameter and pool settings of a MySQL source
+func validateMySQLConnection(mysql *MySQLSource) (admission.Warnings, error) {
+	var warnings admission.Warnings
+
+	if err := validateDatabaseTLS("mysql.tls", mysql.TLS); err != nil {
+		return warnings, err
+	}
+	if mysql.TLS != nil && mysql.TLSMode == MySQLTLSModeDisable {
+		warnings = append(warnings, "mysql.tls is ignored because tlsMode is disable")
+	}
+	if mysql.TLS != nil && mysql.TLS.CASecretRef != nil &&
+		mysql.TLSMode != MySQLTLSModeVerifyCA && mysql.TLSMode != MySQLTLSModeVerifyFull {
+		warnings = append(warnings,
+			fmt.Sprintf("mysql.tls.caSecretRef is only used to verify the server when tlsMode is verify-ca or verify-full (current: %s)", mysql.TLSMode))
+	}
+
+	// tls and parseTime are set by the operator; everything else is passed to the driver as-is
+	for _, key := range []string{"tls", "parseTime"} {
+		if _, ok := mysql.Params[key]; ok {
+			return warnings, fmt.Errorf("mysql.params.%s is managed by the operator and cannot be set", key)
+		}
+	}
+	for key := range mysql.Params {
+		if key == "" {
+			return warnings, fmt.Errorf("mysql.params keys must not be empty")
+		}
+	}
+
+	seen := map[string]string{net.JoinHostPort(mysql.Host, strconv.Itoa(int(mysql.Port))): "mysql.host"}
+	for _, list := range []struct {
+		field     string
+		endpoints []MySQLEndpoint
+	}{{"mysql.failoverHosts", mysql.FailoverHosts}, {"mysql.replicas", mysql.Replicas}} {
+		for i, endpoint := range list.endpoints {
+			field := fmt.Sprintf("%s[%d]", list.field, i)
+			if endpoint.Host == "" {
+				return warnings, fmt.Errorf("%s.host is required", field)
+			}
+			addr := net.JoinHostPort(endpoint.Host, strconv.Itoa(int(endpoint.Port)))
+			if other, ok := seen[addr]; ok {
+				return warnings, fmt.Errorf("%s duplicates %s (%s)", field, other, addr)
+			}
+			seen[addr] = field
+		}
+	}
+	if mysql.ReadPreference == MySQLReadPreferencePrimary && len(mysql.Replicas) == 0 {
+		warnings = append(warnings, "mysql.readPreference has no effect without mysql.replicas")
+	}
+
+	if pool := mysql.Pool; pool != nil {
+		if pool.MaxOpenConns > 0 && pool.MaxIdleConns > pool.MaxOpenConns {
+			warnings = append(warnings,
+				fmt.Sprintf("mysql.pool.maxIdleConns (%d) is greater than maxOpenConns (%d) and will be reduced to it", pool.MaxIdleConns, pool.MaxOpenConns))
+		}
 	}
 
 	return warnings, nil
 }
+
+// sqlDriverDialects lists the database/sql drivers whose dialect is inferred when dialect is omitted
+var sqlDriverDialects = map[string]SQLDialect{
+	"mysql":    SQLDialectMySQL,
+	"pgx":      SQLDialectPostgreSQL,
+	"pgx/v5":   SQLDialectPostgreSQL,
+	"postgres": SQLDialectPostgreSQL,
+	"sqlite":   SQLDialectSQLite,
+	"sqlite3":  SQLDialectSQLite,
+}
+
+// validateSQLSource checks the driver, DSN and table of a generic sql source
+func validateSQLSource(source *SQLSource) (admission.Warnings, error) {
+	var warnings admission.Warnings
+
+	if source == nil {
+		return warnings, fmt.Errorf("sql configuration is required when source type is sql")
+	}
+	if source.Driver == "" {
+		return warnings, fmt.Errorf("sql.driver is required")
+	}
+	if _, ok := sqlDriverDialects[source.Driver]; !ok && source.Dialect == "" {
+		return warnings, fmt.Errorf("sql.dialect is required for driver %q", source.Driver)
+	}
+	if (source.DSN == "") == (source.DSNSecretRef == nil) {
+		return warnings, fmt.Errorf("exactly one of sql.dsn and sql.dsnSecretRef must be set")
+	}
+	if source.Table == "" {
+		return warnings, fmt.Errorf("sql.table is required")
+	}
+	for key := range source.Params {
+		if key == "" {
+			return warnings, fmt.Errorf("sql.params keys must not be empty")
+		}
+	}
+	if err := validateRowFilters("sql.filter", source.Filter); err != nil {
+		return warnings, err
+	}
+
+	if pool := source.Pool; pool != nil {
+		if pool.MaxOpenConns > 0 && pool.MaxIdleConns > pool.MaxOpenConns {
+			warnings = append(warnings,
+				fmt.Sprintf("sql.pool.maxIdleConns (%d) is greater than maxOpenConns (%d) and will be reduced to it", pool.MaxIdleConns, pool.MaxOpenConns))
+		}
+	}
+
+	return warnings, nil
+}
+
+// validateRowFilters checks filter columns and that each operator has the operands it needs
+// Values are bound as query parameters, so only column names need to be restricted.
+func validateRowFilters(field string, filters []RowFilter) error {
+	for i, f := range filters {
+		path := fmt.Sprintf("%s[%d]", field, i)
+		if !filterColumnPattern.MatchString(f.Column) {
+			return fmt.Errorf("%s.column %q must be a plain column name (letters, digits and underscores)", path, f.Column)
+		}
+
+		switch f.Operator {
+		case RowFilterOperatorEq, RowFilterOperatorNe, RowFilterOperatorLt, RowFilterOperatorLe,
+			RowFilterOperatorGt, RowFilterOperatorGe, RowFilterOperatorLike:
+			if len(f.Values) > 0 {
+				return fmt.Errorf("%s: operator %s takes value, not values", path, f.Operator)
+			}
+		case RowFilterOperatorIn, RowFilterOperatorNotIn:
+			if len(f.Values) == 0 {
+				return fmt.Errorf("%s: operator %s requires at least one entry in values", path, f.Operator)
+			}
+			if f.Value != "" {
+				return fmt.Errorf("%s: operator %s takes values, not value", path, f.Operator)
+			}
+		case RowFilterOperatorIsNull, RowFilterOperatorIsNotNull:
+			if f.Value != "" || len(f.Values) > 0 {
+				return fmt.Errorf("%s: operator %s takes no value", path, f.Operator)
+			}
+		default:
+			return fmt.Errorf("%s: unsupported operator %q", path, f.Operator)
+		}
+	}
+	return nil
+}
+
+// validateDatabaseTLS checks that client certificate and key references are configured as a pair
+func validateDatabaseTLS(field string, tls *DatabaseTLS) error {
+	if tls == nil {
+		return nil
+	}
+	if (tls.ClientCertSecretRef == nil) != (tls.ClientKeySecretRef == nil) {
+		return fmt.Errorf("%s.clientCertSecretRef and %s.clientKeySecretRef must be set together", field, field)
+	}
+	return nil
+}
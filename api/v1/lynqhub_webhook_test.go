@@ -0,0 +1,61 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestLynqHubValidator_SchemaCheckOnUpdate(t *testing.T) {
+	calls := 0
+	v := &LynqHubValidator{SchemaCheck: func(ctx context.Context, hub *LynqHub) ([]string, error) {
+		calls++
+		return []string{"column `plan` not found in `tenants`"}, nil
+	}}
+	old := &LynqHub{
+		ObjectMeta: metav1.ObjectMeta{Name: "billing", Namespace: "default", Generation: 1},
+		Spec: LynqHubSpec{
+			Source: DataSource{
+				Type:         SourceTypeMySQL,
+				SyncInterval: "1m",
+				MySQL:        &MySQLSource{Host: "db", Username: "lynq", Database: "crm", Table: "tenants"},
+			},
+			ValueMappings: ValueMappings{UID: "id", Activate: "active"},
+		},
+	}
+
+	// The controller adds its finalizer with a metadata-only update
+	updated := old.DeepCopy()
+	updated.Finalizers = []string{"lynq.sh/hub-finalizer"}
+	warnings, err := v.ValidateUpdate(context.Background(), old, updated)
+	require.NoError(t, err)
+	assert.Empty(t, warnings)
+	assert.Zero(t, calls, "metadata-only updates do not connect to the database")
+
+	// Changing a mapping checks the schema again
+	changed := updated.DeepCopy()
+	changed.Spec.ExtraValueMappings = map[string]string{"plan": "plan"}
+	warnings, err = v.ValidateUpdate(context.Background(), updated, changed)
+	require.NoError(t, err)
+	assert.Equal(t, 1, calls)
+	assert.Contains(t, warnings, "column `plan` not found in `tenants`")
+}
@@ -25,6 +25,63 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConfigMapSource) DeepCopyInto(out *ConfigMapSource) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConfigMapSource.
+func (in *ConfigMapSource) DeepCopy() *ConfigMapSource {
+	if in == nil {
+		return nil
+	}
+	out := new(ConfigMapSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConnectionPool) DeepCopyInto(out *ConnectionPool) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConnectionPool.
+func (in *ConnectionPool) DeepCopy() *ConnectionPool {
+	if in == nil {
+		return nil
+	}
+	out := new(ConnectionPool)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DataSnapshotStatus) DeepCopyInto(out *DataSnapshotStatus) {
+	*out = *in
+	if in.LastSuccessfulSyncTime != nil {
+		in, out := &in.LastSuccessfulSyncTime, &out.LastSuccessfulSyncTime
+		*out = (*in).DeepCopy()
+	}
+	if in.LastFailureTime != nil {
+		in, out := &in.LastFailureTime, &out.LastFailureTime
+		*out = (*in).DeepCopy()
+	}
+	if in.NextRetryTime != nil {
+		in, out := &in.NextRetryTime, &out.NextRetryTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DataSnapshotStatus.
+func (in *DataSnapshotStatus) DeepCopy() *DataSnapshotStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(DataSnapshotStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DataSource) DeepCopyInto(out *DataSource) {
 	*out = *in
@@ -33,6 +90,36 @@ func (in *DataSource) DeepCopyInto(out *DataSource) {
 		*out = new(MySQLSource)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.PostgreSQL != nil {
+		in, out := &in.PostgreSQL, &out.PostgreSQL
+		*out = new(PostgreSQLSource)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SQL != nil {
+		in, out := &in.SQL, &out.SQL
+		*out = new(SQLSource)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.HTTP != nil {
+		in, out := &in.HTTP, &out.HTTP
+		*out = new(HTTPSource)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ConfigMap != nil {
+		in, out := &in.ConfigMap, &out.ConfigMap
+		*out = new(ConfigMapSource)
+		**out = **in
+	}
+	if in.Inline != nil {
+		in, out := &in.Inline, &out.Inline
+		*out = new(InlineSource)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Plugin != nil {
+		in, out := &in.Plugin, &out.Plugin
+		*out = new(PluginSource)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DataSource.
@@ -45,6 +132,209 @@ func (in *DataSource) DeepCopy() *DataSource {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DatabaseTLS) DeepCopyInto(out *DatabaseTLS) {
+	*out = *in
+	if in.CASecretRef != nil {
+		in, out := &in.CASecretRef, &out.CASecretRef
+		*out = new(SecretRef)
+		**out = **in
+	}
+	if in.ClientCertSecretRef != nil {
+		in, out := &in.ClientCertSecretRef, &out.ClientCertSecretRef
+		*out = new(SecretRef)
+		**out = **in
+	}
+	if in.ClientKeySecretRef != nil {
+		in, out := &in.ClientKeySecretRef, &out.ClientKeySecretRef
+		*out = new(SecretRef)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DatabaseTLS.
+func (in *DatabaseTLS) DeepCopy() *DatabaseTLS {
+	if in == nil {
+		return nil
+	}
+	out := new(DatabaseTLS)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DeletionGuard) DeepCopyInto(out *DeletionGuard) {
+	*out = *in
+	if in.MaxDeletions != nil {
+		in, out := &in.MaxDeletions, &out.MaxDeletions
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MaxDeletionPercent != nil {
+		in, out := &in.MaxDeletionPercent, &out.MaxDeletionPercent
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeletionGuard.
+func (in *DeletionGuard) DeepCopy() *DeletionGuard {
+	if in == nil {
+		return nil
+	}
+	out := new(DeletionGuard)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FormSyncStatus) DeepCopyInto(out *FormSyncStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FormSyncStatus.
+func (in *FormSyncStatus) DeepCopy() *FormSyncStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(FormSyncStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HTTPAuth) DeepCopyInto(out *HTTPAuth) {
+	*out = *in
+	if in.TokenRef != nil {
+		in, out := &in.TokenRef, &out.TokenRef
+		*out = new(SecretRef)
+		**out = **in
+	}
+	if in.PasswordRef != nil {
+		in, out := &in.PasswordRef, &out.PasswordRef
+		*out = new(SecretRef)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HTTPAuth.
+func (in *HTTPAuth) DeepCopy() *HTTPAuth {
+	if in == nil {
+		return nil
+	}
+	out := new(HTTPAuth)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HTTPPagination) DeepCopyInto(out *HTTPPagination) {
+	*out = *in
+	if in.StartPage != nil {
+		in, out := &in.StartPage, &out.StartPage
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HTTPPagination.
+func (in *HTTPPagination) DeepCopy() *HTTPPagination {
+	if in == nil {
+		return nil
+	}
+	out := new(HTTPPagination)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HTTPSource) DeepCopyInto(out *HTTPSource) {
+	*out = *in
+	if in.Headers != nil {
+		in, out := &in.Headers, &out.Headers
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Auth != nil {
+		in, out := &in.Auth, &out.Auth
+		*out = new(HTTPAuth)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Pagination != nil {
+		in, out := &in.Pagination, &out.Pagination
+		*out = new(HTTPPagination)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TLS != nil {
+		in, out := &in.TLS, &out.TLS
+		*out = new(DatabaseTLS)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HTTPSource.
+func (in *HTTPSource) DeepCopy() *HTTPSource {
+	if in == nil {
+		return nil
+	}
+	out := new(HTTPSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IncrementalSyncStatus) DeepCopyInto(out *IncrementalSyncStatus) {
+	*out = *in
+	if in.Watermark != nil {
+		in, out := &in.Watermark, &out.Watermark
+		*out = (*in).DeepCopy()
+	}
+	if in.LastFullSyncTime != nil {
+		in, out := &in.LastFullSyncTime, &out.LastFullSyncTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IncrementalSyncStatus.
+func (in *IncrementalSyncStatus) DeepCopy() *IncrementalSyncStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(IncrementalSyncStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InlineSource) DeepCopyInto(out *InlineSource) {
+	*out = *in
+	if in.Rows != nil {
+		in, out := &in.Rows, &out.Rows
+		*out = make([]map[string]string, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = make(map[string]string, len(*in))
+				for key, val := range *in {
+					(*out)[key] = val
+				}
+			}
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InlineSource.
+func (in *InlineSource) DeepCopy() *InlineSource {
+	if in == nil {
+		return nil
+	}
+	out := new(InlineSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *LynqForm) DeepCopyInto(out *LynqForm) {
 	*out = *in
@@ -239,6 +529,11 @@ func (in *LynqFormSpec) DeepCopyInto(out *LynqFormSpec) {
 		*out = new(RolloutConfig)
 		**out = **in
 	}
+	if in.RowSelector != nil {
+		in, out := &in.RowSelector, &out.RowSelector
+		*out = new(RowSelector)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LynqFormSpec.
@@ -371,7 +666,7 @@ func (in *LynqHubList) DeepCopyObject() runtime.Object {
 func (in *LynqHubSpec) DeepCopyInto(out *LynqHubSpec) {
 	*out = *in
 	in.Source.DeepCopyInto(&out.Source)
-	out.ValueMappings = in.ValueMappings
+	in.ValueMappings.DeepCopyInto(&out.ValueMappings)
 	if in.ExtraValueMappings != nil {
 		in, out := &in.ExtraValueMappings, &out.ExtraValueMappings
 		*out = make(map[string]string, len(*in))
@@ -379,6 +674,37 @@ func (in *LynqHubSpec) DeepCopyInto(out *LynqHubSpec) {
 			(*out)[key] = val
 		}
 	}
+	if in.ExtraValueTypes != nil {
+		in, out := &in.ExtraValueTypes, &out.ExtraValueTypes
+		*out = make(map[string]ExtraValueType, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Relations != nil {
+		in, out := &in.Relations, &out.Relations
+		*out = make([]Relation, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.DeletionGuard != nil {
+		in, out := &in.DeletionGuard, &out.DeletionGuard
+		*out = new(DeletionGuard)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.MergeSources != nil {
+		in, out := &in.MergeSources, &out.MergeSources
+		*out = make([]MergeSource, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Retry != nil {
+		in, out := &in.Retry, &out.Retry
+		*out = new(SyncRetryPolicy)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LynqHubSpec.
@@ -394,6 +720,55 @@ func (in *LynqHubSpec) DeepCopy() *LynqHubSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *LynqHubStatus) DeepCopyInto(out *LynqHubStatus) {
 	*out = *in
+	if in.Forms != nil {
+		in, out := &in.Forms, &out.Forms
+		*out = make([]FormSyncStatus, len(*in))
+		copy(*out, *in)
+	}
+	if in.LastSyncTime != nil {
+		in, out := &in.LastSyncTime, &out.LastSyncTime
+		*out = (*in).DeepCopy()
+	}
+	if in.LastSyncDuration != nil {
+		in, out := &in.LastSyncDuration, &out.LastSyncDuration
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.LastSyncChanges != nil {
+		in, out := &in.LastSyncChanges, &out.LastSyncChanges
+		*out = new(SyncChanges)
+		**out = **in
+	}
+	if in.IncrementalSync != nil {
+		in, out := &in.IncrementalSync, &out.IncrementalSync
+		*out = new(IncrementalSyncStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Snapshot != nil {
+		in, out := &in.Snapshot, &out.Snapshot
+		*out = new(DataSnapshotStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RejectedRows != nil {
+		in, out := &in.RejectedRows, &out.RejectedRows
+		*out = make([]RejectedRow, len(*in))
+		copy(*out, *in)
+	}
+	if in.MergeSources != nil {
+		in, out := &in.MergeSources, &out.MergeSources
+		*out = make([]MergeSourceStatus, len(*in))
+		copy(*out, *in)
+	}
+	if in.NodeWriteFailures != nil {
+		in, out := &in.NodeWriteFailures, &out.NodeWriteFailures
+		*out = make([]NodeWriteFailure, len(*in))
+		copy(*out, *in)
+	}
+	if in.SchemaIssues != nil {
+		in, out := &in.SchemaIssues, &out.SchemaIssues
+		*out = make([]SchemaIssue, len(*in))
+		copy(*out, *in)
+	}
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
 		*out = make([]metav1.Condition, len(*in))
@@ -413,21 +788,6 @@ func (in *LynqHubStatus) DeepCopy() *LynqHubStatus {
 	return out
 }
 
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *LynqHubValidator) DeepCopyInto(out *LynqHubValidator) {
-	*out = *in
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LynqHubValidator.
-func (in *LynqHubValidator) DeepCopy() *LynqHubValidator {
-	if in == nil {
-		return nil
-	}
-	out := new(LynqHubValidator)
-	in.DeepCopyInto(out)
-	return out
-}
-
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *LynqNode) DeepCopyInto(out *LynqNode) {
 	*out = *in
@@ -650,14 +1010,108 @@ func (in *LynqNodeStatus) DeepCopy() *LynqNodeStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MergeSource) DeepCopyInto(out *MergeSource) {
+	*out = *in
+	in.Source.DeepCopyInto(&out.Source)
+	if in.ExtraValueMappings != nil {
+		in, out := &in.ExtraValueMappings, &out.ExtraValueMappings
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.ExtraValueTypes != nil {
+		in, out := &in.ExtraValueTypes, &out.ExtraValueTypes
+		*out = make(map[string]ExtraValueType, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MergeSource.
+func (in *MergeSource) DeepCopy() *MergeSource {
+	if in == nil {
+		return nil
+	}
+	out := new(MergeSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MergeSourceStatus) DeepCopyInto(out *MergeSourceStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MergeSourceStatus.
+func (in *MergeSourceStatus) DeepCopy() *MergeSourceStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(MergeSourceStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MySQLEndpoint) DeepCopyInto(out *MySQLEndpoint) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MySQLEndpoint.
+func (in *MySQLEndpoint) DeepCopy() *MySQLEndpoint {
+	if in == nil {
+		return nil
+	}
+	out := new(MySQLEndpoint)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *MySQLSource) DeepCopyInto(out *MySQLSource) {
 	*out = *in
+	if in.FailoverHosts != nil {
+		in, out := &in.FailoverHosts, &out.FailoverHosts
+		*out = make([]MySQLEndpoint, len(*in))
+		copy(*out, *in)
+	}
+	if in.Replicas != nil {
+		in, out := &in.Replicas, &out.Replicas
+		*out = make([]MySQLEndpoint, len(*in))
+		copy(*out, *in)
+	}
 	if in.PasswordRef != nil {
 		in, out := &in.PasswordRef, &out.PasswordRef
 		*out = new(SecretRef)
 		**out = **in
 	}
+	if in.Filter != nil {
+		in, out := &in.Filter, &out.Filter
+		*out = make([]RowFilter, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.TLS != nil {
+		in, out := &in.TLS, &out.TLS
+		*out = new(DatabaseTLS)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Params != nil {
+		in, out := &in.Params, &out.Params
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Pool != nil {
+		in, out := &in.Pool, &out.Pool
+		*out = new(ConnectionPool)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MySQLSource.
@@ -670,6 +1124,122 @@ func (in *MySQLSource) DeepCopy() *MySQLSource {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeWriteFailure) DeepCopyInto(out *NodeWriteFailure) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeWriteFailure.
+func (in *NodeWriteFailure) DeepCopy() *NodeWriteFailure {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeWriteFailure)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PluginSource) DeepCopyInto(out *PluginSource) {
+	*out = *in
+	if in.Config != nil {
+		in, out := &in.Config, &out.Config
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.TokenRef != nil {
+		in, out := &in.TokenRef, &out.TokenRef
+		*out = new(SecretRef)
+		**out = **in
+	}
+	if in.TLS != nil {
+		in, out := &in.TLS, &out.TLS
+		*out = new(DatabaseTLS)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PluginSource.
+func (in *PluginSource) DeepCopy() *PluginSource {
+	if in == nil {
+		return nil
+	}
+	out := new(PluginSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PostgreSQLSource) DeepCopyInto(out *PostgreSQLSource) {
+	*out = *in
+	if in.PasswordRef != nil {
+		in, out := &in.PasswordRef, &out.PasswordRef
+		*out = new(SecretRef)
+		**out = **in
+	}
+	if in.Filter != nil {
+		in, out := &in.Filter, &out.Filter
+		*out = make([]RowFilter, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.TLS != nil {
+		in, out := &in.TLS, &out.TLS
+		*out = new(DatabaseTLS)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PostgreSQLSource.
+func (in *PostgreSQLSource) DeepCopy() *PostgreSQLSource {
+	if in == nil {
+		return nil
+	}
+	out := new(PostgreSQLSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RejectedRow) DeepCopyInto(out *RejectedRow) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RejectedRow.
+func (in *RejectedRow) DeepCopy() *RejectedRow {
+	if in == nil {
+		return nil
+	}
+	out := new(RejectedRow)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Relation) DeepCopyInto(out *Relation) {
+	*out = *in
+	if in.ValueMappings != nil {
+		in, out := &in.ValueMappings, &out.ValueMappings
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Relation.
+func (in *Relation) DeepCopy() *Relation {
+	if in == nil {
+		return nil
+	}
+	out := new(Relation)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RolloutConfig) DeepCopyInto(out *RolloutConfig) {
 	*out = *in
@@ -708,6 +1278,129 @@ func (in *RolloutStatus) DeepCopy() *RolloutStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RowFilter) DeepCopyInto(out *RowFilter) {
+	*out = *in
+	if in.Values != nil {
+		in, out := &in.Values, &out.Values
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RowFilter.
+func (in *RowFilter) DeepCopy() *RowFilter {
+	if in == nil {
+		return nil
+	}
+	out := new(RowFilter)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RowSelector) DeepCopyInto(out *RowSelector) {
+	*out = *in
+	if in.MatchValues != nil {
+		in, out := &in.MatchValues, &out.MatchValues
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.MatchExpressions != nil {
+		in, out := &in.MatchExpressions, &out.MatchExpressions
+		*out = make([]RowSelectorRequirement, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RowSelector.
+func (in *RowSelector) DeepCopy() *RowSelector {
+	if in == nil {
+		return nil
+	}
+	out := new(RowSelector)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RowSelectorRequirement) DeepCopyInto(out *RowSelectorRequirement) {
+	*out = *in
+	if in.Values != nil {
+		in, out := &in.Values, &out.Values
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RowSelectorRequirement.
+func (in *RowSelectorRequirement) DeepCopy() *RowSelectorRequirement {
+	if in == nil {
+		return nil
+	}
+	out := new(RowSelectorRequirement)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SQLSource) DeepCopyInto(out *SQLSource) {
+	*out = *in
+	if in.DSNSecretRef != nil {
+		in, out := &in.DSNSecretRef, &out.DSNSecretRef
+		*out = new(SecretRef)
+		**out = **in
+	}
+	if in.Params != nil {
+		in, out := &in.Params, &out.Params
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Filter != nil {
+		in, out := &in.Filter, &out.Filter
+		*out = make([]RowFilter, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Pool != nil {
+		in, out := &in.Pool, &out.Pool
+		*out = new(ConnectionPool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SQLSource.
+func (in *SQLSource) DeepCopy() *SQLSource {
+	if in == nil {
+		return nil
+	}
+	out := new(SQLSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SchemaIssue) DeepCopyInto(out *SchemaIssue) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SchemaIssue.
+func (in *SchemaIssue) DeepCopy() *SchemaIssue {
+	if in == nil {
+		return nil
+	}
+	out := new(SchemaIssue)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SecretRef) DeepCopyInto(out *SecretRef) {
 	*out = *in
@@ -723,6 +1416,41 @@ func (in *SecretRef) DeepCopy() *SecretRef {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SyncChanges) DeepCopyInto(out *SyncChanges) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SyncChanges.
+func (in *SyncChanges) DeepCopy() *SyncChanges {
+	if in == nil {
+		return nil
+	}
+	out := new(SyncChanges)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SyncRetryPolicy) DeepCopyInto(out *SyncRetryPolicy) {
+	*out = *in
+	if in.CircuitBreakerThreshold != nil {
+		in, out := &in.CircuitBreakerThreshold, &out.CircuitBreakerThreshold
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SyncRetryPolicy.
+func (in *SyncRetryPolicy) DeepCopy() *SyncRetryPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(SyncRetryPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TResource) DeepCopyInto(out *TResource) {
 	*out = *in
@@ -776,6 +1504,11 @@ func (in *TResource) DeepCopy() *TResource {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ValueMappings) DeepCopyInto(out *ValueMappings) {
 	*out = *in
+	if in.ActiveValues != nil {
+		in, out := &in.ActiveValues, &out.ActiveValues
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ValueMappings.
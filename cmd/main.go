@@ -231,11 +231,12 @@ func main() {
 		os.Exit(1)
 	}
 
-	if err := (&controller.LynqHubReconciler{
+	hubReconciler := &controller.LynqHubReconciler{
 		Client:   mgr.GetClient(),
 		Scheme:   mgr.GetScheme(),
 		Recorder: mgr.GetEventRecorderFor("lynqhub-controller"),
-	}).SetupWithManager(mgr, hubConcurrency); err != nil {
+	}
+	if err := hubReconciler.SetupWithManager(mgr, hubConcurrency); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "LynqHub")
 		os.Exit(1)
 	}
@@ -274,7 +275,7 @@ func main() {
 
 	// Setup webhooks (always enabled for validation/defaulting with TLS)
 	setupLog.Info("Setting up webhooks with TLS")
-	if err := (&lynqv1.LynqHub{}).SetupWebhookWithManager(mgr); err != nil {
+	if err := (&lynqv1.LynqHub{}).SetupWebhookWithManager(mgr, hubReconciler.SchemaWarnings); err != nil {
 		setupLog.Error(err, "unable to create webhook", "webhook", "LynqHub")
 		os.Exit(1)
 	}
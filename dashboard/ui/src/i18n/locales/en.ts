@@ -98,6 +98,15 @@ const en = {
     userSecret: "User Secret",
     passwordSecret: "Password Secret",
     lastSync: "Last Sync",
+    lastSyncDuration: "took {{duration}}",
+    lastSyncError: "Last Sync Error",
+    created: "Created",
+    updated: "Updated",
+    deleted: "Deleted",
+    activeRows: "Active Rows",
+    rejectedRows: "Rejected Rows",
+    formBreakdown: "Forms",
+    throttled: "Throttled",
     valueMappings: "Value Mappings",
     requiredMappings: "Required Mappings",
     uidColumn: "UID Column",
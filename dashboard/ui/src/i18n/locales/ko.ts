@@ -98,6 +98,15 @@ const ko = {
     userSecret: "사용자 시크릿",
     passwordSecret: "비밀번호 시크릿",
     lastSync: "마지막 동기화",
+    lastSyncDuration: "소요 시간 {{duration}}",
+    lastSyncError: "마지막 동기화 오류",
+    created: "생성",
+    updated: "업데이트",
+    deleted: "삭제",
+    activeRows: "활성 행",
+    rejectedRows: "거부된 행",
+    formBreakdown: "폼",
+    throttled: "제한됨",
     valueMappings: "값 매핑",
     requiredMappings: "필수 매핑",
     uidColumn: "UID 컬럼",
@@ -294,10 +294,48 @@ export function HubDetail() {
                   <p className="text-sm text-muted-foreground">{t('hubs.lastSync')}</p>
                   <p className="text-sm">
                     {new Date(hub.status.lastSyncTime).toLocaleString()}
+                    {hub.status.lastSyncDuration && (
+                      <span className="text-muted-foreground">
+                        {' '}
+                        ({t('hubs.lastSyncDuration', { duration: hub.status.lastSyncDuration })})
+                      </span>
+                    )}
                   </p>
                 </div>
               </div>
             )}
+            {hub.status?.lastSyncChanges && (
+              <div className="grid grid-cols-3 gap-4">
+                <div>
+                  <p className="text-sm text-muted-foreground">{t('hubs.created')}</p>
+                  <p className="font-mono text-sm">{hub.status.lastSyncChanges.created}</p>
+                </div>
+                <div>
+                  <p className="text-sm text-muted-foreground">{t('hubs.updated')}</p>
+                  <p className="font-mono text-sm">{hub.status.lastSyncChanges.updated}</p>
+                </div>
+                <div>
+                  <p className="text-sm text-muted-foreground">{t('hubs.deleted')}</p>
+                  <p className="font-mono text-sm">{hub.status.lastSyncChanges.deleted}</p>
+                </div>
+              </div>
+            )}
+            <div className="grid grid-cols-2 gap-4">
+              <div>
+                <p className="text-sm text-muted-foreground">{t('hubs.activeRows')}</p>
+                <p className="font-mono text-sm">{hub.status?.snapshot?.rowCount || 0}</p>
+              </div>
+              <div>
+                <p className="text-sm text-muted-foreground">{t('hubs.rejectedRows')}</p>
+                <p className="font-mono text-sm">{hub.status?.rejectedRowCount || 0}</p>
+              </div>
+            </div>
+            {hub.status?.lastSyncError && (
+              <div className="p-2 rounded bg-rose-500/10">
+                <p className="text-sm text-rose-500">{t('hubs.lastSyncError')}</p>
+                <p className="font-mono text-xs break-all">{hub.status.lastSyncError}</p>
+              </div>
+            )}
           </CardContent>
         </Card>
 
@@ -343,6 +381,45 @@ export function HubDetail() {
         </Card>
       </div>
 
+      {/* Per-form breakdown */}
+      {hub.status?.forms && hub.status.forms.length > 0 && (
+        <Card>
+          <CardHeader>
+            <CardTitle>{t('hubs.formBreakdown')}</CardTitle>
+          </CardHeader>
+          <CardContent className="space-y-2">
+            {hub.status.forms.map((form) => (
+              <Link
+                key={form.name}
+                to={`/forms/${form.name}?namespace=${hub.metadata.namespace}`}
+                className="flex items-center justify-between p-3 rounded-lg border hover:bg-accent transition-colors"
+              >
+                <div className="flex items-center gap-3">
+                  <IconFileCode size={16} className="text-muted-foreground" />
+                  <p className="font-medium">{form.name}</p>
+                </div>
+                <div className="flex items-center gap-4 text-sm">
+                  <span className="text-blue-500">
+                    {form.desired} {t('hubs.desired')}
+                  </span>
+                  <span className="text-emerald-500">
+                    {form.ready} {t('status.ready')}
+                  </span>
+                  <span className="text-rose-500">
+                    {form.failed} {t('status.failed')}
+                  </span>
+                  {!!form.throttled && (
+                    <span className="text-amber-500">
+                      {form.throttled} {t('hubs.throttled')}
+                    </span>
+                  )}
+                </div>
+              </Link>
+            ))}
+          </CardContent>
+        </Card>
+      )}
+
       {/* Conditions */}
       {hub.status?.conditions && hub.status.conditions.length > 0 && (
         <Card>
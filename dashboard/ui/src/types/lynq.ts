@@ -36,6 +36,7 @@ export interface LynqHubSpec {
   }
   valueMappings: ValueMappings
   extraValueMappings?: ExtraValueMapping[]
+  suspend?: boolean
 }
 
 export interface SecretKeyRef {
@@ -53,12 +54,46 @@ export interface ExtraValueMapping {
   variable: string
 }
 
+// Per-form breakdown of the hub's node counts
+export interface FormSyncStatus {
+  name: string
+  desired: number
+  ready: number
+  failed: number
+  throttled?: number
+}
+
+// LynqNodes changed by a sync
+export interface SyncChanges {
+  created: number
+  updated: number
+  deleted: number
+}
+
+export interface DataSnapshotStatus {
+  lastSuccessfulSyncTime?: string
+  rowCount?: number
+  hash?: string
+  consecutiveFailures?: number
+  lastFailureTime?: string
+  lastError?: string
+  nextRetryTime?: string
+}
+
 export interface LynqHubStatus {
+  observedGeneration?: number
   referencingTemplates: number
   desired: number
   ready: number
   failed: number
+  forms?: FormSyncStatus[]
   lastSyncTime?: string
+  lastSyncDuration?: string // Go duration, e.g. "1.234s"
+  lastSyncChanges?: SyncChanges
+  lastSyncError?: string
+  snapshot?: DataSnapshotStatus
+  rejectedRowCount?: number
+  nodeWriteFailureCount?: number
   conditions: Condition[]
 }
 
@@ -105,6 +140,7 @@ export interface LynqFormSpec {
   networkPolicies?: TResource[]
   horizontalPodAutoscalers?: TResource[]
   manifests?: TResource[]
+  suspend?: boolean
 }
 
 export interface LynqFormStatus {
@@ -0,0 +1,120 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package activation decides whether a datasource row is active.
+//
+// Three modes are supported, in order of precedence:
+//   - an expression over row columns, e.g. `status in ('active','trial')` or `deleted_at IS NULL`
+//   - an explicit set of active values for the activate column
+//   - the default truthy values ("1", "true", "yes" in common casings)
+package activation
+
+import (
+	"fmt"
+	"sort"
+)
+
+// Lookup returns the value of a column for the current row.
+// ok is false when the column is NULL or missing.
+type Lookup func(column string) (value string, ok bool)
+
+// Rule decides whether a row is active
+type Rule struct {
+	values map[string]struct{}
+	expr   node
+}
+
+// New creates an activation rule from a set of active values or an expression.
+// Setting both is an error; setting neither yields the default truthy rule.
+func New(activeValues []string, expression string) (*Rule, error) {
+	if len(activeValues) > 0 && expression != "" {
+		return nil, fmt.Errorf("activeValues and activateExpression are mutually exclusive")
+	}
+
+	rule := &Rule{}
+	if expression != "" {
+		expr, err := parse(expression)
+		if err != nil {
+			return nil, fmt.Errorf("invalid activate expression %q: %w", expression, err)
+		}
+		rule.expr = expr
+	}
+	if len(activeValues) > 0 {
+		rule.values = make(map[string]struct{}, len(activeValues))
+		for _, value := range activeValues {
+			rule.values[value] = struct{}{}
+		}
+	}
+	return rule, nil
+}
+
+// Validate checks that an activate expression parses
+// This is a convenience function for webhook validation without creating a Rule
+func Validate(expression string) error {
+	_, err := New(nil, expression)
+	return err
+}
+
+// Columns returns the sorted, de-duplicated columns referenced by the expression
+func (r *Rule) Columns() []string {
+	if r == nil || r.expr == nil {
+		return nil
+	}
+	set := map[string]struct{}{}
+	r.expr.columns(set)
+
+	columns := make([]string, 0, len(set))
+	for column := range set {
+		columns = append(columns, column)
+	}
+	sort.Strings(columns)
+	return columns
+}
+
+// HasExpression reports whether the rule is expression based (the activate column is not consulted)
+func (r *Rule) HasExpression() bool {
+	return r != nil && r.expr != nil
+}
+
+// IsActive reports whether a row is active.
+// activate/activateValid are the activate column value and whether it was non-NULL;
+// lookup resolves columns referenced by an expression.
+func (r *Rule) IsActive(activate string, activateValid bool, lookup Lookup) bool {
+	switch {
+	case r != nil && r.expr != nil:
+		// Like a SQL WHERE clause, unknown (NULL) results are not active
+		return r.expr.eval(lookup) == triTrue
+	case r != nil && r.values != nil:
+		if !activateValid {
+			return false
+		}
+		_, ok := r.values[activate]
+		return ok
+	default:
+		return IsTruthy(activate)
+	}
+}
+
+// IsTruthy reports whether value is one of the default truthy activate values
+func IsTruthy(value string) bool {
+	// Truthy values: "1", "true", "TRUE", "yes", etc.
+	switch value {
+	case "1", "true", "TRUE", "True", "yes", "YES", "Yes":
+		return true
+	default:
+		return false
+	}
+}
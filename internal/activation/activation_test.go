@@ -0,0 +1,191 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package activation
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// row builds a Lookup from a map; columns mapped to nil are NULL
+func row(values map[string]*string) Lookup {
+	return func(column string) (string, bool) {
+		value, ok := values[column]
+		if !ok || value == nil {
+			return "", false
+		}
+		return *value, true
+	}
+}
+
+func str(s string) *string { return &s }
+
+func TestRule_Default(t *testing.T) {
+	rule, err := New(nil, "")
+	require.NoError(t, err)
+
+	for _, value := range []string{"1", "true", "TRUE", "True", "yes", "YES", "Yes"} {
+		assert.True(t, rule.IsActive(value, true, nil), value)
+	}
+	for _, value := range []string{"0", "false", "no", "", "active"} {
+		assert.False(t, rule.IsActive(value, true, nil), value)
+	}
+	assert.Nil(t, rule.Columns())
+	assert.False(t, rule.HasExpression())
+}
+
+func TestRule_ActiveValues(t *testing.T) {
+	rule, err := New([]string{"active", "trial"}, "")
+	require.NoError(t, err)
+
+	assert.True(t, rule.IsActive("active", true, nil))
+	assert.True(t, rule.IsActive("trial", true, nil))
+	assert.False(t, rule.IsActive("Active", true, nil), "values are case-sensitive")
+	assert.False(t, rule.IsActive("1", true, nil), "default truthy values no longer apply")
+	assert.False(t, rule.IsActive("", false, nil), "NULL is never active")
+}
+
+func TestRule_Expression(t *testing.T) {
+	tests := []struct {
+		name       string
+		expression string
+		row        map[string]*string
+		want       bool
+	}{
+		{
+			name:       "in list matches",
+			expression: "status in ('active','trial')",
+			row:        map[string]*string{"status": str("trial")},
+			want:       true,
+		},
+		{
+			name:       "in list does not match",
+			expression: "status IN ('active', 'trial')",
+			row:        map[string]*string{"status": str("churned")},
+		},
+		{
+			name:       "in with NULL is unknown",
+			expression: "status in ('active')",
+			row:        map[string]*string{"status": nil},
+		},
+		{
+			name:       "not in with NULL is unknown",
+			expression: "status not in ('churned')",
+			row:        map[string]*string{"status": nil},
+		},
+		{
+			name:       "is null",
+			expression: "deleted_at IS NULL",
+			row:        map[string]*string{"deleted_at": nil},
+			want:       true,
+		},
+		{
+			name:       "is null on missing column",
+			expression: "deleted_at is null",
+			row:        map[string]*string{},
+			want:       true,
+		},
+		{
+			name:       "is not null",
+			expression: "deleted_at IS NOT NULL",
+			row:        map[string]*string{"deleted_at": str("2025-01-01")},
+			want:       true,
+		},
+		{
+			name:       "and with equality and number literal",
+			expression: "deleted_at IS NULL AND seats != 0",
+			row:        map[string]*string{"deleted_at": nil, "seats": str("5")},
+			want:       true,
+		},
+		{
+			name:       "or with parentheses and not",
+			expression: "NOT (status = 'suspended') AND (plan = 'pro' OR plan <> 'free')",
+			row:        map[string]*string{"status": str("active"), "plan": str("team")},
+			want:       true,
+		},
+		{
+			name:       "unknown OR true is true",
+			expression: "status = 'active' OR vip = 'yes'",
+			row:        map[string]*string{"status": nil, "vip": str("yes")},
+			want:       true,
+		},
+		{
+			name:       "escaped quote",
+			expression: "name = 'o''brien'",
+			row:        map[string]*string{"name": str("o'brien")},
+			want:       true,
+		},
+		{
+			name:       "dotted column",
+			expression: "billing.status = 'paid'",
+			row:        map[string]*string{"billing.status": str("paid")},
+			want:       true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule, err := New(nil, tt.expression)
+			require.NoError(t, err)
+			assert.True(t, rule.HasExpression())
+			assert.Equal(t, tt.want, rule.IsActive("ignored", true, row(tt.row)))
+		})
+	}
+}
+
+func TestRule_Columns(t *testing.T) {
+	rule, err := New(nil, "status in ('active') and (deleted_at is null or status = 'trial')")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"deleted_at", "status"}, rule.Columns())
+}
+
+func TestNew_Errors(t *testing.T) {
+	tests := []struct {
+		name         string
+		activeValues []string
+		expression   string
+		errMessage   string
+	}{
+		{name: "both modes", activeValues: []string{"active"}, expression: "a = 'b'", errMessage: "mutually exclusive"},
+		{name: "dangling and", expression: "a = 'b' AND", errMessage: "expected column name at end of expression"},
+		{name: "missing operator", expression: "status 'active'", errMessage: "expected IS, IN, NOT IN, = or !="},
+		{name: "unterminated string", expression: "status = 'active", errMessage: "unterminated string"},
+		{name: "unbalanced parenthesis", expression: "(status = 'a'", errMessage: `expected ")"`},
+		{name: "trailing tokens", expression: "status = 'a' 'b'", errMessage: `unexpected "b"`},
+		{name: "unsupported operator", expression: "seats > 5", errMessage: `unexpected '>'`},
+		{name: "keyword as column", expression: "null is null", errMessage: "expected column name"},
+		{name: "is without null", expression: "a is 'x'", errMessage: "expected NULL"},
+		{name: "column literal", expression: "status = active", errMessage: "expected quoted string or number"},
+		{name: "semicolon", expression: "a = 'b'; drop table x", errMessage: `unexpected ';'`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := New(tt.activeValues, tt.expression)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.errMessage)
+		})
+	}
+}
+
+func TestValidate(t *testing.T) {
+	assert.NoError(t, Validate("deleted_at IS NULL"))
+	assert.NoError(t, Validate(""))
+	assert.Error(t, Validate("deleted_at IS"))
+}
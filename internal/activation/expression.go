@@ -0,0 +1,407 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package activation
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// Expression grammar (keywords are case-insensitive):
+//
+//	expr       := and ( OR and )*
+//	and        := not ( AND not )*
+//	not        := NOT not | primary
+//	primary    := '(' expr ')' | comparison
+//	comparison := column IS [NOT] NULL
+//	            | column [NOT] IN '(' literal ( ',' literal )* ')'
+//	            | column ( '=' | '!=' | '<>' ) literal
+//	literal    := 'quoted string' | number
+//
+// Values are compared as strings. NULL follows SQL three-valued logic.
+
+// tri is a three-valued logic result
+type tri int8
+
+const (
+	triFalse tri = iota
+	triTrue
+	triUnknown
+)
+
+func (t tri) not() tri {
+	switch t {
+	case triTrue:
+		return triFalse
+	case triFalse:
+		return triTrue
+	default:
+		return triUnknown
+	}
+}
+
+// node is an expression tree node
+type node interface {
+	eval(lookup Lookup) tri
+	columns(set map[string]struct{})
+}
+
+type orNode struct{ left, right node }
+
+func (n orNode) eval(lookup Lookup) tri {
+	l, r := n.left.eval(lookup), n.right.eval(lookup)
+	switch {
+	case l == triTrue || r == triTrue:
+		return triTrue
+	case l == triUnknown || r == triUnknown:
+		return triUnknown
+	default:
+		return triFalse
+	}
+}
+
+func (n orNode) columns(set map[string]struct{}) {
+	n.left.columns(set)
+	n.right.columns(set)
+}
+
+type andNode struct{ left, right node }
+
+func (n andNode) eval(lookup Lookup) tri {
+	l, r := n.left.eval(lookup), n.right.eval(lookup)
+	switch {
+	case l == triFalse || r == triFalse:
+		return triFalse
+	case l == triUnknown || r == triUnknown:
+		return triUnknown
+	default:
+		return triTrue
+	}
+}
+
+func (n andNode) columns(set map[string]struct{}) {
+	n.left.columns(set)
+	n.right.columns(set)
+}
+
+type notNode struct{ inner node }
+
+func (n notNode) eval(lookup Lookup) tri { return n.inner.eval(lookup).not() }
+
+func (n notNode) columns(set map[string]struct{}) { n.inner.columns(set) }
+
+// nullNode is `column IS [NOT] NULL`
+type nullNode struct {
+	column string
+	negate bool
+}
+
+func (n nullNode) eval(lookup Lookup) tri {
+	_, ok := lookup(n.column)
+	if ok == n.negate {
+		return triTrue
+	}
+	return triFalse
+}
+
+func (n nullNode) columns(set map[string]struct{}) { set[n.column] = struct{}{} }
+
+// inNode is `column [NOT] IN (...)`; equality is the single-value case
+type inNode struct {
+	column string
+	values map[string]struct{}
+	negate bool
+}
+
+func (n inNode) eval(lookup Lookup) tri {
+	value, ok := lookup(n.column)
+	if !ok {
+		return triUnknown
+	}
+	_, found := n.values[value]
+	if found != n.negate {
+		return triTrue
+	}
+	return triFalse
+}
+
+func (n inNode) columns(set map[string]struct{}) { set[n.column] = struct{}{} }
+
+// token kinds
+const (
+	tokEOF = iota
+	tokIdent
+	tokString
+	tokNumber
+	tokOp
+)
+
+type token struct {
+	kind int
+	text string
+	pos  int
+}
+
+// keyword reports whether the token is the given keyword (case-insensitive)
+func (t token) keyword(word string) bool {
+	return t.kind == tokIdent && strings.EqualFold(t.text, word)
+}
+
+func tokenize(input string) ([]token, error) {
+	var tokens []token
+	runes := []rune(input)
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case r == '(' || r == ')' || r == ',' || r == '=':
+			tokens = append(tokens, token{kind: tokOp, text: string(r), pos: i})
+			i++
+		case r == '!' || r == '<':
+			if i+1 < len(runes) && ((r == '!' && runes[i+1] == '=') || (r == '<' && runes[i+1] == '>')) {
+				tokens = append(tokens, token{kind: tokOp, text: "!=", pos: i})
+				i += 2
+				continue
+			}
+			return nil, fmt.Errorf("unexpected %q at position %d", r, i)
+		case r == '\'':
+			start := i
+			var sb strings.Builder
+			i++
+			for {
+				if i >= len(runes) {
+					return nil, fmt.Errorf("unterminated string starting at position %d", start)
+				}
+				if runes[i] == '\'' {
+					// '' is an escaped quote
+					if i+1 < len(runes) && runes[i+1] == '\'' {
+						sb.WriteRune('\'')
+						i += 2
+						continue
+					}
+					i++
+					break
+				}
+				sb.WriteRune(runes[i])
+				i++
+			}
+			tokens = append(tokens, token{kind: tokString, text: sb.String(), pos: start})
+		case r == '-' || r == '.' || unicode.IsDigit(r):
+			start := i
+			i++
+			for i < len(runes) && (unicode.IsDigit(runes[i]) || runes[i] == '.') {
+				i++
+			}
+			tokens = append(tokens, token{kind: tokNumber, text: string(runes[start:i]), pos: start})
+		case r == '_' || unicode.IsLetter(r):
+			start := i
+			for i < len(runes) && (runes[i] == '_' || runes[i] == '.' || unicode.IsLetter(runes[i]) || unicode.IsDigit(runes[i])) {
+				i++
+			}
+			tokens = append(tokens, token{kind: tokIdent, text: string(runes[start:i]), pos: start})
+		default:
+			return nil, fmt.Errorf("unexpected %q at position %d", r, i)
+		}
+	}
+	return append(tokens, token{kind: tokEOF, pos: len(runes)}), nil
+}
+
+// parser is a recursive descent parser over the token list
+type parser struct {
+	tokens []token
+	pos    int
+}
+
+func parse(input string) (node, error) {
+	tokens, err := tokenize(input)
+	if err != nil {
+		return nil, err
+	}
+	p := &parser{tokens: tokens}
+	expr, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if tok := p.peek(); tok.kind != tokEOF {
+		return nil, fmt.Errorf("unexpected %q at position %d", tok.text, tok.pos)
+	}
+	return expr, nil
+}
+
+func (p *parser) peek() token { return p.tokens[p.pos] }
+
+func (p *parser) next() token {
+	tok := p.tokens[p.pos]
+	if tok.kind != tokEOF {
+		p.pos++
+	}
+	return tok
+}
+
+func (p *parser) expectOp(op string) error {
+	tok := p.next()
+	if tok.kind != tokOp || tok.text != op {
+		return unexpected(tok, fmt.Sprintf("%q", op))
+	}
+	return nil
+}
+
+func (p *parser) parseOr() (node, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.peek().keyword("OR") {
+		p.next()
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = orNode{left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *parser) parseAnd() (node, error) {
+	left, err := p.parseNot()
+	if err != nil {
+		return nil, err
+	}
+	for p.peek().keyword("AND") {
+		p.next()
+		right, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		left = andNode{left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *parser) parseNot() (node, error) {
+	if p.peek().keyword("NOT") {
+		p.next()
+		inner, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		return notNode{inner: inner}, nil
+	}
+	return p.parsePrimary()
+}
+
+func (p *parser) parsePrimary() (node, error) {
+	tok := p.peek()
+	if tok.kind == tokOp && tok.text == "(" {
+		p.next()
+		expr, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if err := p.expectOp(")"); err != nil {
+			return nil, err
+		}
+		return expr, nil
+	}
+	return p.parseComparison()
+}
+
+func (p *parser) parseComparison() (node, error) {
+	colTok := p.next()
+	if colTok.kind != tokIdent || isReserved(colTok.text) {
+		return nil, unexpected(colTok, "column name")
+	}
+	column := colTok.text
+
+	tok := p.next()
+	switch {
+	case tok.keyword("IS"):
+		negate := false
+		if p.peek().keyword("NOT") {
+			p.next()
+			negate = true
+		}
+		if nullTok := p.next(); !nullTok.keyword("NULL") {
+			return nil, unexpected(nullTok, "NULL")
+		}
+		return nullNode{column: column, negate: negate}, nil
+
+	case tok.keyword("NOT"), tok.keyword("IN"):
+		negate := tok.keyword("NOT")
+		if negate {
+			if inTok := p.next(); !inTok.keyword("IN") {
+				return nil, unexpected(inTok, "IN")
+			}
+		}
+		if err := p.expectOp("("); err != nil {
+			return nil, err
+		}
+		values := map[string]struct{}{}
+		for {
+			value, err := p.parseLiteral()
+			if err != nil {
+				return nil, err
+			}
+			values[value] = struct{}{}
+			sep := p.next()
+			if sep.kind == tokOp && sep.text == ")" {
+				break
+			}
+			if sep.kind != tokOp || sep.text != "," {
+				return nil, unexpected(sep, `"," or ")"`)
+			}
+		}
+		return inNode{column: column, values: values, negate: negate}, nil
+
+	case tok.kind == tokOp && (tok.text == "=" || tok.text == "!="):
+		value, err := p.parseLiteral()
+		if err != nil {
+			return nil, err
+		}
+		return inNode{column: column, values: map[string]struct{}{value: {}}, negate: tok.text == "!="}, nil
+
+	default:
+		return nil, unexpected(tok, "IS, IN, NOT IN, = or !=")
+	}
+}
+
+func (p *parser) parseLiteral() (string, error) {
+	tok := p.next()
+	if tok.kind != tokString && tok.kind != tokNumber {
+		return "", unexpected(tok, "quoted string or number")
+	}
+	return tok.text, nil
+}
+
+// isReserved reports whether an identifier is a keyword and cannot be used as a column name
+func isReserved(word string) bool {
+	for _, kw := range []string{"AND", "OR", "NOT", "IN", "IS", "NULL"} {
+		if strings.EqualFold(word, kw) {
+			return true
+		}
+	}
+	return false
+}
+
+func unexpected(tok token, want string) error {
+	if tok.kind == tokEOF {
+		return fmt.Errorf("expected %s at end of expression", want)
+	}
+	return fmt.Errorf("expected %s at position %d, got %q", want, tok.pos, tok.text)
+}
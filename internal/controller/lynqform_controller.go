@@ -47,6 +47,8 @@ const (
 	ConditionTypeValid = "Valid"
 	// ConditionTypeApplied is the condition type for LynqForm applied status
 	ConditionTypeApplied = "Applied"
+	// ConditionTypeHubStale mirrors the DataStale condition of the referenced LynqHub
+	ConditionTypeHubStale = "HubStale"
 )
 
 // LynqFormReconciler reconciles a LynqForm object
@@ -286,6 +288,12 @@ func (r *LynqFormReconciler) updateStatus(ctx context.Context, tmpl *lynqv1.Lynq
 func (r *LynqFormReconciler) updateStatusWithRollout(ctx context.Context, tmpl *lynqv1.LynqForm, validationErrors []string, stats rolloutStats) {
 	logger := log.FromContext(ctx)
 
+	// The referenced hub may not exist (reported by the Valid condition)
+	hub := &lynqv1.LynqHub{}
+	if err := r.Get(ctx, types.NamespacedName{Name: tmpl.Spec.HubID, Namespace: tmpl.Namespace}, hub); err != nil {
+		hub = nil
+	}
+
 	// Retry status update on conflict
 	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
 		// Get the latest version of the template
@@ -338,6 +346,8 @@ func (r *LynqFormReconciler) updateStatusWithRollout(ctx context.Context, tmpl *
 		// Use meta.SetStatusCondition which correctly preserves LastTransitionTime
 		meta.SetStatusCondition(&latest.Status.Conditions, validCondition)
 		meta.SetStatusCondition(&latest.Status.Conditions, appliedCondition)
+		setHubStaleCondition(&latest.Status, hub)
+		setFormSuspendedCondition(&latest.Status, latest, hub)
 
 		// Skip write if status is unchanged (compare full status including rollout fields)
 		if apiequality.Semantic.DeepEqual(statusBefore, &latest.Status) {
@@ -353,6 +363,49 @@ func (r *LynqFormReconciler) updateStatusWithRollout(ctx context.Context, tmpl *
 	}
 }
 
+// setHubStaleCondition reflects the referenced hub's data staleness on the form, so that
+// users of the form can see that new or changed rows are not being applied
+func setHubStaleCondition(status *lynqv1.LynqFormStatus, hub *lynqv1.LynqHub) {
+	var stale *metav1.Condition
+	if hub != nil {
+		stale = meta.FindStatusCondition(hub.Status.Conditions, ConditionTypeDataStale)
+	}
+	if stale == nil {
+		meta.RemoveStatusCondition(&status.Conditions, ConditionTypeHubStale)
+		return
+	}
+	meta.SetStatusCondition(&status.Conditions, metav1.Condition{
+		Type:    ConditionTypeHubStale,
+		Status:  stale.Status,
+		Reason:  stale.Reason,
+		Message: fmt.Sprintf("LynqHub '%s': %s", hub.Name, stale.Message),
+	})
+}
+
+// setFormSuspendedCondition reports whether the hub leaves the form's nodes unchanged, because
+// of the form's or the hub's spec.suspend. The condition is only added once either is suspended.
+func setFormSuspendedCondition(status *lynqv1.LynqFormStatus, tmpl *lynqv1.LynqForm, hub *lynqv1.LynqHub) {
+	condition := metav1.Condition{
+		Type:    ConditionTypeSuspended,
+		Status:  metav1.ConditionTrue,
+		Reason:  "Suspended",
+		Message: "spec.suspend is true; LynqNodes are not created, updated or deleted",
+	}
+	switch {
+	case tmpl.Spec.Suspend:
+	case hub != nil && hub.Spec.Suspend:
+		condition.Reason = "HubSuspended"
+		condition.Message = fmt.Sprintf("LynqHub '%s' is suspended; LynqNodes are not created, updated or deleted", hub.Name)
+	case meta.FindStatusCondition(status.Conditions, ConditionTypeSuspended) == nil:
+		return
+	default:
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = "Resumed"
+		condition.Message = "LynqNodes are synced"
+	}
+	meta.SetStatusCondition(&status.Conditions, condition)
+}
+
 // updateRolloutStatus updates the rollout status based on current statistics
 func (r *LynqFormReconciler) updateRolloutStatus(tmpl *lynqv1.LynqForm, stats rolloutStats) {
 	// Only track rollout status if maxSkew is configured
@@ -18,9 +18,16 @@ package controller
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
+	"math/rand"
+	"path/filepath"
+	"slices"
+	"sort"
 	"strings"
+	"sync"
 	"time"
 
 	appsv1 "k8s.io/api/apps/v1"
@@ -31,14 +38,17 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/client-go/tools/record"
 	"k8s.io/client-go/util/retry"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
@@ -51,6 +61,52 @@ import (
 const (
 	// Finalizer for LynqHub
 	FinalizerLynqHub = "lynq.sh/hub-finalizer"
+
+	// defaultFullResyncInterval is used for incremental sync when fullResyncInterval is unset
+	defaultFullResyncInterval = time.Hour
+
+	// ConditionTypeDeletionBlocked is set on a LynqHub while the deletion guard holds back deletions
+	ConditionTypeDeletionBlocked = "DeletionBlocked"
+
+	// ConditionTypeDataStale is set on a LynqHub while its datasource is unavailable and
+	// LynqNodes keep serving the data of the last successful sync
+	ConditionTypeDataStale = "DataStale"
+
+	// ConditionTypeSchemaValid is set on a LynqHub with a SQL source when its column mappings
+	// have been checked against the table schema
+	ConditionTypeSchemaValid = "SchemaValid"
+
+	// ConditionTypeRowsRejected is set on a LynqHub while rows are not synced because of their uid
+	ConditionTypeRowsRejected = "RowsRejected"
+
+	// ConditionTypeCircuitOpen is set on a LynqHub while its datasource is not queried because it
+	// failed retry.circuitBreakerThreshold consecutive syncs
+	ConditionTypeCircuitOpen = "CircuitOpen"
+
+	// defaultQueryTimeout bounds each datasource query when source.queryTimeout is unset
+	defaultQueryTimeout = 30 * time.Second
+
+	// defaultMaxBackoff caps the delay between failed syncs when retry.maxBackoff is unset
+	defaultMaxBackoff = 10 * time.Minute
+
+	// defaultCircuitBreakerThreshold and defaultCircuitBreakerCooldown apply when retry does not set them
+	defaultCircuitBreakerThreshold = 5
+	defaultCircuitBreakerCooldown  = 5 * time.Minute
+
+	// retryJitter is the largest fraction of the retry delay added to spread out retries
+	retryJitter = 0.2
+
+	// maxRejectedRows bounds the rows listed in status.rejectedRows
+	maxRejectedRows = 50
+
+	// maxNodeWriteFailures bounds the writes listed in status.nodeWriteFailures
+	maxNodeWriteFailures = 50
+
+	// maxSyncErrorLength bounds the datasource error kept in status.snapshot.lastError
+	maxSyncErrorLength = 512
+
+	// configMapSourceIndex indexes LynqHubs by the ConfigMaps their source and merge sources read
+	configMapSourceIndex = "spec.source.configMap.name"
 )
 
 // LynqHubReconciler reconciles a LynqHub object
@@ -58,6 +114,10 @@ type LynqHubReconciler struct {
 	client.Client
 	Scheme   *runtime.Scheme
 	Recorder record.EventRecorder
+
+	// Datasources keeps datasource connections open between syncs, keyed by datasourceKey
+	// When nil, a new connection is opened and closed for every sync
+	Datasources *datasource.Cache
 }
 
 // +kubebuilder:rbac:groups=operator.lynq.sh,resources=lynqhubs,verbs=get;list;watch;create;update;patch;delete
@@ -65,6 +125,7 @@ type LynqHubReconciler struct {
 // +kubebuilder:rbac:groups=operator.lynq.sh,resources=lynqhubs/finalizers,verbs=update
 // +kubebuilder:rbac:groups=operator.lynq.sh,resources=lynqnodes,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=configmaps,verbs=get;list;watch
 // +kubebuilder:rbac:groups="",resources=events,verbs=create;patch
 
 // Reconcile syncs nodes from external data source to Kubernetes
@@ -75,6 +136,11 @@ func (r *LynqHubReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ct
 	registry := &lynqv1.LynqHub{}
 	if err := r.Get(ctx, req.NamespacedName, registry); err != nil {
 		if errors.IsNotFound(err) {
+			// The hub may be gone without passing the finalizer (removed by hand, or deleted while
+			// the operator was down); its connections must not stay open until a restart
+			if r.Datasources != nil {
+				r.Datasources.EvictOwner(hubDatasourceOwner(req.NamespacedName))
+			}
 			return ctrl.Result{}, nil
 		}
 		logger.Error(err, "Failed to get LynqHub")
@@ -90,7 +156,12 @@ func (r *LynqHubReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ct
 
 	// Handle finalizer logic
 	if !registry.DeletionTimestamp.IsZero() {
-		// Hub is being deleted
+		// Hub is being deleted; its datasource connection is no longer needed
+		if r.Datasources != nil {
+			r.Datasources.EvictOwner(hubDatasourceOwner(client.ObjectKeyFromObject(registry)))
+		}
+		deleteHubMetrics(registry)
+
 		if containsString(registry.Finalizers, FinalizerLynqHub) {
 			// Run cleanup logic for DeletionPolicy.Retain resources
 			if err := r.cleanupRetainResources(ctx, registry); err != nil {
@@ -130,15 +201,78 @@ func (r *LynqHubReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ct
 		return ctrl.Result{RequeueAfter: syncInterval}, err
 	}
 
-	// Connect to database and query nodes
-	nodeRows, err := r.queryDatabase(ctx, registry)
+	// A suspended hub neither queries its datasource nor touches its LynqNodes. Its spec change
+	// when resumed triggers the next sync.
+	if registry.Spec.Suspend {
+		logger.V(1).Info("LynqHub suspended, skipping sync")
+		readyCount, failedCount := r.countLynqNodeStatus(ctx, registry)
+		r.updateStatus(ctx, registry, int32(len(templates)), registry.Status.Desired, readyCount, failedCount, false)
+		if !meta.IsStatusConditionTrue(registry.Status.Conditions, ConditionTypeSuspended) {
+			r.Recorder.Eventf(registry, corev1.EventTypeNormal, "Suspended",
+				"Sync suspended; the datasource is not queried and LynqNodes are not changed")
+		}
+		return ctrl.Result{}, nil
+	}
+
+	// While the circuit breaker is open the datasource is not queried, whatever triggered the
+	// reconcile. A spec change probes it right away, since it may fix the connection.
+	if wait := circuitOpenFor(registry, time.Now()); wait > 0 {
+		logger.V(1).Info("Circuit breaker open, skipping datasource query", "retryIn", wait)
+		return ctrl.Result{RequeueAfter: wait}, nil
+	}
+
+	// Check the column mappings against the table schema after spec changes, so that a
+	// misspelled column is reported by name rather than as a raw query error
+	schemaUpdate := r.checkSchema(ctx, registry)
+
+	// Connect to database and query nodes (only changed rows for incremental syncs)
+	syncStart := time.Now()
+	rowSet, err := r.syncRows(ctx, registry, templates)
 	if err != nil {
 		logger.Error(err, "Failed to query database")
 		r.Recorder.Eventf(registry, corev1.EventTypeWarning, "DatabaseQueryFailed",
 			"Failed to query database: %v", err)
-		r.updateStatus(ctx, registry, int32(len(templates)), 0, 0, 0, false)
-		return ctrl.Result{RequeueAfter: syncInterval}, err
-	}
+		// Existing LynqNodes are kept as they are, so keep reporting the last known desired count
+		readyCount, failedCount := r.countLynqNodeStatus(ctx, registry)
+		syncErr := err
+		schedule := nextSyncRetry(registry, syncInterval)
+		now := time.Now()
+		r.updateStatus(ctx, registry, int32(len(templates)), registry.Status.Desired, readyCount, failedCount, false,
+			func(status *lynqv1.LynqHubStatus) {
+				recordSyncFailure(status, now, syncErr)
+				recordSyncRetry(status, now, schedule)
+				recordLastSync(status, syncStart, now, lynqv1.SyncChanges{}, syncErr.Error())
+			}, schemaUpdate)
+		if schedule.circuitOpen && !meta.IsStatusConditionTrue(registry.Status.Conditions, ConditionTypeCircuitOpen) {
+			r.Recorder.Eventf(registry, corev1.EventTypeWarning, "CircuitOpened",
+				"Datasource failed %d consecutive syncs; not querying it for %s", schedule.failures, schedule.delay.Truncate(time.Second))
+		}
+		// The error is reported in status and events; returning it would retry on the controller's
+		// rate limiter instead of the hub's backoff
+		return ctrl.Result{RequeueAfter: schedule.delay}, nil
+	}
+	// Check the uids before they become LynqNode names and labels
+	readUIDs := rowSet.readUIDs()
+	policy := registry.Spec.InvalidRowPolicy
+	validRows, rejected := screenRows(rowSet.rows, defaultNamedForms(registry, templates), policy)
+	if len(rejected) > 0 {
+		r.Recorder.Eventf(registry, corev1.EventTypeWarning, "RowsRejected",
+			"%d rows rejected (invalidRowPolicy %s): %s", len(rejected), invalidRowPolicyName(policy), summarizeRejectedRows(rejected, len(rejected)))
+	}
+	if len(rejected) > 0 && policy == lynqv1.InvalidRowPolicyFail {
+		// Nothing is applied and the incremental sync watermark is kept, so the rows are read again
+		logger.Info("Sync halted by invalid rows", "rejected", len(rejected))
+		readyCount, failedCount := r.countLynqNodeStatus(ctx, registry)
+		r.updateStatus(ctx, registry, int32(len(templates)), registry.Status.Desired, readyCount, failedCount, true,
+			func(status *lynqv1.LynqHubStatus) {
+				recordRejectedRows(status, rejected, policy, rowSet.incremental, readUIDs)
+				recordLastSync(status, syncStart, time.Now(), lynqv1.SyncChanges{},
+					fmt.Sprintf("sync halted: %d rows rejected while invalidRowPolicy is fail", len(rejected)))
+			}, schemaUpdate)
+		return ctrl.Result{RequeueAfter: syncInterval}, nil
+	}
+	rowSet.rows = validRows
+	nodeRows := rowSet.rows
 
 	// Get existing LynqNode CRs
 	existingNodes, err := r.getExistingLynqNodes(ctx, registry)
@@ -147,15 +281,41 @@ func (r *LynqHubReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ct
 		return ctrl.Result{RequeueAfter: syncInterval}, err
 	}
 
-	// Build desired node set: key = {template-name}-{uid}
+	// Nodes are identified by form and uid, never by name: names come from nodeNameTemplate
 	type NodeKey struct {
 		TemplateName string
 		UID          string
 	}
+
+	// Build existing node map, and the owners of the names in use
+	existing := make(map[NodeKey]*lynqv1.LynqNode)
+	nodeNames := make(map[string]NodeKey, len(existingNodes.Items))
+	for i := range existingNodes.Items {
+		node := &existingNodes.Items[i]
+		key := NodeKey{
+			TemplateName: node.Spec.TemplateRef,
+			UID:          node.Spec.UID,
+		}
+		existing[key] = node
+		nodeNames[node.Name] = key
+	}
+
+	// Track whether every change was applied; the incremental sync watermark only
+	// advances when nothing was throttled or failed, so skipped rows are fetched again
+	allApplied := true
+
+	// Build desired node set
 	desired := make(map[NodeKey]struct {
 		Template *lynqv1.LynqForm
 		Row      datasource.NodeRow
 	})
+	// Pairs whose row is not selected by the form's rowSelector, and existing nodes whose
+	// selector could not be evaluated (kept as they are)
+	deselected := make(map[NodeKey]struct{})
+	unresolved := make(map[NodeKey]struct{})
+	selectedByForm := make(map[string]int32, len(templates))
+	// One engine renders the rowSelectors, names and resources of every node in this sync
+	engine := template.NewEngine()
 
 	for _, tmpl := range templates {
 		for _, row := range nodeRows {
@@ -163,6 +323,36 @@ func (r *LynqHubReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ct
 				TemplateName: tmpl.Name,
 				UID:          row.UID,
 			}
+			selected, err := rowSelected(engine, registry, tmpl, row)
+			if err != nil {
+				r.Recorder.Eventf(registry, corev1.EventTypeWarning, "RowSelectorFailed",
+					"Failed to evaluate the rowSelector of LynqForm %s for uid %s: %v", tmpl.Name, row.UID, err)
+				unresolved[key] = struct{}{}
+				allApplied = false
+				continue
+			}
+			if !selected {
+				deselected[key] = struct{}{}
+				continue
+			}
+			selectedByForm[tmpl.Name]++
+			// A new node must not take the name of another uid's node (existing nodes keep their names)
+			if _, exists := existing[key]; !exists {
+				name, err := lynqNodeName(engine, registry, tmpl, row)
+				if err != nil {
+					r.Recorder.Eventf(registry, corev1.EventTypeWarning, "TemplateRenderFailed",
+						"Failed to render the LynqNode name for uid %s (LynqForm %s): %v", row.UID, tmpl.Name, err)
+					allApplied = false
+					continue
+				}
+				if owner, taken := nodeNames[name]; taken && owner != key {
+					r.Recorder.Eventf(registry, corev1.EventTypeWarning, "NodeNameConflict",
+						"LynqNode name %q for uid %s (LynqForm %s) is already used by uid %s (LynqForm %s); the node is not created",
+						name, row.UID, tmpl.Name, owner.UID, owner.TemplateName)
+					continue
+				}
+				nodeNames[name] = key
+			}
 			desired[key] = struct {
 				Template *lynqv1.LynqForm
 				Row      datasource.NodeRow
@@ -171,208 +361,1708 @@ func (r *LynqHubReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ct
 				Row:      row,
 			}
 		}
-	}
+	}
+
+	// Build template lookup map for shouldUpdateLynqNode (avoids per-node API calls)
+	templateMap := make(map[string]*lynqv1.LynqForm, len(templates))
+	for _, tmpl := range templates {
+		templateMap[tmpl.Name] = tmpl
+	}
+
+	// Group existing nodes by template for maxSkew checking
+	nodesByTemplate := make(map[string][]*lynqv1.LynqNode)
+	for key, node := range existing {
+		nodesByTemplate[key.TemplateName] = append(nodesByTemplate[key.TemplateName], node)
+	}
+
+	// Track throttled updates for events
+	throttledByTemplate := make(map[string]int)
+
+	// Track nodes updated in THIS reconcile iteration per template
+	// This is critical for maxSkew enforcement because templateNodes snapshot doesn't reflect
+	// updates made within this loop iteration. Writes run in parallel, so a slot is taken when
+	// the write is planned rather than when it succeeds: a failed write frees its slot on the
+	// next sync, and parallel writes never exceed maxSkew.
+	updatedInThisIteration := make(map[string]int32)
+
+	// Plan creates/updates for each template-row combination
+	var writes []nodeWrite
+	for key, desired := range desired {
+		tmpl := desired.Template
+		templateNodes := nodesByTemplate[tmpl.Name]
+
+		// Nodes of suspended forms are neither created nor updated. Resuming bumps the form's
+		// generation, which forces a full resync of incremental hubs.
+		if tmpl.Spec.Suspend {
+			continue
+		}
+
+		existingLynqNode, exists := existing[key]
+		if exists && !r.shouldUpdateLynqNode(ctx, registry, existingLynqNode, desired.Row, templateMap) {
+			continue
+		}
+		// Check maxSkew before creating or updating
+		if !r.canUpdateNodeWithCount(ctx, tmpl, templateNodes, updatedInThisIteration[tmpl.Name]) {
+			// Throttled by maxSkew
+			throttledByTemplate[tmpl.Name]++
+			allApplied = false
+			continue
+		}
+		updatedInThisIteration[tmpl.Name]++
+		writes = append(writes, nodeWrite{template: tmpl, row: desired.Row, node: existingLynqNode})
+	}
+
+	// Apply the writes with spec.parallelism workers
+	var writeFailures []lynqv1.NodeWriteFailure
+	var changes lynqv1.SyncChanges
+	for i, err := range r.applyNodeWrites(ctx, registry, engine, writes, syncParallelism(registry)) {
+		write := writes[i]
+		if err == nil {
+			if write.node == nil {
+				changes.Created++
+			} else {
+				changes.Updated++
+			}
+			continue
+		}
+		key := NodeKey{TemplateName: write.template.Name, UID: write.row.UID}
+		if write.node == nil && errors.IsAlreadyExists(err) {
+			if node, findErr := r.findLynqNode(ctx, registry, key.TemplateName, key.UID); findErr == nil && node == nil {
+				// The name is taken by a node this hub does not own for the uid, e.g. one of another hub.
+				// AlreadyExists for the node of the uid itself comes from concurrent reconciliations.
+				r.Recorder.Eventf(registry, corev1.EventTypeWarning, "NodeNameConflict",
+					"LynqNode name for uid %s (LynqForm %s) is already used by another LynqNode; the node is not created",
+					key.UID, key.TemplateName)
+			}
+			continue
+		}
+		logger.Error(err, "Failed to write LynqNode", "operation", write.operation(), "template", key.TemplateName, "uid", key.UID)
+		writeFailures = append(writeFailures, lynqv1.NodeWriteFailure{
+			UID:       key.UID,
+			Form:      key.TemplateName,
+			Operation: write.operation(),
+			Message:   err.Error(),
+		})
+		allApplied = false
+	}
+	syncError := ""
+	if len(writeFailures) > 0 {
+		syncError = fmt.Sprintf("%d of %d LynqNode writes failed: %s",
+			len(writeFailures), len(writes), summarizeNodeWriteFailures(writeFailures))
+		r.Recorder.Eventf(registry, corev1.EventTypeWarning, "NodeWritesFailed", "%s", syncError)
+	}
+
+	// Emit events for throttled updates
+	for tmplName, throttledCount := range throttledByTemplate {
+		// Find the template to get maxSkew value
+		for _, tmpl := range templates {
+			if tmpl.Name == tmplName && tmpl.Spec.Rollout != nil {
+				r.Recorder.Eventf(registry, corev1.EventTypeNormal, "RolloutThrottled",
+					"LynqForm '%s': %d node updates throttled (maxSkew=%d, currently updating=%d)",
+					tmplName, throttledCount, tmpl.Spec.Rollout.MaxSkew,
+					r.countUpdatingNodes(ctx, nodesByTemplate[tmplName], tmpl.Generation))
+				break
+			}
+		}
+	}
+
+	// Collect nodes no longer in desired set
+	// This handles:
+	// 1. Rows deleted from database
+	// 2. Rows with activate=false
+	// 3. Templates deleted/changed
+	var deletions []NodeKey
+	for key := range existing {
+		if _, stillExists := desired[key]; !stillExists {
+			if _, keep := unresolved[key]; keep {
+				continue
+			}
+			if tmpl, ok := templateMap[key.TemplateName]; ok && tmpl.Spec.Suspend {
+				continue
+			}
+			// Incremental syncs only see changed rows: keep nodes whose rows did not change
+			// (deleted rows are caught by the next full resync), unless the row changed and
+			// no longer matches the form's rowSelector
+			_, unselected := deselected[key]
+			if rowSet.incremental && !unselected && !rowSet.shouldDelete(key.UID, key.TemplateName, templateMap) {
+				continue
+			}
+			deletions = append(deletions, key)
+		}
+	}
+
+	// Check the deletion guard before deleting anything
+	approved := registry.Annotations[lynqv1.AnnotationApproveDeletions] == AnnotationValueTrue
+	deletionBlocked, guardMessage := deletionGuardExceeded(registry.Spec.DeletionGuard, len(deletions), len(existing))
+	if deletionBlocked && approved {
+		logger.Info("Deletion guard exceeded but deletions were approved", "deletions", len(deletions))
+		r.Recorder.Eventf(registry, corev1.EventTypeNormal, "DeletionApproved",
+			"Deleting %d LynqNodes approved via %s annotation: %s", len(deletions), lynqv1.AnnotationApproveDeletions, guardMessage)
+		deletionBlocked = false
+	}
+	if deletionBlocked {
+		logger.Info("Deletion guard exceeded, skipping deletions", "deletions", len(deletions), "existing", len(existing))
+		r.Recorder.Eventf(registry, corev1.EventTypeWarning, "DeletionBlocked",
+			"%s; no LynqNodes were deleted. Set annotation %s=true on the LynqHub to approve",
+			guardMessage, lynqv1.AnnotationApproveDeletions)
+		deletions = nil
+		allApplied = false
+	}
+	// An approval only applies to the sync that sees it; never carry it over to a later mass deletion
+	if _, ok := registry.Annotations[lynqv1.AnnotationApproveDeletions]; ok && !deletionBlocked {
+		if err := r.clearDeletionApproval(ctx, registry); err != nil {
+			logger.Error(err, "Failed to remove deletion approval annotation")
+		}
+	}
+
+	// Delete nodes no longer in desired set
+	deletedCount := 0
+	for _, key := range deletions {
+		node := existing[key]
+
+		logger.Info("Deleting LynqNode (no longer in desired set)",
+			"node", node.Name,
+			"template", key.TemplateName,
+			"uid", key.UID,
+			"reason", "row removed from database or activate=false or template changed")
+
+		// Emit detailed deletion event
+		r.Recorder.Eventf(registry, corev1.EventTypeNormal, "NodeDeleting",
+			"Deleting LynqNode '%s' (template: %s, uid: %s) - no longer in active dataset. "+
+				"This could be due to: row deletion, activate=false, or template change.",
+			node.Name, key.TemplateName, key.UID)
+
+		if err := r.Delete(ctx, node); err != nil {
+			if !errors.IsNotFound(err) {
+				logger.Error(err, "Failed to delete LynqNode", "node", node.Name, "template", key.TemplateName, "uid", key.UID)
+				r.Recorder.Eventf(registry, corev1.EventTypeWarning, "NodeDeletionFailed",
+					"Failed to delete LynqNode '%s': %v", node.Name, err)
+				allApplied = false
+			}
+		} else {
+			deletedCount++
+			r.Recorder.Eventf(registry, corev1.EventTypeNormal, "NodeDeleted",
+				"Successfully deleted LynqNode '%s' (template: %s, uid: %s)",
+				node.Name, key.TemplateName, key.UID)
+		}
+	}
+
+	if deletedCount > 0 {
+		logger.Info("Garbage collection completed", "deletedNodes", deletedCount)
+	}
+
+	// Update status (reuse already-fetched node list instead of a separate LIST call)
+	readyCount, failedCount := countNodeStatusFromList(existingNodes)
+	activeRows := int32(len(nodeRows))
+	if rowSet.incremental {
+		activeRows = rowSet.countActiveUIDs(existingNodes, templateMap)
+	}
+	// Only the form and row pairs matched by rowSelectors are desired
+	desiredByForm := selectedByForm
+	if rowSet.incremental {
+		for form, unchanged := range rowSet.countUnchangedNodes(existingNodes, templateMap) {
+			desiredByForm[form] += unchanged
+		}
+	}
+	var totalDesired int32
+	for _, count := range desiredByForm {
+		totalDesired += count
+	}
+	changes.Deleted = int32(deletedCount)
+
+	// Incremental syncs only see changed rows and keep the hash of the last full sync
+	snapshotHash := ""
+	if !rowSet.incremental {
+		snapshotHash = rowSetHash(nodeRows)
+	}
+
+	// Persist the incremental sync state. When some changes were not applied, an incremental
+	// sync keeps the previous watermark and a full resync drops it to force another full resync.
+	incrementalState := rowSet.nextState
+	if incrementalState != nil && !allApplied {
+		if rowSet.incremental {
+			incrementalState = registry.Status.IncrementalSync
+		} else {
+			incrementalState.Watermark = nil
+		}
+	}
+	r.updateStatus(ctx, registry, int32(len(templates)), totalDesired, readyCount, failedCount, true,
+		func(status *lynqv1.LynqHubStatus) {
+			status.IncrementalSync = incrementalState
+			setDeletionBlockedCondition(status, registry.Spec.DeletionGuard, deletionBlocked, guardMessage)
+			recordSyncSuccess(status, time.Now(), activeRows, snapshotHash)
+			status.ActiveEndpoint = rowSet.endpoint
+			recordRejectedRows(status, rejected, policy, rowSet.incremental, readUIDs)
+			status.MergeSources = rowSet.mergeSources
+			recordNodeWriteFailures(status, writeFailures)
+			status.Forms = formSyncStatuses(templates, existingNodes, desiredByForm, throttledByTemplate)
+			recordLastSync(status, syncStart, time.Now(), changes, syncError)
+		}, schemaUpdate)
+	if meta.IsStatusConditionTrue(registry.Status.Conditions, ConditionTypeCircuitOpen) {
+		r.Recorder.Eventf(registry, corev1.EventTypeNormal, "CircuitClosed", "Datasource sync succeeded; circuit breaker closed")
+	}
+
+	return ctrl.Result{RequeueAfter: syncInterval}, nil
+}
+
+// queryDatabase connects to database and retrieves node rows, together with the endpoint that served them
+func (r *LynqHubReconciler) queryDatabase(ctx context.Context, registry *lynqv1.LynqHub) ([]datasource.NodeRow, string, error) {
+	ds, queryConfig, err := r.openDatasource(ctx, registry)
+	if err != nil {
+		return nil, "", err
+	}
+
+	timeout := queryTimeout(registry)
+	queryCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	rows, err := ds.QueryNodes(queryCtx, queryConfig)
+	err = queryTimeoutError(queryCtx, timeout, err)
+	endpoint := activeEndpoint(ds)
+	r.releaseDatasource(registry, ds, err)
+	return rows, endpoint, err
+}
+
+// queryTimeout returns the time each datasource query of the hub may take
+func queryTimeout(registry *lynqv1.LynqHub) time.Duration {
+	return parseDurationOr(registry.Spec.Source.QueryTimeout, defaultQueryTimeout)
+}
+
+// queryTimeoutError names the timeout in errors of queries that ran out of time
+func queryTimeoutError(queryCtx context.Context, timeout time.Duration, err error) error {
+	if err != nil && queryCtx.Err() == context.DeadlineExceeded {
+		return fmt.Errorf("query did not finish within %s (source.queryTimeout): %w", timeout, err)
+	}
+	return err
+}
+
+// parseDurationOr parses a duration field, returning def when the field is empty or invalid
+func parseDurationOr(value string, def time.Duration) time.Duration {
+	if value == "" {
+		return def
+	}
+	parsed, err := time.ParseDuration(value)
+	if err != nil || parsed <= 0 {
+		return def
+	}
+	return parsed
+}
+
+// activeEndpoint returns the endpoint reported by adapters that choose between several servers
+func activeEndpoint(ds datasource.Datasource) string {
+	if reporter, ok := ds.(datasource.EndpointReporter); ok {
+		return reporter.ActiveEndpoint()
+	}
+	return ""
+}
+
+// openDatasource resolves the source credentials and creates the datasource adapter and query config
+// With a datasource cache the adapter is reused while the resolved config (spec, credentials and
+// TLS material) is unchanged. Callers must hand the adapter back with releaseDatasource.
+func (r *LynqHubReconciler) openDatasource(ctx context.Context, registry *lynqv1.LynqHub) (datasource.Datasource, datasource.QueryConfig, error) {
+	sourceType := datasource.SourceType(registry.Spec.Source.Type)
+	config, queryConfig, err := r.resolveDatasourceConfig(ctx, registry)
+	if err != nil {
+		return nil, datasource.QueryConfig{}, err
+	}
+
+	// Create datasource adapter (or reuse the cached one)
+	var ds datasource.Datasource
+	if r.Datasources != nil {
+		ds, err = r.Datasources.Get(datasourceKey(registry), sourceType, config)
+	} else {
+		ds, err = datasource.NewDatasource(sourceType, config)
+	}
+	if err != nil {
+		return nil, datasource.QueryConfig{}, fmt.Errorf("failed to create datasource: %w", err)
+	}
+
+	return ds, queryConfig, nil
+}
+
+// hubDatasourceOwner is the owner of the cached datasources of a hub, known even after the hub is gone
+func hubDatasourceOwner(key types.NamespacedName) string {
+	return key.Namespace + "/" + key.Name
+}
+
+// datasourceKey is the datasource cache key of a hub: "{namespace}/{name}/{UID}"
+// The UID keeps a recreated hub from sharing a connection with the deleted one.
+func datasourceKey(registry *lynqv1.LynqHub) string {
+	return hubDatasourceOwner(client.ObjectKeyFromObject(registry)) + "/" + string(registry.UID)
+}
+
+// resolveDatasourceConfig builds the datasource config, with credentials, TLS material and
+// ConfigMap rows loaded, and the query config of the hub
+func (r *LynqHubReconciler) resolveDatasourceConfig(ctx context.Context, registry *lynqv1.LynqHub) (datasource.Config, datasource.QueryConfig, error) {
+	// Get password (HTTP/plugin bearer token, or sql DSN) from Secret
+	password := ""
+	if passwordRef := sourcePasswordRef(registry); passwordRef != nil {
+		value, err := r.getSecretValue(ctx, registry.Namespace, passwordRef)
+		if err != nil {
+			return datasource.Config{}, datasource.QueryConfig{}, fmt.Errorf("failed to get password secret: %w", err)
+		}
+		password = value
+	}
+
+	// Build datasource config
+	config, table, err := r.buildDatasourceConfig(registry, password)
+	if err != nil {
+		return datasource.Config{}, datasource.QueryConfig{}, err
+	}
+
+	// Load TLS material referenced by the source (MySQL/PostgreSQL/HTTP/plugin)
+	if err := r.loadTLSMaterial(ctx, registry.Namespace, sourceTLS(registry), &config); err != nil {
+		return datasource.Config{}, datasource.QueryConfig{}, err
+	}
+
+	// Load rows from the referenced ConfigMap (configmap specific)
+	if err := r.loadConfigMapRows(ctx, registry, &config); err != nil {
+		return datasource.Config{}, datasource.QueryConfig{}, err
+	}
+
+	queryConfig := datasource.QueryConfig{
+		Table: table,
+		ValueMappings: datasource.ValueMappings{
+			UID:                registry.Spec.ValueMappings.UID,
+			HostOrURL:          registry.Spec.ValueMappings.HostOrURL,
+			Activate:           registry.Spec.ValueMappings.Activate,
+			ActiveValues:       registry.Spec.ValueMappings.ActiveValues,
+			ActivateExpression: registry.Spec.ValueMappings.ActivateExpression,
+		},
+		ExtraMappings: registry.Spec.ExtraValueMappings,
+		ExtraTypes:    extraValueTypes(registry.Spec.ExtraValueTypes),
+		Filters:       sourceFilters(registry),
+		Relations:     sourceRelations(registry),
+	}
+
+	return config, queryConfig, nil
+}
+
+// extraValueTypes converts the declared extra value types to datasource value types
+func extraValueTypes(types map[string]lynqv1.ExtraValueType) map[string]datasource.ValueType {
+	if len(types) == 0 {
+		return nil
+	}
+	converted := make(map[string]datasource.ValueType, len(types))
+	for key, valueType := range types {
+		converted[key] = datasource.ValueType(valueType)
+	}
+	return converted
+}
+
+// sourceRelations converts the hub's relations to datasource relations
+func sourceRelations(registry *lynqv1.LynqHub) []datasource.Relation {
+	if len(registry.Spec.Relations) == 0 {
+		return nil
+	}
+	relations := make([]datasource.Relation, 0, len(registry.Spec.Relations))
+	for _, relation := range registry.Spec.Relations {
+		relations = append(relations, datasource.Relation{
+			Name:       relation.Name,
+			Table:      relation.Table,
+			ForeignKey: relation.ForeignKey,
+			Mappings:   relation.ValueMappings,
+			OrderBy:    relation.OrderBy,
+		})
+	}
+	return relations
+}
+
+// deletionGuardExceeded reports whether deleting the given number of nodes out of the existing
+// nodes exceeds the guard, with a message describing the exceeded limit
+func deletionGuardExceeded(guard *lynqv1.DeletionGuard, deletions, existing int) (bool, string) {
+	if guard == nil || deletions == 0 {
+		return false, ""
+	}
+	if guard.MaxDeletions != nil && deletions > int(*guard.MaxDeletions) {
+		return true, fmt.Sprintf("sync would delete %d of %d LynqNodes, exceeding maxDeletions=%d",
+			deletions, existing, *guard.MaxDeletions)
+	}
+	if guard.MaxDeletionPercent != nil && existing > 0 && deletions*100 > int(*guard.MaxDeletionPercent)*existing {
+		return true, fmt.Sprintf("sync would delete %d of %d LynqNodes (%d%%), exceeding maxDeletionPercent=%d",
+			deletions, existing, deletions*100/existing, *guard.MaxDeletionPercent)
+	}
+	return false, ""
+}
+
+// setDeletionBlockedCondition records the deletion guard state on the hub status
+// The condition is removed when no guard is configured.
+func setDeletionBlockedCondition(status *lynqv1.LynqHubStatus, guard *lynqv1.DeletionGuard, blocked bool, message string) {
+	if guard == nil {
+		meta.RemoveStatusCondition(&status.Conditions, ConditionTypeDeletionBlocked)
+		return
+	}
+	condition := metav1.Condition{
+		Type:    ConditionTypeDeletionBlocked,
+		Status:  metav1.ConditionFalse,
+		Reason:  "WithinLimits",
+		Message: "Deletions are within the deletion guard limits",
+	}
+	if blocked {
+		condition.Status = metav1.ConditionTrue
+		condition.Reason = "DeletionLimitExceeded"
+		condition.Message = fmt.Sprintf("%s; set annotation %s=true to approve", message, lynqv1.AnnotationApproveDeletions)
+	}
+	meta.SetStatusCondition(&status.Conditions, condition)
+}
+
+// screenRows returns the rows whose uid can name a LynqNode of every default-named template, and
+// the rejected rows. Under the sanitize policy invalid uids are rewritten first; of several rows
+// with the same uid the first is kept.
+func screenRows(rows []datasource.NodeRow, templates []*lynqv1.LynqForm, policy lynqv1.InvalidRowPolicy) ([]datasource.NodeRow, []lynqv1.RejectedRow) {
+	var rejected []lynqv1.RejectedRow
+	valid := make([]datasource.NodeRow, 0, len(rows))
+	seen := make(map[string]string, len(rows))
+	for _, row := range rows {
+		original := row.UID
+		if original == "" {
+			rejected = append(rejected, lynqv1.RejectedRow{
+				Reason:  lynqv1.RowRejectedEmptyUID,
+				Message: "row has an empty uid",
+			})
+			continue
+		}
+
+		if reason, message := uidProblem(original, templates); reason != "" {
+			sanitized := ""
+			if policy == lynqv1.InvalidRowPolicySanitize {
+				sanitized = sanitizeUID(original, templates)
+			}
+			if sanitized == "" {
+				rejected = append(rejected, lynqv1.RejectedRow{UID: original, Reason: reason, Message: message})
+				continue
+			}
+			row.UID = sanitized
+		}
+
+		if first, ok := seen[row.UID]; ok {
+			message := fmt.Sprintf("uid %q is used by more than one row; the first row is synced", row.UID)
+			if first != original || row.UID != original {
+				message = fmt.Sprintf("uid %q sanitizes to %q, which another row already uses", original, row.UID)
+			}
+			rejected = append(rejected, lynqv1.RejectedRow{UID: original, Reason: lynqv1.RowRejectedDuplicateUID, Message: message})
+			continue
+		}
+		seen[row.UID] = original
+		valid = append(valid, row)
+	}
+	return valid, rejected
+}
+
+// uidProblem returns the rejection reason and message for a uid that cannot name a LynqNode
+// of the given forms. Without forms every non-empty uid can be used: rendered names and labels
+// are made valid with a hash suffix.
+func uidProblem(uid string, templates []*lynqv1.LynqForm) (string, string) {
+	if len(templates) == 0 {
+		return "", ""
+	}
+	if errs := validation.IsValidLabelValue(uid); len(errs) > 0 {
+		return lynqv1.RowRejectedInvalidUID, fmt.Sprintf("uid %q is not a valid label value: %s", uid, strings.Join(errs, "; "))
+	}
+	for _, tmpl := range templates {
+		name := fmt.Sprintf("%s-%s", uid, tmpl.Name)
+		if len(name) > validation.DNS1123SubdomainMaxLength {
+			return lynqv1.RowRejectedNameTooLong, fmt.Sprintf("LynqNode name for uid %q and LynqForm %s is longer than %d characters",
+				uid, tmpl.Name, validation.DNS1123SubdomainMaxLength)
+		}
+		if errs := validation.IsDNS1123Subdomain(name); len(errs) > 0 {
+			return lynqv1.RowRejectedInvalidUID, fmt.Sprintf("uid %q gives the invalid LynqNode name %q: %s", uid, name, strings.Join(errs, "; "))
+		}
+	}
+	return "", ""
+}
+
+// sanitizeUID rewrites a uid into a lowercase DNS label short enough for every template's
+// LynqNode name, or returns "" when nothing valid is left
+func sanitizeUID(uid string, templates []*lynqv1.LynqForm) string {
+	maxLength := validation.DNS1123LabelMaxLength
+	for _, tmpl := range templates {
+		maxLength = min(maxLength, validation.DNS1123SubdomainMaxLength-len(tmpl.Name)-1)
+	}
+
+	sanitized := []byte(strings.ToLower(uid))
+	for i, c := range sanitized {
+		if (c < 'a' || c > 'z') && (c < '0' || c > '9') {
+			sanitized[i] = '-'
+		}
+	}
+	result := strings.Trim(string(sanitized), "-")
+	if len(result) > maxLength {
+		result = strings.TrimRight(result[:max(maxLength, 0)], "-")
+	}
+	if result == "" {
+		return ""
+	}
+	if reason, _ := uidProblem(result, templates); reason != "" {
+		return ""
+	}
+	return result
+}
+
+// defaultNamedForms returns the forms whose LynqNodes are named "{uid}-{form}", the forms for which
+// uids must be valid in object names and labels
+func defaultNamedForms(registry *lynqv1.LynqHub, templates []*lynqv1.LynqForm) []*lynqv1.LynqForm {
+	forms := make([]*lynqv1.LynqForm, 0, len(templates))
+	for _, tmpl := range templates {
+		if nodeNameTemplate(registry, tmpl) == "" {
+			forms = append(forms, tmpl)
+		}
+	}
+	return forms
+}
+
+// nodeNameTemplate returns the LynqNode name template of a form: its own, else the hub's, else ""
+func nodeNameTemplate(registry *lynqv1.LynqHub, tmpl *lynqv1.LynqForm) string {
+	if tmpl.Spec.NodeNameTemplate != "" {
+		return tmpl.Spec.NodeNameTemplate
+	}
+	return registry.Spec.NodeNameTemplate
+}
+
+// nodeVariables returns the template variables of a row's LynqNode
+func nodeVariables(registry *lynqv1.LynqHub, tmpl *lynqv1.LynqForm, row datasource.NodeRow) template.Variables {
+	vars := template.BuildVariables(row.UID, row.HostOrURL, row.Activate, row.Values())
+	// Add hubId and templateRef for template rendering (needed for labelsTemplate, annotationsTemplate)
+	vars["hubId"] = registry.Name
+	vars["templateRef"] = tmpl.Name
+	return vars
+}
+
+// rowSelected reports whether a form applies to a row: true without a rowSelector, otherwise
+// when all its matchValues, matchExpressions and expression match the row's variables
+func rowSelected(engine *template.Engine, registry *lynqv1.LynqHub, tmpl *lynqv1.LynqForm, row datasource.NodeRow) (bool, error) {
+	selector := tmpl.Spec.RowSelector
+	if selector == nil {
+		return true, nil
+	}
+	vars := nodeVariables(registry, tmpl, row)
+	for key, value := range selector.MatchValues {
+		if rowValue(vars, key) != value {
+			return false, nil
+		}
+	}
+	for _, req := range selector.MatchExpressions {
+		if !requirementMatches(req, rowValue(vars, req.Key)) {
+			return false, nil
+		}
+	}
+	if selector.Expression == "" {
+		return true, nil
+	}
+	rendered, err := engine.Render(selector.Expression, vars)
+	if err != nil {
+		return false, fmt.Errorf("failed to render rowSelector.expression: %w", err)
+	}
+	return strings.TrimSpace(rendered) == "true", nil
+}
+
+// rowValue returns the string form of a row variable, or "" when the row has no such value
+func rowValue(vars template.Variables, key string) string {
+	value, ok := vars[key]
+	if !ok || value == nil {
+		return ""
+	}
+	return fmt.Sprint(value)
+}
+
+// requirementMatches reports whether a row value meets a rowSelector requirement; empty values
+// count as missing
+func requirementMatches(req lynqv1.RowSelectorRequirement, value string) bool {
+	switch req.Operator {
+	case lynqv1.RowSelectorOpIn:
+		return value != "" && containsString(req.Values, value)
+	case lynqv1.RowSelectorOpNotIn:
+		return !containsString(req.Values, value)
+	case lynqv1.RowSelectorOpExists:
+		return value != ""
+	case lynqv1.RowSelectorOpDoesNotExist:
+		return value == ""
+	default:
+		return false
+	}
+}
+
+// lynqNodeName returns the name of the LynqNode for a row and form
+// Without a nodeNameTemplate the name is "{uid}-{form}"; screenRows rejects uids for which that
+// is invalid. Rendered names are made valid with safeObjectName.
+func lynqNodeName(engine *template.Engine, registry *lynqv1.LynqHub, tmpl *lynqv1.LynqForm, row datasource.NodeRow) (string, error) {
+	nameTemplate := nodeNameTemplate(registry, tmpl)
+	if nameTemplate == "" {
+		return fmt.Sprintf("%s-%s", row.UID, tmpl.Name), nil
+	}
+	rendered, err := engine.Render(nameTemplate, nodeVariables(registry, tmpl, row))
+	if err != nil {
+		return "", fmt.Errorf("failed to render nodeNameTemplate: %w", err)
+	}
+	return safeObjectName(strings.TrimSpace(rendered)), nil
+}
+
+// safeObjectName returns name when it is a valid object name (DNS-1123 subdomain), and otherwise
+// a valid name derived from it: lowercased, other characters replaced with "-", truncated, and
+// suffixed with a hash of name so that different names stay different
+func safeObjectName(name string) string {
+	if len(validation.IsDNS1123Subdomain(name)) == 0 {
+		return name
+	}
+	cleaned := []byte(strings.ToLower(name))
+	for i, c := range cleaned {
+		if (c < 'a' || c > 'z') && (c < '0' || c > '9') {
+			cleaned[i] = '-'
+		}
+	}
+	return withHashSuffix(strings.Trim(string(cleaned), "-"), name, validation.DNS1123SubdomainMaxLength, "-")
+}
+
+// uidLabelValue returns the lynq.sh/uid label value of a uid: the uid itself when it is a valid
+// label value, and otherwise a valid value derived from it with a hash suffix
+// The raw uid is kept in the LynqNode's spec.uid.
+func uidLabelValue(uid string) string {
+	if len(validation.IsValidLabelValue(uid)) == 0 {
+		return uid
+	}
+	cleaned := []byte(uid)
+	for i, c := range cleaned {
+		if (c < 'a' || c > 'z') && (c < 'A' || c > 'Z') && (c < '0' || c > '9') && c != '.' && c != '_' && c != '-' {
+			cleaned[i] = '-'
+		}
+	}
+	return withHashSuffix(strings.Trim(string(cleaned), "-_."), uid, validation.LabelValueMaxLength, "-_.")
+}
+
+// withHashSuffix appends "-{hash of original}" to prefix, truncating prefix (and trimming the
+// cutset from its end) so the result fits in maxLength
+func withHashSuffix(prefix, original string, maxLength int, cutset string) string {
+	sum := sha256.Sum256([]byte(original))
+	suffix := hex.EncodeToString(sum[:5])
+	if maxPrefix := maxLength - len(suffix) - 1; len(prefix) > maxPrefix {
+		prefix = strings.TrimRight(prefix[:maxPrefix], cutset)
+	}
+	if prefix == "" {
+		return suffix
+	}
+	return prefix + "-" + suffix
+}
+
+// findLynqNode looks up the LynqNode of a uid and form by the lynq.sh/uid label (nil when there is none)
+// Node names come from nodeNameTemplate and cannot be reconstructed from the uid.
+func (r *LynqHubReconciler) findLynqNode(ctx context.Context, registry *lynqv1.LynqHub, templateName, uid string) (*lynqv1.LynqNode, error) {
+	nodes := &lynqv1.LynqNodeList{}
+	if err := r.List(ctx, nodes, client.InNamespace(registry.Namespace), client.MatchingLabels{
+		"lynq.sh/hub": registry.Name,
+		"lynq.sh/uid": uidLabelValue(uid),
+	}); err != nil {
+		return nil, err
+	}
+	for i := range nodes.Items {
+		if nodes.Items[i].Spec.UID == uid && nodes.Items[i].Spec.TemplateRef == templateName {
+			return &nodes.Items[i], nil
+		}
+	}
+	return nil, nil
+}
+
+// invalidRowPolicyName returns the policy, with the default for unset values
+func invalidRowPolicyName(policy lynqv1.InvalidRowPolicy) lynqv1.InvalidRowPolicy {
+	if policy == "" {
+		return lynqv1.InvalidRowPolicySkip
+	}
+	return policy
+}
+
+// summarizeRejectedRows returns the messages of the first rejected rows out of total, for events and conditions
+func summarizeRejectedRows(rejected []lynqv1.RejectedRow, total int) string {
+	const shown = 3
+	messages := make([]string, 0, shown+1)
+	for i, row := range rejected {
+		if i == shown {
+			break
+		}
+		messages = append(messages, row.Message)
+	}
+	if total > len(messages) {
+		messages = append(messages, fmt.Sprintf("and %d more", total-len(messages)))
+	}
+	return strings.Join(messages, "; ")
+}
+
+// recordRejectedRows records the rows rejected by a sync in the hub status
+// A full sync replaces the previous list. An incremental sync only read some rows, so previous
+// entries of uids it did not read are kept.
+func recordRejectedRows(status *lynqv1.LynqHubStatus, rejected []lynqv1.RejectedRow, policy lynqv1.InvalidRowPolicy, incremental bool, readUIDs map[string]struct{}) {
+	rows := rejected
+	count := int32(len(rejected))
+	// Under the fail policy a halted sync is read again in full, so only its own rows count
+	if incremental && policy != lynqv1.InvalidRowPolicyFail {
+		rows = nil
+		count = status.RejectedRowCount
+		for _, previous := range status.RejectedRows {
+			if _, read := readUIDs[previous.UID]; read {
+				count--
+				continue
+			}
+			rows = append(rows, previous)
+		}
+		rows = append(rows, rejected...)
+		count = max(count+int32(len(rejected)), int32(len(rows)))
+	}
+	if len(rows) > maxRejectedRows {
+		rows = rows[:maxRejectedRows]
+	}
+	status.RejectedRows = rows
+	status.RejectedRowCount = count
+
+	condition := metav1.Condition{
+		Type:    ConditionTypeRowsRejected,
+		Status:  metav1.ConditionFalse,
+		Reason:  "AllRowsValid",
+		Message: "Every row has a valid uid",
+	}
+	switch {
+	case count > 0 && policy == lynqv1.InvalidRowPolicyFail:
+		condition.Status = metav1.ConditionTrue
+		condition.Reason = "SyncHalted"
+		condition.Message = fmt.Sprintf("%d rows have an invalid uid; no changes are applied while invalidRowPolicy is fail: %s",
+			count, summarizeRejectedRows(rows, int(count)))
+	case count > 0:
+		condition.Status = metav1.ConditionTrue
+		condition.Reason = "RowsSkipped"
+		condition.Message = fmt.Sprintf("%d rows are not synced: %s", count, summarizeRejectedRows(rows, int(count)))
+	}
+	meta.SetStatusCondition(&status.Conditions, condition)
+}
+
+// rowSetHash returns a short, order-independent hash of the active rows
+func rowSetHash(rows []datasource.NodeRow) string {
+	sorted := make([]datasource.NodeRow, len(rows))
+	copy(sorted, rows)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].UID < sorted[j].UID })
+
+	// Marshaling a NodeRow cannot fail; map keys are sorted, so the output is stable
+	data, _ := json.Marshal(sorted)
+	hash := sha256.Sum256(data)
+	return hex.EncodeToString(hash[:8])
+}
+
+// recordSyncSuccess records a successful datasource sync in the hub status
+// An empty hash keeps the previous one (incremental syncs do not see the full row set).
+func recordSyncSuccess(status *lynqv1.LynqHubStatus, now time.Time, rowCount int32, hash string) {
+	snapshot := status.Snapshot
+	if snapshot == nil {
+		snapshot = &lynqv1.DataSnapshotStatus{}
+	}
+	syncTime := metav1.NewTime(now)
+	snapshot.LastSuccessfulSyncTime = &syncTime
+	snapshot.RowCount = rowCount
+	if hash != "" {
+		snapshot.Hash = hash
+	}
+	snapshot.ConsecutiveFailures = 0
+	snapshot.LastError = ""
+	snapshot.NextRetryTime = nil
+	status.Snapshot = snapshot
+
+	meta.SetStatusCondition(&status.Conditions, metav1.Condition{
+		Type:    ConditionTypeDataStale,
+		Status:  metav1.ConditionFalse,
+		Reason:  "DataCurrent",
+		Message: "LynqNodes reflect the latest datasource sync",
+	})
+	if meta.IsStatusConditionTrue(status.Conditions, ConditionTypeCircuitOpen) {
+		meta.SetStatusCondition(&status.Conditions, metav1.Condition{
+			Type:    ConditionTypeCircuitOpen,
+			Status:  metav1.ConditionFalse,
+			Reason:  "SyncSucceeded",
+			Message: "Datasource sync succeeded; circuit breaker closed",
+		})
+	}
+}
+
+// recordSyncFailure records a failed datasource sync in the hub status
+// The snapshot of the last successful sync is kept; LynqNodes continue to serve its data.
+func recordSyncFailure(status *lynqv1.LynqHubStatus, now time.Time, syncErr error) {
+	snapshot := status.Snapshot
+	if snapshot == nil {
+		snapshot = &lynqv1.DataSnapshotStatus{}
+	}
+	failureTime := metav1.NewTime(now)
+	snapshot.ConsecutiveFailures++
+	snapshot.LastFailureTime = &failureTime
+	snapshot.LastError = syncErr.Error()
+	if len(snapshot.LastError) > maxSyncErrorLength {
+		snapshot.LastError = snapshot.LastError[:maxSyncErrorLength] + "..."
+	}
+	status.Snapshot = snapshot
+
+	message := fmt.Sprintf("Datasource unavailable for %d consecutive syncs; no successful sync yet",
+		snapshot.ConsecutiveFailures)
+	if snapshot.LastSuccessfulSyncTime != nil {
+		age := now.Sub(snapshot.LastSuccessfulSyncTime.Time).Truncate(time.Second)
+		message = fmt.Sprintf("Datasource unavailable for %d consecutive syncs; serving data from %s (%s old)",
+			snapshot.ConsecutiveFailures, snapshot.LastSuccessfulSyncTime.UTC().Format(time.RFC3339), age)
+	}
+	meta.SetStatusCondition(&status.Conditions, metav1.Condition{
+		Type:    ConditionTypeDataStale,
+		Status:  metav1.ConditionTrue,
+		Reason:  "DatasourceUnavailable",
+		Message: message,
+	})
+}
+
+// syncRetry schedules the next sync after a failed one
+type syncRetry struct {
+	// delay is the time until the next sync
+	delay time.Duration
+	// failures counts the consecutive failed syncs, including this one
+	failures int32
+	// threshold is the circuit breaker threshold (0 when disabled)
+	threshold int32
+	// circuitOpen is true when the failures reached the threshold; delay is then the cooldown
+	circuitOpen bool
+}
+
+// nextSyncRetry schedules the sync after the next consecutive failure of the hub's datasource
+// The delay starts at syncInterval and doubles per failure up to retry.maxBackoff. Once the
+// failures reach retry.circuitBreakerThreshold, the circuit opens for retry.circuitBreakerCooldown.
+func nextSyncRetry(registry *lynqv1.LynqHub, syncInterval time.Duration) syncRetry {
+	schedule := syncRetry{failures: 1, threshold: defaultCircuitBreakerThreshold}
+	if snapshot := registry.Status.Snapshot; snapshot != nil {
+		schedule.failures = snapshot.ConsecutiveFailures + 1
+	}
+
+	maxBackoff, cooldown := defaultMaxBackoff, defaultCircuitBreakerCooldown
+	if policy := registry.Spec.Retry; policy != nil {
+		maxBackoff = parseDurationOr(policy.MaxBackoff, defaultMaxBackoff)
+		cooldown = parseDurationOr(policy.CircuitBreakerCooldown, defaultCircuitBreakerCooldown)
+		if policy.CircuitBreakerThreshold != nil {
+			schedule.threshold = *policy.CircuitBreakerThreshold
+		}
+	}
+
+	schedule.delay = backoffDelay(syncInterval, maxBackoff, schedule.failures)
+	if schedule.threshold > 0 && schedule.failures >= schedule.threshold {
+		schedule.circuitOpen = true
+		schedule.delay = cooldown
+	}
+	schedule.delay += time.Duration(rand.Int63n(int64(float64(schedule.delay)*retryJitter) + 1))
+	return schedule
+}
+
+// backoffDelay returns syncInterval doubled for every failure after the first, capped at
+// maxBackoff but never shorter than syncInterval
+func backoffDelay(syncInterval, maxBackoff time.Duration, failures int32) time.Duration {
+	maxBackoff = max(maxBackoff, syncInterval)
+	delay := syncInterval
+	for i := int32(1); i < failures && delay < maxBackoff; i++ {
+		delay *= 2
+	}
+	return min(delay, maxBackoff)
+}
+
+// recordSyncRetry records when the next sync runs after a failure, and opens the circuit
+// breaker when the failures reached its threshold
+func recordSyncRetry(status *lynqv1.LynqHubStatus, now time.Time, schedule syncRetry) {
+	if status.Snapshot == nil {
+		status.Snapshot = &lynqv1.DataSnapshotStatus{}
+	}
+	next := metav1.NewTime(now.Add(schedule.delay))
+	status.Snapshot.NextRetryTime = &next
+
+	if schedule.circuitOpen {
+		meta.SetStatusCondition(&status.Conditions, metav1.Condition{
+			Type:   ConditionTypeCircuitOpen,
+			Status: metav1.ConditionTrue,
+			Reason: "FailureThresholdReached",
+			Message: fmt.Sprintf("%d consecutive failed syncs (threshold %d); the datasource is not queried until %s",
+				status.Snapshot.ConsecutiveFailures, schedule.threshold, next.UTC().Format(time.RFC3339)),
+		})
+	} else if meta.IsStatusConditionTrue(status.Conditions, ConditionTypeCircuitOpen) {
+		// The threshold was raised or the circuit breaker disabled
+		meta.SetStatusCondition(&status.Conditions, metav1.Condition{
+			Type:    ConditionTypeCircuitOpen,
+			Status:  metav1.ConditionFalse,
+			Reason:  "BelowThreshold",
+			Message: "Consecutive failed syncs are below retry.circuitBreakerThreshold",
+		})
+	}
+}
+
+// circuitOpenFor returns how long the circuit breaker of the hub stays open (0 when closed)
+// A spec change the status has not observed yet closes it, so a fixed source is probed right away.
+func circuitOpenFor(registry *lynqv1.LynqHub, now time.Time) time.Duration {
+	if registry.Generation != registry.Status.ObservedGeneration ||
+		!meta.IsStatusConditionTrue(registry.Status.Conditions, ConditionTypeCircuitOpen) {
+		return 0
+	}
+	snapshot := registry.Status.Snapshot
+	if snapshot == nil || snapshot.NextRetryTime == nil {
+		return 0
+	}
+	return max(snapshot.NextRetryTime.Sub(now), 0)
+}
+
+// isSQLSource reports whether the hub reads from a SQL database (mysql, postgresql or sql)
+func isSQLSource(registry *lynqv1.LynqHub) bool {
+	switch registry.Spec.Source.Type {
+	case lynqv1.SourceTypeMySQL, lynqv1.SourceTypePostgreSQL, lynqv1.SourceTypeSQL:
+		return true
+	default:
+		return false
+	}
+}
+
+// schemaCheckDue reports whether the hub's column mappings should be checked against the table schema
+// The check runs once per hub generation; until it passes it is repeated on every sync, so
+// fixing the table (rather than the hub) is picked up too.
+func schemaCheckDue(registry *lynqv1.LynqHub) bool {
+	if !isSQLSource(registry) {
+		return false
+	}
+	condition := meta.FindStatusCondition(registry.Status.Conditions, ConditionTypeSchemaValid)
+	return condition == nil || condition.ObservedGeneration != registry.Generation || condition.Status != metav1.ConditionTrue
+}
+
+// checkSchema compares the hub's column mappings to the table schema when a check is due
+// It returns the status update recording the result, or nil when there is nothing to record.
+func (r *LynqHubReconciler) checkSchema(ctx context.Context, registry *lynqv1.LynqHub) func(*lynqv1.LynqHubStatus) {
+	if !isSQLSource(registry) {
+		if meta.FindStatusCondition(registry.Status.Conditions, ConditionTypeSchemaValid) == nil {
+			return nil
+		}
+		// The source is no longer a SQL database
+		return func(status *lynqv1.LynqHubStatus) {
+			meta.RemoveStatusCondition(&status.Conditions, ConditionTypeSchemaValid)
+			status.SchemaIssues = nil
+		}
+	}
+	if !schemaCheckDue(registry) {
+		return nil
+	}
+
+	ds, queryConfig, err := r.openDatasource(ctx, registry)
+	if err != nil {
+		// syncRows reports the connection failure
+		return nil
+	}
+	inspector, ok := ds.(datasource.SchemaInspector)
+	if !ok {
+		r.releaseDatasource(registry, ds, nil)
+		return nil
+	}
+	issues, err := datasource.CheckSchema(ctx, inspector, queryConfig)
+	r.releaseDatasource(registry, ds, err)
+
+	if err != nil {
+		log.FromContext(ctx).Error(err, "Failed to inspect the table schema")
+	} else if len(issues) > 0 {
+		r.Recorder.Eventf(registry, corev1.EventTypeWarning, "SchemaMismatch",
+			"Column mappings do not match the table schema: %s", joinSchemaIssues(issues))
+	}
+	generation := registry.Generation
+	return func(status *lynqv1.LynqHubStatus) {
+		recordSchemaCheck(status, generation, issues, err)
+	}
+}
+
+// recordSchemaCheck records the result of a schema check in the hub status
+func recordSchemaCheck(status *lynqv1.LynqHubStatus, generation int64, issues []datasource.SchemaIssue, inspectErr error) {
+	condition := metav1.Condition{
+		Type:               ConditionTypeSchemaValid,
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: generation,
+		Reason:             "ColumnsFound",
+		Message:            "All mapped columns exist in the source table",
+	}
+	status.SchemaIssues = nil
+	switch {
+	case inspectErr != nil:
+		condition.Status = metav1.ConditionUnknown
+		condition.Reason = "InspectionFailed"
+		condition.Message = inspectErr.Error()
+		if len(condition.Message) > maxSyncErrorLength {
+			condition.Message = condition.Message[:maxSyncErrorLength] + "..."
+		}
+	case len(issues) > 0:
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = "SchemaMismatch"
+		condition.Message = joinSchemaIssues(issues)
+		status.SchemaIssues = make([]lynqv1.SchemaIssue, 0, len(issues))
+		for _, issue := range issues {
+			status.SchemaIssues = append(status.SchemaIssues, lynqv1.SchemaIssue{
+				Field:   issue.Field,
+				Column:  issue.Column,
+				Reason:  issue.Reason,
+				Message: issue.Message,
+			})
+		}
+	}
+	meta.SetStatusCondition(&status.Conditions, condition)
+}
+
+// joinSchemaIssues joins the issue messages into one line
+func joinSchemaIssues(issues []datasource.SchemaIssue) string {
+	messages := make([]string, 0, len(issues))
+	for _, issue := range issues {
+		messages = append(messages, issue.Message)
+	}
+	return strings.Join(messages, "; ")
+}
+
+// SchemaWarnings connects to the hub's datasource and returns a message for every mapped column
+// that does not match the table schema. It backs the LynqHub admission warnings, so it opens a
+// connection of its own instead of using the datasource cache.
+func (r *LynqHubReconciler) SchemaWarnings(ctx context.Context, hub *lynqv1.LynqHub) ([]string, error) {
+	config, queryConfig, err := r.resolveDatasourceConfig(ctx, hub)
+	if err != nil {
+		return nil, err
+	}
+	ds, err := datasource.NewDatasource(datasource.SourceType(hub.Spec.Source.Type), config)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = ds.Close() // Best effort close
+	}()
+
+	inspector, ok := ds.(datasource.SchemaInspector)
+	if !ok {
+		return nil, nil
+	}
+	issues, err := datasource.CheckSchema(ctx, inspector, queryConfig)
+	if err != nil {
+		return nil, err
+	}
+	messages := make([]string, 0, len(issues))
+	for _, issue := range issues {
+		messages = append(messages, issue.Message)
+	}
+	return messages, nil
+}
+
+// clearDeletionApproval removes the deletion approval annotation from the hub
+func (r *LynqHubReconciler) clearDeletionApproval(ctx context.Context, registry *lynqv1.LynqHub) error {
+	patch := client.MergeFrom(registry.DeepCopy())
+	delete(registry.Annotations, lynqv1.AnnotationApproveDeletions)
+	return r.Patch(ctx, registry, patch)
+}
+
+// releaseDatasource returns an adapter obtained from openDatasource
+// Uncached adapters are closed. A cached adapter is kept open unless the query failed, in which
+// case it is evicted so the next sync starts from a fresh connection.
+func (r *LynqHubReconciler) releaseDatasource(registry *lynqv1.LynqHub, ds datasource.Datasource, queryErr error) {
+	if r.Datasources == nil {
+		_ = ds.Close() // Best effort close
+		return
+	}
+	if queryErr != nil {
+		r.Datasources.Evict(datasourceKey(registry))
+	}
+}
+
+// rowSync is the result of querying the datasource for one reconcile
+type rowSync struct {
+	// rows are the active rows to apply: all active rows for a full sync,
+	// only the changed active rows for an incremental sync
+	rows []datasource.NodeRow
+
+	// incremental is true when rows only contain changes since the watermark.
+	// Nodes whose rows are not in the result are kept unless their UID is in inactiveUIDs.
+	incremental  bool
+	inactiveUIDs map[string]struct{}
+
+	// nextState is the incremental sync state to persist once all changes are applied
+	// (nil when incremental sync is disabled)
+	nextState *lynqv1.IncrementalSyncStatus
+
+	// endpoint is the datasource endpoint that served the query ("" when not reported)
+	endpoint string
+
+	// mergeSources reports the rows read from each merge source
+	mergeSources []lynqv1.MergeSourceStatus
+}
+
+// mergeSourceRows reads every merge source of the hub and joins its rows to rows by uid
+// A merge source that cannot be read fails the sync, like the primary source.
+func (r *LynqHubReconciler) mergeSourceRows(ctx context.Context, registry *lynqv1.LynqHub, rows []datasource.NodeRow) ([]datasource.NodeRow, []lynqv1.MergeSourceStatus, error) {
+	if len(registry.Spec.MergeSources) == 0 {
+		return rows, nil, nil
+	}
+
+	sets := make([]datasource.MergeSet, 0, len(registry.Spec.MergeSources))
+	for _, source := range registry.Spec.MergeSources {
+		sourceRows, _, err := r.queryDatabase(ctx, mergeSourceHub(registry, source))
+		if err != nil {
+			return nil, nil, fmt.Errorf("merge source %s: %w", source.Name, err)
+		}
+		sets = append(sets, datasource.MergeSet{Name: source.Name, Rows: sourceRows, Required: source.Required})
+	}
+
+	merged, matched := datasource.MergeRows(rows, sets)
+	statuses := make([]lynqv1.MergeSourceStatus, 0, len(sets))
+	for i, set := range sets {
+		statuses = append(statuses, lynqv1.MergeSourceStatus{
+			Name:    set.Name,
+			Rows:    int32(len(set.Rows)),
+			Matched: int32(matched[i]),
+		})
+	}
+	return merged, statuses, nil
+}
+
+// mergeSourceHub returns the hub copy that reads a merge source
+// Its UID is "{hub UID}/{source name}", so the source keeps a cached connection of its own,
+// evicted together with the hub's.
+func mergeSourceHub(registry *lynqv1.LynqHub, source lynqv1.MergeSource) *lynqv1.LynqHub {
+	hub := registry.MergeSourceHub(source)
+	hub.UID = types.UID(fmt.Sprintf("%s/%s", registry.UID, source.Name))
+	return hub
+}
+
+// readUIDs returns the uids of the rows read from the datasource, active and inactive
+func (s *rowSync) readUIDs() map[string]struct{} {
+	uids := make(map[string]struct{}, len(s.rows)+len(s.inactiveUIDs))
+	for _, row := range s.rows {
+		uids[row.UID] = struct{}{}
+	}
+	for uid := range s.inactiveUIDs {
+		uids[uid] = struct{}{}
+	}
+	return uids
+}
+
+// syncRows queries the rows to apply in this reconcile
+// Without spec.source.updatedAtColumn this is a plain full query. With it, only rows changed
+// since the persisted watermark are queried, falling back to a full resync when there is no
+// watermark, the hub or its LynqForms changed, or fullResyncInterval elapsed.
+func (r *LynqHubReconciler) syncRows(ctx context.Context, registry *lynqv1.LynqHub, templates []*lynqv1.LynqForm) (*rowSync, error) {
+	column := registry.Spec.Source.UpdatedAtColumn
+	if column == "" {
+		rows, endpoint, err := r.queryDatabase(ctx, registry)
+		if err != nil {
+			return nil, err
+		}
+		rows, mergeStatus, err := r.mergeSourceRows(ctx, registry, rows)
+		if err != nil {
+			return nil, err
+		}
+		return &rowSync{rows: rows, endpoint: endpoint, mergeSources: mergeStatus}, nil
+	}
+
+	ds, queryConfig, err := r.openDatasource(ctx, registry)
+	if err != nil {
+		return nil, err
+	}
+
+	incrementalDS, ok := ds.(datasource.IncrementalDatasource)
+	if !ok {
+		r.releaseDatasource(registry, ds, nil)
+		return nil, fmt.Errorf("source type %s does not support updatedAtColumn", registry.Spec.Source.Type)
+	}
+	queryConfig.UpdatedAtColumn = column
+
+	now := time.Now()
+	fingerprint := incrementalSyncFingerprint(registry, templates)
+	since := incrementalSyncSince(registry, fingerprint, now)
+
+	timeout := queryTimeout(registry)
+	queryCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	changes, err := incrementalDS.QueryChangedNodes(queryCtx, queryConfig, since)
+	err = queryTimeoutError(queryCtx, timeout, err)
+	endpoint := activeEndpoint(ds)
+	r.releaseDatasource(registry, ds, err)
+	if err != nil {
+		return nil, err
+	}
+
+	active, mergeStatus, err := r.mergeSourceRows(ctx, registry, changes.Active)
+	if err != nil {
+		return nil, err
+	}
+
+	state := &lynqv1.IncrementalSyncStatus{Fingerprint: fingerprint}
+	if !changes.Watermark.IsZero() {
+		// Status timestamps have second precision; truncating keeps the next ">=" query inclusive
+		watermark := metav1.NewTime(changes.Watermark.Truncate(time.Second))
+		state.Watermark = &watermark
+	}
+
+	if since.IsZero() {
+		lastFullSync := metav1.NewTime(now)
+		state.LastFullSyncTime = &lastFullSync
+		return &rowSync{rows: active, nextState: state, endpoint: endpoint, mergeSources: mergeStatus}, nil
+	}
+
+	state.LastFullSyncTime = registry.Status.IncrementalSync.LastFullSyncTime
+	inactiveUIDs := make(map[string]struct{}, len(changes.InactiveUIDs))
+	for _, uid := range changes.InactiveUIDs {
+		inactiveUIDs[uid] = struct{}{}
+	}
+	// Changed rows dropped by a required merge source are removed like inactive rows
+	kept := make(map[string]struct{}, len(active))
+	for _, row := range active {
+		kept[row.UID] = struct{}{}
+	}
+	for _, row := range changes.Active {
+		if _, ok := kept[row.UID]; !ok {
+			inactiveUIDs[row.UID] = struct{}{}
+		}
+	}
+
+	return &rowSync{
+		rows:         active,
+		incremental:  true,
+		inactiveUIDs: inactiveUIDs,
+		nextState:    state,
+		endpoint:     endpoint,
+		mergeSources: mergeStatus,
+	}, nil
+}
+
+// incrementalSyncSince returns the watermark to query from, or zero when a full resync is needed
+func incrementalSyncSince(registry *lynqv1.LynqHub, fingerprint string, now time.Time) time.Time {
+	state := registry.Status.IncrementalSync
+	if state == nil || state.Watermark == nil || state.LastFullSyncTime == nil || state.Fingerprint != fingerprint {
+		return time.Time{}
+	}
+
+	fullResyncInterval := defaultFullResyncInterval
+	if registry.Spec.Source.FullResyncInterval != "" {
+		if parsed, err := time.ParseDuration(registry.Spec.Source.FullResyncInterval); err == nil {
+			fullResyncInterval = parsed
+		}
+	}
+	if now.Sub(state.LastFullSyncTime.Time) >= fullResyncInterval {
+		return time.Time{}
+	}
+
+	return state.Watermark.Time
+}
+
+// incrementalSyncFingerprint identifies the hub spec generation and the referencing LynqForm
+// generations. Incremental syncs only see changed rows, so any change here needs a full resync.
+func incrementalSyncFingerprint(registry *lynqv1.LynqHub, templates []*lynqv1.LynqForm) string {
+	forms := make([]string, 0, len(templates))
+	for _, tmpl := range templates {
+		forms = append(forms, fmt.Sprintf("%s:%d", tmpl.Name, tmpl.Generation))
+	}
+	sort.Strings(forms)
+
+	hash := sha256.Sum256([]byte(fmt.Sprintf("%d|%s", registry.Generation, strings.Join(forms, ","))))
+	return hex.EncodeToString(hash[:8])
+}
+
+// shouldDelete reports whether an incremental sync should delete the node for the given UID and template
+func (s *rowSync) shouldDelete(uid, templateName string, templateMap map[string]*lynqv1.LynqForm) bool {
+	if _, inactive := s.inactiveUIDs[uid]; inactive {
+		return true
+	}
+	_, templateExists := templateMap[templateName]
+	return !templateExists
+}
+
+// countActiveUIDs estimates the number of active rows after an incremental sync from the
+// existing nodes of referencing templates plus changed active rows, minus changed inactive rows
+func (s *rowSync) countActiveUIDs(existingNodes *lynqv1.LynqNodeList, templateMap map[string]*lynqv1.LynqForm) int32 {
+	uids := make(map[string]struct{})
+	for i := range existingNodes.Items {
+		node := &existingNodes.Items[i]
+		if _, ok := templateMap[node.Spec.TemplateRef]; ok {
+			uids[node.Spec.UID] = struct{}{}
+		}
+	}
+	for _, row := range s.rows {
+		uids[row.UID] = struct{}{}
+	}
+	for uid := range s.inactiveUIDs {
+		delete(uids, uid)
+	}
+	return int32(len(uids))
+}
+
+// countUnchangedNodes counts, by template, the existing nodes of referencing templates whose
+// rows were not read by an incremental sync, and so stay desired
+func (s *rowSync) countUnchangedNodes(existingNodes *lynqv1.LynqNodeList, templateMap map[string]*lynqv1.LynqForm) map[string]int32 {
+	changed := s.readUIDs()
+	counts := make(map[string]int32, len(templateMap))
+	for i := range existingNodes.Items {
+		node := &existingNodes.Items[i]
+		if _, ok := templateMap[node.Spec.TemplateRef]; !ok {
+			continue
+		}
+		if _, ok := changed[node.Spec.UID]; !ok {
+			counts[node.Spec.TemplateRef]++
+		}
+	}
+	return counts
+}
+
+// buildDatasourceConfig builds datasource configuration from LynqHub spec
+func (r *LynqHubReconciler) buildDatasourceConfig(registry *lynqv1.LynqHub, password string) (datasource.Config, string, error) {
+	switch registry.Spec.Source.Type {
+	case lynqv1.SourceTypeMySQL:
+		mysql := registry.Spec.Source.MySQL
+		if mysql == nil {
+			return datasource.Config{}, "", fmt.Errorf("MySQL configuration is nil")
+		}
+
+		config := datasource.Config{
+			Host:           mysql.Host,
+			Port:           mysql.Port,
+			FailoverHosts:  mysqlEndpoints(mysql.FailoverHosts),
+			Replicas:       mysqlEndpoints(mysql.Replicas),
+			ReadPreference: string(mysql.ReadPreference),
+			Username:       mysql.Username,
+			Password:       password,
+			Database:       mysql.Database,
+			TLSMode:        string(mysql.TLSMode),
+			Params:         mysql.Params,
+		}
+
+		if pool := mysql.Pool; pool != nil {
+			config.MaxOpenConns = int(pool.MaxOpenConns)
+			config.MaxIdleConns = int(pool.MaxIdleConns)
+			config.ConnMaxLifetime = pool.ConnMaxLifetime
+		}
+
+		return config, mysql.Table, nil
+
+	case lynqv1.SourceTypePostgreSQL:
+		pg := registry.Spec.Source.PostgreSQL
+		if pg == nil {
+			return datasource.Config{}, "", fmt.Errorf("PostgreSQL configuration is nil")
+		}
 
-	// Build template lookup map for shouldUpdateLynqNode (avoids per-node API calls)
-	templateMap := make(map[string]*lynqv1.LynqForm, len(templates))
-	for _, tmpl := range templates {
-		templateMap[tmpl.Name] = tmpl
-	}
+		config := datasource.Config{
+			Host:     pg.Host,
+			Port:     pg.Port,
+			Username: pg.Username,
+			Password: password,
+			Database: pg.Database,
+			Schema:   pg.Schema,
+			SSLMode:  string(pg.SSLMode),
+		}
 
-	// Build existing node map: key = {template-name}-{uid}
-	existing := make(map[NodeKey]*lynqv1.LynqNode)
-	for i := range existingNodes.Items {
-		node := &existingNodes.Items[i]
-		key := NodeKey{
-			TemplateName: node.Spec.TemplateRef,
-			UID:          node.Spec.UID,
+		return config, pg.Table, nil
+
+	case lynqv1.SourceTypeSQL:
+		source := registry.Spec.Source.SQL
+		if source == nil {
+			return datasource.Config{}, "", fmt.Errorf("sql configuration is nil")
 		}
-		existing[key] = node
-	}
 
-	// Group existing nodes by template for maxSkew checking
-	nodesByTemplate := make(map[string][]*lynqv1.LynqNode)
-	for key, node := range existing {
-		nodesByTemplate[key.TemplateName] = append(nodesByTemplate[key.TemplateName], node)
-	}
+		config := datasource.Config{
+			Driver:  source.Driver,
+			Dialect: string(source.Dialect),
+			DSN:     source.DSN,
+			Params:  source.Params,
+		}
+		// A DSN from a Secret is resolved like a password (see sourcePasswordRef)
+		if source.DSNSecretRef != nil {
+			config.DSN = password
+		}
 
-	// Track throttled updates for events
-	throttledByTemplate := make(map[string]int)
+		if pool := source.Pool; pool != nil {
+			config.MaxOpenConns = int(pool.MaxOpenConns)
+			config.MaxIdleConns = int(pool.MaxIdleConns)
+			config.ConnMaxLifetime = pool.ConnMaxLifetime
+		}
 
-	// Track nodes updated in THIS reconcile iteration per template
-	// This is critical for maxSkew enforcement because templateNodes snapshot doesn't reflect
-	// updates made within this loop iteration
-	updatedInThisIteration := make(map[string]int32)
+		return config, source.Table, nil
 
-	// Create/update nodes for each template-row combination
-	for key, desired := range desired {
-		tmpl := desired.Template
-		templateNodes := nodesByTemplate[tmpl.Name]
+	case lynqv1.SourceTypePlugin:
+		plugin := registry.Spec.Source.Plugin
+		if plugin == nil {
+			return datasource.Config{}, "", fmt.Errorf("plugin configuration is nil")
+		}
 
-		if existingLynqNode, exists := existing[key]; !exists {
-			// Create new LynqNode - check maxSkew before creating
-			if r.canUpdateNodeWithCount(ctx, tmpl, templateNodes, updatedInThisIteration[tmpl.Name]) {
-				if err := r.createLynqNode(ctx, registry, tmpl, desired.Row); err != nil {
-					// Ignore AlreadyExists errors (can happen due to concurrent reconciliations)
-					if !errors.IsAlreadyExists(err) {
-						logger.Error(err, "Failed to create LynqNode", "template", key.TemplateName, "uid", key.UID)
-					}
-				} else {
-					// Successfully created - track it
-					updatedInThisIteration[tmpl.Name]++
-				}
-			} else {
-				// Throttled by maxSkew
-				throttledByTemplate[tmpl.Name]++
-			}
-		} else {
-			// Update existing LynqNode if data or template changed
-			if r.shouldUpdateLynqNode(ctx, registry, existingLynqNode, desired.Row, templateMap) {
-				// Check maxSkew before updating
-				if r.canUpdateNodeWithCount(ctx, tmpl, templateNodes, updatedInThisIteration[tmpl.Name]) {
-					if err := r.updateLynqNode(ctx, registry, tmpl, existingLynqNode, desired.Row); err != nil {
-						logger.Error(err, "Failed to update LynqNode", "template", key.TemplateName, "uid", key.UID)
-					} else {
-						// Successfully updated - track it
-						updatedInThisIteration[tmpl.Name]++
-					}
-				} else {
-					// Throttled by maxSkew
-					throttledByTemplate[tmpl.Name]++
-				}
+		config := datasource.Config{
+			Endpoint:     plugin.Endpoint,
+			Table:        plugin.Table,
+			PluginConfig: plugin.Config,
+			PluginTLS:    plugin.TLS != nil,
+			BearerToken:  password,
+		}
+
+		if plugin.Timeout != "" {
+			timeout, err := time.ParseDuration(plugin.Timeout)
+			if err != nil {
+				return datasource.Config{}, "", fmt.Errorf("invalid plugin.timeout: %w", err)
 			}
+			config.Timeout = timeout
 		}
-	}
 
-	// Emit events for throttled updates
-	for tmplName, throttledCount := range throttledByTemplate {
-		// Find the template to get maxSkew value
-		for _, tmpl := range templates {
-			if tmpl.Name == tmplName && tmpl.Spec.Rollout != nil {
-				r.Recorder.Eventf(registry, corev1.EventTypeNormal, "RolloutThrottled",
-					"LynqForm '%s': %d node updates throttled (maxSkew=%d, currently updating=%d)",
-					tmplName, throttledCount, tmpl.Spec.Rollout.MaxSkew,
-					r.countUpdatingNodes(ctx, nodesByTemplate[tmplName], tmpl.Generation))
-				break
+		return config, plugin.Table, nil
+
+	case lynqv1.SourceTypeHTTP:
+		httpSource := registry.Spec.Source.HTTP
+		if httpSource == nil {
+			return datasource.Config{}, "", fmt.Errorf("HTTP configuration is nil")
+		}
+
+		config := datasource.Config{
+			URL:       httpSource.URL,
+			Headers:   httpSource.Headers,
+			ItemsPath: httpSource.ItemsPath,
+		}
+
+		if httpSource.Timeout != "" {
+			timeout, err := time.ParseDuration(httpSource.Timeout)
+			if err != nil {
+				return datasource.Config{}, "", fmt.Errorf("invalid http.timeout: %w", err)
 			}
+			config.Timeout = timeout
 		}
-	}
 
-	// Delete nodes no longer in desired set
-	// This handles:
-	// 1. Rows deleted from database
-	// 2. Rows with activate=false
-	// 3. Templates deleted/changed
-	deletedCount := 0
-	for key, node := range existing {
-		if _, stillExists := desired[key]; !stillExists {
-			logger.Info("Deleting LynqNode (no longer in desired set)",
-				"node", node.Name,
-				"template", key.TemplateName,
-				"uid", key.UID,
-				"reason", "row removed from database or activate=false or template changed")
-
-			// Emit detailed deletion event
-			r.Recorder.Eventf(registry, corev1.EventTypeNormal, "NodeDeleting",
-				"Deleting LynqNode '%s' (template: %s, uid: %s) - no longer in active dataset. "+
-					"This could be due to: row deletion, activate=false, or template change.",
-				node.Name, key.TemplateName, key.UID)
+		if auth := httpSource.Auth; auth != nil {
+			switch auth.Type {
+			case lynqv1.HTTPAuthTypeBearer:
+				config.BearerToken = password
+			case lynqv1.HTTPAuthTypeBasic:
+				config.Username = auth.Username
+				config.Password = password
+			}
+		}
 
-			if err := r.Delete(ctx, node); err != nil {
-				if !errors.IsNotFound(err) {
-					logger.Error(err, "Failed to delete LynqNode", "node", node.Name, "template", key.TemplateName, "uid", key.UID)
-					r.Recorder.Eventf(registry, corev1.EventTypeWarning, "NodeDeletionFailed",
-						"Failed to delete LynqNode '%s': %v", node.Name, err)
-				}
-			} else {
-				deletedCount++
-				r.Recorder.Eventf(registry, corev1.EventTypeNormal, "NodeDeleted",
-					"Successfully deleted LynqNode '%s' (template: %s, uid: %s)",
-					node.Name, key.TemplateName, key.UID)
+		if p := httpSource.Pagination; p != nil {
+			startPage := 1
+			if p.StartPage != nil {
+				startPage = int(*p.StartPage)
+			}
+			config.Pagination = datasource.HTTPPagination{
+				Type:          string(p.Type),
+				CursorPath:    p.CursorPath,
+				CursorParam:   p.CursorParam,
+				PageParam:     p.PageParam,
+				StartPage:     startPage,
+				PageSizeParam: p.PageSizeParam,
+				PageSize:      int(p.PageSize),
+				MaxPages:      int(p.MaxPages),
 			}
 		}
-	}
 
-	if deletedCount > 0 {
-		logger.Info("Garbage collection completed", "deletedNodes", deletedCount)
-	}
+		// HTTP sources have no table; the endpoint is part of the config
+		return config, "", nil
 
-	// Update status (reuse already-fetched node list instead of a separate LIST call)
-	readyCount, failedCount := countNodeStatusFromList(existingNodes)
-	totalDesired := int32(len(templates)) * int32(len(nodeRows))
-	r.updateStatus(ctx, registry, int32(len(templates)), totalDesired, readyCount, failedCount, true)
+	case lynqv1.SourceTypeConfigMap:
+		cm := registry.Spec.Source.ConfigMap
+		if cm == nil {
+			return datasource.Config{}, "", fmt.Errorf("ConfigMap configuration is nil")
+		}
 
-	return ctrl.Result{RequeueAfter: syncInterval}, nil
-}
+		format := string(cm.Format)
+		if format == "" {
+			format = rowFormatForKey(cm.Key)
+		}
 
-// queryDatabase connects to database and retrieves node rows
-func (r *LynqHubReconciler) queryDatabase(ctx context.Context, registry *lynqv1.LynqHub) ([]datasource.NodeRow, error) {
-	// Determine datasource type
-	sourceType := datasource.SourceType(registry.Spec.Source.Type)
+		// Row data itself is loaded from the ConfigMap by loadConfigMapRows
+		return datasource.Config{RowFormat: format}, "", nil
 
-	// Get password from Secret (MySQL/PostgreSQL specific)
-	password := ""
-	if registry.Spec.Source.MySQL != nil && registry.Spec.Source.MySQL.PasswordRef != nil {
-		secret := &corev1.Secret{}
-		if err := r.Get(ctx, types.NamespacedName{
-			Name:      registry.Spec.Source.MySQL.PasswordRef.Name,
-			Namespace: registry.Namespace,
-		}, secret); err != nil {
-			return nil, fmt.Errorf("failed to get password secret: %w", err)
+	case lynqv1.SourceTypeInline:
+		rows := []map[string]string{}
+		if registry.Spec.Source.Inline != nil && registry.Spec.Source.Inline.Rows != nil {
+			rows = registry.Spec.Source.Inline.Rows
 		}
-		password = string(secret.Data[registry.Spec.Source.MySQL.PasswordRef.Key])
+
+		return datasource.Config{Rows: rows}, "", nil
+
+	default:
+		return datasource.Config{}, "", fmt.Errorf("unsupported source type: %s", registry.Spec.Source.Type)
 	}
+}
 
-	// Build datasource config
-	config, table, err := r.buildDatasourceConfig(registry, password)
-	if err != nil {
-		return nil, err
+// mysqlEndpoints converts additional MySQL endpoints to datasource endpoints
+func mysqlEndpoints(endpoints []lynqv1.MySQLEndpoint) []datasource.Endpoint {
+	if len(endpoints) == 0 {
+		return nil
+	}
+	converted := make([]datasource.Endpoint, 0, len(endpoints))
+	for _, endpoint := range endpoints {
+		port := endpoint.Port
+		if port == 0 {
+			port = 3306
+		}
+		converted = append(converted, datasource.Endpoint{Host: endpoint.Host, Port: port})
 	}
+	return converted
+}
 
-	// Create datasource adapter
-	ds, err := datasource.NewDatasource(sourceType, config)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create datasource: %w", err)
+// sourcePasswordRef returns the password Secret reference of the configured source, if any
+// For http sources this is the bearer token or basic auth password, depending on the auth type;
+// for plugin sources it is the bearer token, and for sql sources the Secret holding the DSN.
+func sourcePasswordRef(registry *lynqv1.LynqHub) *lynqv1.SecretRef {
+	switch registry.Spec.Source.Type {
+	case lynqv1.SourceTypeHTTP:
+		if registry.Spec.Source.HTTP != nil && registry.Spec.Source.HTTP.Auth != nil {
+			auth := registry.Spec.Source.HTTP.Auth
+			switch auth.Type {
+			case lynqv1.HTTPAuthTypeBearer:
+				return auth.TokenRef
+			case lynqv1.HTTPAuthTypeBasic:
+				return auth.PasswordRef
+			}
+		}
+	case lynqv1.SourceTypeMySQL:
+		if registry.Spec.Source.MySQL != nil {
+			return registry.Spec.Source.MySQL.PasswordRef
+		}
+	case lynqv1.SourceTypePostgreSQL:
+		if registry.Spec.Source.PostgreSQL != nil {
+			return registry.Spec.Source.PostgreSQL.PasswordRef
+		}
+	case lynqv1.SourceTypeSQL:
+		if registry.Spec.Source.SQL != nil {
+			return registry.Spec.Source.SQL.DSNSecretRef
+		}
+	case lynqv1.SourceTypePlugin:
+		if registry.Spec.Source.Plugin != nil {
+			return registry.Spec.Source.Plugin.TokenRef
+		}
 	}
-	defer func() {
-		_ = ds.Close() // Best effort close
-	}()
+	return nil
+}
 
-	// Query nodes
-	queryConfig := datasource.QueryConfig{
-		Table: table,
-		ValueMappings: datasource.ValueMappings{
-			UID:       registry.Spec.ValueMappings.UID,
-			HostOrURL: registry.Spec.ValueMappings.HostOrURL,
-			Activate:  registry.Spec.ValueMappings.Activate,
-		},
-		ExtraMappings: registry.Spec.ExtraValueMappings,
+// sourceFilters converts the row filter of the configured SQL source into datasource filters
+func sourceFilters(registry *lynqv1.LynqHub) []datasource.Filter {
+	var rowFilters []lynqv1.RowFilter
+	switch registry.Spec.Source.Type {
+	case lynqv1.SourceTypeMySQL:
+		if registry.Spec.Source.MySQL != nil {
+			rowFilters = registry.Spec.Source.MySQL.Filter
+		}
+	case lynqv1.SourceTypePostgreSQL:
+		if registry.Spec.Source.PostgreSQL != nil {
+			rowFilters = registry.Spec.Source.PostgreSQL.Filter
+		}
+	case lynqv1.SourceTypeSQL:
+		if registry.Spec.Source.SQL != nil {
+			rowFilters = registry.Spec.Source.SQL.Filter
+		}
 	}
 
-	return ds.QueryNodes(ctx, queryConfig)
+	if len(rowFilters) == 0 {
+		return nil
+	}
+	filters := make([]datasource.Filter, 0, len(rowFilters))
+	for _, f := range rowFilters {
+		values := f.Values
+		switch f.Operator {
+		case lynqv1.RowFilterOperatorIn, lynqv1.RowFilterOperatorNotIn:
+		case lynqv1.RowFilterOperatorIsNull, lynqv1.RowFilterOperatorIsNotNull:
+			values = nil
+		default:
+			values = []string{f.Value}
+		}
+		filters = append(filters, datasource.Filter{
+			Column:   f.Column,
+			Operator: string(f.Operator),
+			Values:   values,
+		})
+	}
+	return filters
 }
 
-// buildDatasourceConfig builds datasource configuration from LynqHub spec
-func (r *LynqHubReconciler) buildDatasourceConfig(registry *lynqv1.LynqHub, password string) (datasource.Config, string, error) {
+// sourceTLS returns the TLS Secret references of the configured source, if any
+func sourceTLS(registry *lynqv1.LynqHub) *lynqv1.DatabaseTLS {
 	switch registry.Spec.Source.Type {
 	case lynqv1.SourceTypeMySQL:
-		mysql := registry.Spec.Source.MySQL
-		if mysql == nil {
-			return datasource.Config{}, "", fmt.Errorf("MySQL configuration is nil")
+		// TLS material is not needed (and its Secrets need not exist) while TLS is disabled
+		if mysql := registry.Spec.Source.MySQL; mysql != nil &&
+			mysql.TLSMode != "" && mysql.TLSMode != lynqv1.MySQLTLSModeDisable {
+			return mysql.TLS
 		}
+	case lynqv1.SourceTypePostgreSQL:
+		if registry.Spec.Source.PostgreSQL != nil {
+			return registry.Spec.Source.PostgreSQL.TLS
+		}
+	case lynqv1.SourceTypeHTTP:
+		if registry.Spec.Source.HTTP != nil {
+			return registry.Spec.Source.HTTP.TLS
+		}
+	case lynqv1.SourceTypePlugin:
+		if registry.Spec.Source.Plugin != nil {
+			return registry.Spec.Source.Plugin.TLS
+		}
+	}
+	return nil
+}
 
-		config := datasource.Config{
-			Host:     mysql.Host,
-			Port:     mysql.Port,
-			Username: mysql.Username,
-			Password: password,
-			Database: mysql.Database,
+// getSecretValue reads a single key from a Secret in the given namespace
+func (r *LynqHubReconciler) getSecretValue(ctx context.Context, namespace string, ref *lynqv1.SecretRef) (string, error) {
+	secret := &corev1.Secret{}
+	if err := r.Get(ctx, types.NamespacedName{
+		Name:      ref.Name,
+		Namespace: namespace,
+	}, secret); err != nil {
+		return "", err
+	}
+	return string(secret.Data[ref.Key]), nil
+}
+
+// loadTLSMaterial resolves the CA and client certificate Secrets into the datasource config
+func (r *LynqHubReconciler) loadTLSMaterial(ctx context.Context, namespace string, tls *lynqv1.DatabaseTLS, config *datasource.Config) error {
+	if tls == nil {
+		return nil
+	}
+
+	refs := []struct {
+		name   string
+		ref    *lynqv1.SecretRef
+		target *string
+	}{
+		{"CA certificate", tls.CASecretRef, &config.CACert},
+		{"client certificate", tls.ClientCertSecretRef, &config.ClientCert},
+		{"client key", tls.ClientKeySecretRef, &config.ClientKey},
+	}
+	for _, item := range refs {
+		if item.ref == nil {
+			continue
+		}
+		value, err := r.getSecretValue(ctx, namespace, item.ref)
+		if err != nil {
+			return fmt.Errorf("failed to get %s secret: %w", item.name, err)
+		}
+		if value == "" {
+			return fmt.Errorf("%s secret %s has no data for key %q", item.name, item.ref.Name, item.ref.Key)
 		}
+		*item.target = value
+	}
 
-		return config, mysql.Table, nil
+	return nil
+}
+
+// loadConfigMapRows reads the row data of a configmap source into the datasource config
+func (r *LynqHubReconciler) loadConfigMapRows(ctx context.Context, registry *lynqv1.LynqHub, config *datasource.Config) error {
+	source := registry.Spec.Source.ConfigMap
+	if registry.Spec.Source.Type != lynqv1.SourceTypeConfigMap || source == nil {
+		return nil
+	}
 
+	cm := &corev1.ConfigMap{}
+	if err := r.Get(ctx, types.NamespacedName{Name: source.Name, Namespace: registry.Namespace}, cm); err != nil {
+		return fmt.Errorf("failed to get rows ConfigMap: %w", err)
+	}
+
+	if data, ok := cm.Data[source.Key]; ok {
+		config.RowData = data
+		return nil
+	}
+	if data, ok := cm.BinaryData[source.Key]; ok {
+		config.RowData = string(data)
+		return nil
+	}
+	return fmt.Errorf("ConfigMap %s has no data for key %q", source.Name, source.Key)
+}
+
+// rowFormatForKey infers the row format from a ConfigMap key extension, defaulting to JSON
+func rowFormatForKey(key string) string {
+	switch strings.ToLower(filepath.Ext(key)) {
+	case ".csv":
+		return datasource.RowFormatCSV
+	case ".yaml", ".yml":
+		return datasource.RowFormatYAML
 	default:
-		return datasource.Config{}, "", fmt.Errorf("unsupported source type: %s", registry.Spec.Source.Type)
+		return datasource.RowFormatJSON
 	}
 }
 
@@ -398,11 +2088,10 @@ func (r *LynqHubReconciler) getTemplatesForRegistry(ctx context.Context, registr
 
 // renderAllTemplateResources renders all resources from a template with the given variables
 func (r *LynqHubReconciler) renderAllTemplateResources(
+	engine *template.Engine,
 	tmpl *lynqv1.LynqForm,
 	vars template.Variables,
 ) (*lynqv1.LynqNodeSpec, error) {
-	engine := template.NewEngine()
-
 	spec := &lynqv1.LynqNodeSpec{
 		ServiceAccounts:          make([]lynqv1.TResource, 0),
 		Deployments:              make([]lynqv1.TResource, 0),
@@ -571,17 +2260,14 @@ func (r *LynqHubReconciler) renderResource(
 }
 
 // createLynqNode creates a new LynqNode CR
-func (r *LynqHubReconciler) createLynqNode(ctx context.Context, registry *lynqv1.LynqHub, tmpl *lynqv1.LynqForm, row datasource.NodeRow) error {
+func (r *LynqHubReconciler) createLynqNode(ctx context.Context, registry *lynqv1.LynqHub, engine *template.Engine, tmpl *lynqv1.LynqForm, row datasource.NodeRow) error {
 	logger := log.FromContext(ctx)
 
 	// 1. Build template variables
-	vars := template.BuildVariables(row.UID, row.HostOrURL, row.Activate, row.Extra)
-	// Add hubId and templateRef for template rendering (needed for labelsTemplate, annotationsTemplate)
-	vars["hubId"] = registry.Name
-	vars["templateRef"] = tmpl.Name
+	vars := nodeVariables(registry, tmpl, row)
 
 	// 2. Render all template resources
-	renderedSpec, err := r.renderAllTemplateResources(tmpl, vars)
+	renderedSpec, err := r.renderAllTemplateResources(engine, tmpl, vars)
 	if err != nil {
 		logger.Error(err, "Failed to render template resources", "node", row.UID)
 		r.Recorder.Eventf(registry, corev1.EventTypeWarning, "TemplateRenderFailed",
@@ -589,22 +2275,27 @@ func (r *LynqHubReconciler) createLynqNode(ctx context.Context, registry *lynqv1
 		return fmt.Errorf("failed to render template: %w", err)
 	}
 
+	// Name format: nodeNameTemplate, or {uid}-{template-name} to support multiple templates per registry
+	name, err := lynqNodeName(engine, registry, tmpl, row)
+	if err != nil {
+		return err
+	}
+
 	// 4. Marshal extra values to JSON for annotation
-	extraJSON, err := json.Marshal(row.Extra)
+	extraJSON, err := json.Marshal(row.Values())
 	if err != nil {
 		logger.Error(err, "Failed to marshal extra values", "node", row.UID)
 		extraJSON = []byte("{}")
 	}
 
 	// 3. Create LynqNode CR with rendered resources
-	// Name format: {uid}-{template-name} to support multiple templates per registry
 	node := &lynqv1.LynqNode{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      fmt.Sprintf("%s-%s", row.UID, tmpl.Name),
+			Name:      name,
 			Namespace: registry.Namespace,
 			Labels: map[string]string{
 				"lynq.sh/hub": registry.Name,
-				"lynq.sh/uid": row.UID,
+				"lynq.sh/uid": uidLabelValue(row.UID),
 			},
 			Annotations: map[string]string{
 				"lynq.sh/hostOrUrl":                     row.HostOrURL,
@@ -643,8 +2334,8 @@ func (r *LynqHubReconciler) shouldUpdateLynqNode(_ context.Context, _ *lynqv1.Ly
 		return true
 	}
 
-	// Compare extra values
-	currentExtraJSON, err := json.Marshal(row.Extra)
+	// Compare extra values (typed values are stored with their JSON type)
+	currentExtraJSON, err := json.Marshal(row.Values())
 	if err != nil {
 		// If can't marshal, assume changed
 		return true
@@ -669,7 +2360,7 @@ func (r *LynqHubReconciler) shouldUpdateLynqNode(_ context.Context, _ *lynqv1.Ly
 }
 
 // updateLynqNode updates an existing LynqNode CR with new data from database
-func (r *LynqHubReconciler) updateLynqNode(ctx context.Context, registry *lynqv1.LynqHub, tmpl *lynqv1.LynqForm, node *lynqv1.LynqNode, row datasource.NodeRow) error {
+func (r *LynqHubReconciler) updateLynqNode(ctx context.Context, registry *lynqv1.LynqHub, engine *template.Engine, tmpl *lynqv1.LynqForm, node *lynqv1.LynqNode, row datasource.NodeRow) error {
 	logger := log.FromContext(ctx)
 
 	// Check what triggered the update
@@ -680,13 +2371,10 @@ func (r *LynqHubReconciler) updateLynqNode(ctx context.Context, registry *lynqv1
 		node.Annotations["lynq.sh/activate"] != row.Activate
 
 	// 1. Build template variables with new data
-	vars := template.BuildVariables(row.UID, row.HostOrURL, row.Activate, row.Extra)
-	// Add hubId and templateRef for template rendering (needed for labelsTemplate, annotationsTemplate)
-	vars["hubId"] = registry.Name
-	vars["templateRef"] = tmpl.Name
+	vars := nodeVariables(registry, tmpl, row)
 
 	// 2. Render all template resources
-	renderedSpec, err := r.renderAllTemplateResources(tmpl, vars)
+	renderedSpec, err := r.renderAllTemplateResources(engine, tmpl, vars)
 	if err != nil {
 		logger.Error(err, "Failed to render template resources", "node", row.UID)
 		r.Recorder.Eventf(registry, corev1.EventTypeWarning, "TemplateRenderFailed",
@@ -695,7 +2383,7 @@ func (r *LynqHubReconciler) updateLynqNode(ctx context.Context, registry *lynqv1
 	}
 
 	// 4. Marshal extra values to JSON for annotation
-	extraJSON, err := json.Marshal(row.Extra)
+	extraJSON, err := json.Marshal(row.Values())
 	if err != nil {
 		logger.Error(err, "Failed to marshal extra values", "node", row.UID)
 		extraJSON = []byte("{}")
@@ -722,6 +2410,10 @@ func (r *LynqHubReconciler) updateLynqNode(ctx context.Context, registry *lynqv1
 		latest.Annotations["lynq.sh/extra"] = string(extraJSON)
 		latest.Annotations[lynqv1.AnnotationTemplateGeneration] = newTemplateGeneration
 		latest.Annotations["lynq.sh/hubId"] = registry.Name
+		if latest.Labels == nil {
+			latest.Labels = make(map[string]string)
+		}
+		latest.Labels["lynq.sh/uid"] = uidLabelValue(row.UID)
 		// Update rollout start time for progress deadline tracking
 		latest.Annotations[lynqv1.AnnotationRolloutUpdateStartTime] = time.Now().Format(time.RFC3339)
 
@@ -897,6 +2589,135 @@ func (r *LynqHubReconciler) formatResourceDetails(counts map[string]int) string
 	return fmt.Sprintf("%s", details[0:])
 }
 
+// nodeWrite is a LynqNode create (node == nil) or update planned by a sync
+type nodeWrite struct {
+	template *lynqv1.LynqForm
+	row      datasource.NodeRow
+	node     *lynqv1.LynqNode
+}
+
+// operation returns "Create" or "Update"
+func (w nodeWrite) operation() string {
+	if w.node == nil {
+		return "Create"
+	}
+	return "Update"
+}
+
+// syncParallelism returns the number of LynqNode writes a sync runs at the same time
+func syncParallelism(registry *lynqv1.LynqHub) int {
+	if registry.Spec.Parallelism < 1 {
+		return 1
+	}
+	return int(registry.Spec.Parallelism)
+}
+
+// applyNodeWrites creates and updates LynqNodes with up to parallelism writes at a time, and
+// returns the error of each write (nil on success) in the order of writes
+func (r *LynqHubReconciler) applyNodeWrites(ctx context.Context, registry *lynqv1.LynqHub, engine *template.Engine, writes []nodeWrite, parallelism int) []error {
+	errs := make([]error, len(writes))
+	slots := make(chan struct{}, parallelism)
+	var wg sync.WaitGroup
+	for i := range writes {
+		if err := ctx.Err(); err != nil {
+			errs[i] = err
+			continue
+		}
+		slots <- struct{}{}
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			defer func() { <-slots }()
+			write := writes[i]
+			if write.node == nil {
+				errs[i] = r.createLynqNode(ctx, registry, engine, write.template, write.row)
+			} else {
+				errs[i] = r.updateLynqNode(ctx, registry, engine, write.template, write.node, write.row)
+			}
+		}(i)
+	}
+	wg.Wait()
+	return errs
+}
+
+// formSyncStatuses breaks the hub's node counts down by referencing template, sorted by name
+func formSyncStatuses(templates []*lynqv1.LynqForm, nodes *lynqv1.LynqNodeList, desired map[string]int32, throttled map[string]int) []lynqv1.FormSyncStatus {
+	forms := make([]lynqv1.FormSyncStatus, 0, len(templates))
+	index := make(map[string]int, len(templates))
+	for _, tmpl := range templates {
+		index[tmpl.Name] = len(forms)
+		forms = append(forms, lynqv1.FormSyncStatus{
+			Name:      tmpl.Name,
+			Desired:   desired[tmpl.Name],
+			Throttled: int32(throttled[tmpl.Name]),
+		})
+	}
+	for i := range nodes.Items {
+		node := &nodes.Items[i]
+		pos, ok := index[node.Spec.TemplateRef]
+		if !ok {
+			continue
+		}
+		if cond := meta.FindStatusCondition(node.Status.Conditions, "Ready"); cond != nil {
+			if cond.Status == metav1.ConditionTrue {
+				forms[pos].Ready++
+			} else {
+				forms[pos].Failed++
+			}
+		}
+	}
+	sort.Slice(forms, func(i, j int) bool { return forms[i].Name < forms[j].Name })
+	if len(forms) == 0 {
+		return nil
+	}
+	return forms
+}
+
+// recordLastSync records the timing, changes and error of a sync in status
+func recordLastSync(status *lynqv1.LynqHubStatus, start, end time.Time, changes lynqv1.SyncChanges, syncError string) {
+	startTime := metav1.NewTime(start)
+	status.LastSyncTime = &startTime
+	status.LastSyncDuration = &metav1.Duration{Duration: end.Sub(start).Round(time.Millisecond)}
+	status.LastSyncChanges = &changes
+	if len(syncError) > maxSyncErrorLength {
+		syncError = syncError[:maxSyncErrorLength] + "..."
+	}
+	status.LastSyncError = syncError
+}
+
+// summarizeNodeWriteFailures lists the first failed writes for events
+func summarizeNodeWriteFailures(failures []lynqv1.NodeWriteFailure) string {
+	const shown = 3
+	parts := make([]string, 0, shown)
+	for _, failure := range failures[:min(shown, len(failures))] {
+		parts = append(parts, fmt.Sprintf("%s %s (LynqForm %s): %s", failure.Operation, failure.UID, failure.Form, failure.Message))
+	}
+	summary := strings.Join(parts, "; ")
+	if len(failures) > shown {
+		summary += fmt.Sprintf("; and %d more", len(failures)-shown)
+	}
+	return summary
+}
+
+// recordNodeWriteFailures replaces the failed writes of the previous sync in status
+func recordNodeWriteFailures(status *lynqv1.LynqHubStatus, failures []lynqv1.NodeWriteFailure) {
+	status.NodeWriteFailureCount = int32(len(failures))
+	if len(failures) > maxNodeWriteFailures {
+		failures = failures[:maxNodeWriteFailures]
+	}
+	listed := make([]lynqv1.NodeWriteFailure, 0, len(failures))
+	for _, failure := range failures {
+		if len(failure.Message) > maxSyncErrorLength {
+			failure.Message = failure.Message[:maxSyncErrorLength] + "..."
+		}
+		listed = append(listed, failure)
+	}
+	if len(listed) == 0 {
+		listed = nil
+	}
+	status.NodeWriteFailures = listed
+}
+
 // getExistingLynqNodes lists LynqNode CRs managed by this registry
 func (r *LynqHubReconciler) getExistingLynqNodes(ctx context.Context, registry *lynqv1.LynqHub) (*lynqv1.LynqNodeList, error) {
 	nodeList := &lynqv1.LynqNodeList{}
@@ -936,7 +2757,8 @@ func countNodeStatusFromList(nodes *lynqv1.LynqNodeList) (int32, int32) {
 }
 
 // updateStatus updates LynqHub status with retry on conflict
-func (r *LynqHubReconciler) updateStatus(ctx context.Context, registry *lynqv1.LynqHub, referencingTemplates, desired, ready, failed int32, synced bool) {
+// Additional status changes can be passed as functions applied to the latest status before writing.
+func (r *LynqHubReconciler) updateStatus(ctx context.Context, registry *lynqv1.LynqHub, referencingTemplates, desired, ready, failed int32, synced bool, updates ...func(*lynqv1.LynqHubStatus)) {
 	logger := log.FromContext(ctx)
 
 	// Record metrics first (these don't depend on the status update)
@@ -944,8 +2766,12 @@ func (r *LynqHubReconciler) updateStatus(ctx context.Context, registry *lynqv1.L
 	metrics.HubReady.WithLabelValues(registry.Name, registry.Namespace).Set(float64(ready))
 	metrics.HubFailed.WithLabelValues(registry.Name, registry.Namespace).Set(float64(failed))
 
+	// Set when the write moves the Suspended condition from True to False
+	resumed := false
+
 	// Retry status update on conflict
 	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		resumed = false
 		// Get the latest version of the registry
 		key := client.ObjectKeyFromObject(registry)
 		latest := &lynqv1.LynqHub{}
@@ -962,6 +2788,24 @@ func (r *LynqHubReconciler) updateStatus(ctx context.Context, registry *lynqv1.L
 		latest.Status.Ready = ready
 		latest.Status.Failed = failed
 		latest.Status.ObservedGeneration = latest.Generation
+		for _, update := range updates {
+			if update != nil {
+				update(&latest.Status)
+			}
+		}
+		if snapshot := latest.Status.Snapshot; snapshot != nil {
+			if snapshot.LastSuccessfulSyncTime != nil {
+				metrics.HubLastSuccessfulSync.WithLabelValues(registry.Name, registry.Namespace).
+					Set(float64(snapshot.LastSuccessfulSyncTime.Unix()))
+			}
+			metrics.HubConsecutiveFailures.WithLabelValues(registry.Name, registry.Namespace).
+				Set(float64(snapshot.ConsecutiveFailures))
+		}
+		circuitOpen := 0.0
+		if meta.IsStatusConditionTrue(latest.Status.Conditions, ConditionTypeCircuitOpen) {
+			circuitOpen = 1
+		}
+		metrics.HubCircuitOpen.WithLabelValues(registry.Name, registry.Namespace).Set(circuitOpen)
 
 		// Prepare condition — use meta.SetStatusCondition to preserve LastTransitionTime
 		conditionStatus := metav1.ConditionTrue
@@ -980,8 +2824,12 @@ func (r *LynqHubReconciler) updateStatus(ctx context.Context, registry *lynqv1.L
 		}
 
 		// Use meta.SetStatusCondition which correctly preserves LastTransitionTime
-		// when the condition status hasn't changed (per K8s API conventions)
-		meta.SetStatusCondition(&latest.Status.Conditions, condition)
+		// when the condition status hasn't changed (per K8s API conventions).
+		// A suspended hub keeps the Ready condition of its last sync.
+		if !registry.Spec.Suspend {
+			meta.SetStatusCondition(&latest.Status.Conditions, condition)
+		}
+		setHubSuspendedCondition(&latest.Status, registry.Spec.Suspend)
 
 		// Skip write if status is unchanged (compare full status struct)
 		if apiequality.Semantic.DeepEqual(statusBefore, &latest.Status) {
@@ -989,14 +2837,54 @@ func (r *LynqHubReconciler) updateStatus(ctx context.Context, registry *lynqv1.L
 		}
 
 		// Update status subresource
-		return r.Status().Update(ctx, latest)
+		if err := r.Status().Update(ctx, latest); err != nil {
+			return err
+		}
+		resumed = meta.IsStatusConditionTrue(statusBefore.Conditions, ConditionTypeSuspended) &&
+			!meta.IsStatusConditionTrue(latest.Status.Conditions, ConditionTypeSuspended)
+		return nil
 	})
 
 	if err != nil {
 		logger.Error(err, "Failed to update LynqHub status after retries")
+		return
+	}
+	if resumed {
+		r.Recorder.Eventf(registry, corev1.EventTypeNormal, "Resumed", "Sync resumed")
+	}
+}
+
+// setHubSuspendedCondition reports spec.suspend; the condition is only added once the hub is suspended
+func setHubSuspendedCondition(status *lynqv1.LynqHubStatus, suspended bool) {
+	if suspended {
+		meta.SetStatusCondition(&status.Conditions, metav1.Condition{
+			Type:    ConditionTypeSuspended,
+			Status:  metav1.ConditionTrue,
+			Reason:  "Suspended",
+			Message: "spec.suspend is true; the datasource is not queried and LynqNodes are not changed",
+		})
+		return
+	}
+	if meta.FindStatusCondition(status.Conditions, ConditionTypeSuspended) != nil {
+		meta.SetStatusCondition(&status.Conditions, metav1.Condition{
+			Type:    ConditionTypeSuspended,
+			Status:  metav1.ConditionFalse,
+			Reason:  "Resumed",
+			Message: "spec.suspend is false",
+		})
 	}
 }
 
+// deleteHubMetrics removes the metrics of a deleted hub so it does not keep firing alerts
+func deleteHubMetrics(registry *lynqv1.LynqHub) {
+	metrics.HubDesired.DeleteLabelValues(registry.Name, registry.Namespace)
+	metrics.HubReady.DeleteLabelValues(registry.Name, registry.Namespace)
+	metrics.HubFailed.DeleteLabelValues(registry.Name, registry.Namespace)
+	metrics.HubLastSuccessfulSync.DeleteLabelValues(registry.Name, registry.Namespace)
+	metrics.HubConsecutiveFailures.DeleteLabelValues(registry.Name, registry.Namespace)
+	metrics.HubCircuitOpen.DeleteLabelValues(registry.Name, registry.Namespace)
+}
+
 // cleanupRetainResources handles DeletionPolicy.Retain resources when Hub is deleted
 func (r *LynqHubReconciler) cleanupRetainResources(ctx context.Context, registry *lynqv1.LynqHub) error {
 	logger := log.FromContext(ctx)
@@ -1434,11 +3322,35 @@ func (r *LynqHubReconciler) canUpdateNodeWithCount(ctx context.Context, tmpl *ly
 
 // SetupWithManager sets up the controller with the Manager.
 func (r *LynqHubReconciler) SetupWithManager(mgr ctrl.Manager, concurrency int) error {
+	if r.Datasources == nil {
+		r.Datasources = datasource.NewCache()
+	}
+	if err := mgr.GetFieldIndexer().IndexField(context.Background(), &lynqv1.LynqHub{},
+		configMapSourceIndex, configMapSourceNames); err != nil {
+		return err
+	}
+	// Close all cached datasource connections when the manager stops
+	if err := mgr.Add(manager.RunnableFunc(func(ctx context.Context) error {
+		<-ctx.Done()
+		return r.Datasources.Close()
+	})); err != nil {
+		return err
+	}
+
 	return ctrl.NewControllerManagedBy(mgr).
-		For(&lynqv1.LynqHub{}).
+		// Ignore status-only updates: every sync writes status (e.g. the last successful sync time),
+		// which would otherwise immediately trigger another sync
+		For(&lynqv1.LynqHub{}, builder.WithPredicates(predicate.Or(
+			predicate.GenerationChangedPredicate{},
+			predicate.AnnotationChangedPredicate{},
+			predicate.LabelChangedPredicate{},
+		))).
 		Owns(&lynqv1.LynqNode{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
 		// Watch LynqForms to re-sync nodes when template changes
 		Watches(&lynqv1.LynqForm{}, handler.EnqueueRequestsFromMapFunc(r.findRegistryForTemplate)).
+		// Watch ConfigMaps so edits to configmap sources sync immediately instead of waiting for syncInterval
+		Watches(&corev1.ConfigMap{}, handler.EnqueueRequestsFromMapFunc(r.findRegistriesForConfigMap),
+			builder.WithPredicates(configMapDataChanged)).
 		Named("lynqhub").
 		WithOptions(controller.Options{
 			MaxConcurrentReconciles: concurrency,
@@ -1446,6 +3358,21 @@ func (r *LynqHubReconciler) SetupWithManager(mgr ctrl.Manager, concurrency int)
 		Complete(r)
 }
 
+// configMapDataChanged passes ConfigMap creations, deletions and data changes, so that
+// metadata-only churn (e.g. leader election annotations) does not look up hubs
+var configMapDataChanged = predicate.Funcs{
+	UpdateFunc: func(e event.UpdateEvent) bool {
+		oldCM, okOld := e.ObjectOld.(*corev1.ConfigMap)
+		newCM, okNew := e.ObjectNew.(*corev1.ConfigMap)
+		if !okOld || !okNew {
+			return true
+		}
+		return !apiequality.Semantic.DeepEqual(oldCM.Data, newCM.Data) ||
+			!apiequality.Semantic.DeepEqual(oldCM.BinaryData, newCM.BinaryData)
+	},
+	GenericFunc: func(event.GenericEvent) bool { return false },
+}
+
 // findRegistryForTemplate maps a LynqForm to its LynqHub for watch events
 func (r *LynqHubReconciler) findRegistryForTemplate(ctx context.Context, obj client.Object) []reconcile.Request {
 	tmpl := obj.(*lynqv1.LynqForm)
@@ -1460,3 +3387,40 @@ func (r *LynqHubReconciler) findRegistryForTemplate(ctx context.Context, obj cli
 		},
 	}
 }
+
+// findRegistriesForConfigMap maps a ConfigMap to the configmap-sourced LynqHubs that read from it
+func (r *LynqHubReconciler) findRegistriesForConfigMap(ctx context.Context, obj client.Object) []reconcile.Request {
+	hubList := &lynqv1.LynqHubList{}
+	if err := r.List(ctx, hubList, client.InNamespace(obj.GetNamespace()),
+		client.MatchingFields{configMapSourceIndex: obj.GetName()}); err != nil {
+		return nil
+	}
+
+	requests := make([]reconcile.Request, 0, len(hubList.Items))
+	for _, hub := range hubList.Items {
+		requests = append(requests, reconcile.Request{
+			NamespacedName: types.NamespacedName{Name: hub.Name, Namespace: hub.Namespace},
+		})
+	}
+	return requests
+}
+
+// configMapSourceNames returns the ConfigMaps read by the hub's source and merge sources,
+// for configMapSourceIndex
+func configMapSourceNames(obj client.Object) []string {
+	hub, ok := obj.(*lynqv1.LynqHub)
+	if !ok {
+		return nil
+	}
+	sources := []lynqv1.DataSource{hub.Spec.Source}
+	for _, merge := range hub.Spec.MergeSources {
+		sources = append(sources, merge.Source)
+	}
+	var names []string
+	for _, source := range sources {
+		if source.Type == lynqv1.SourceTypeConfigMap && source.ConfigMap != nil && !slices.Contains(names, source.ConfigMap.Name) {
+			names = append(names, source.ConfigMap.Name)
+		}
+	}
+	return names
+}
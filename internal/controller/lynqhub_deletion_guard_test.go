@@ -0,0 +1,202 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	lynqv1 "github.com/k8s-lynq/lynq/api/v1"
+)
+
+func TestDeletionGuardExceeded(t *testing.T) {
+	tests := []struct {
+		name      string
+		guard     *lynqv1.DeletionGuard
+		deletions int
+		existing  int
+		want      bool
+		message   string
+	}{
+		{name: "no guard", guard: nil, deletions: 100, existing: 100},
+		{name: "no deletions", guard: &lynqv1.DeletionGuard{MaxDeletions: ptr.To[int32](0)}, deletions: 0, existing: 10},
+		{name: "within max deletions", guard: &lynqv1.DeletionGuard{MaxDeletions: ptr.To[int32](5)}, deletions: 5, existing: 10},
+		{
+			name:      "exceeds max deletions",
+			guard:     &lynqv1.DeletionGuard{MaxDeletions: ptr.To[int32](5)},
+			deletions: 6,
+			existing:  10,
+			want:      true,
+			message:   "sync would delete 6 of 10 LynqNodes, exceeding maxDeletions=5",
+		},
+		{name: "within max percent", guard: &lynqv1.DeletionGuard{MaxDeletionPercent: ptr.To[int32](50)}, deletions: 5, existing: 10},
+		{
+			name:      "exceeds max percent",
+			guard:     &lynqv1.DeletionGuard{MaxDeletionPercent: ptr.To[int32](50)},
+			deletions: 6,
+			existing:  10,
+			want:      true,
+			message:   "sync would delete 6 of 10 LynqNodes (60%), exceeding maxDeletionPercent=50",
+		},
+		{
+			name:      "zero max deletions blocks every deletion",
+			guard:     &lynqv1.DeletionGuard{MaxDeletions: ptr.To[int32](0)},
+			deletions: 1,
+			existing:  10,
+			want:      true,
+			message:   "sync would delete 1 of 10 LynqNodes, exceeding maxDeletions=0",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, message := deletionGuardExceeded(tt.guard, tt.deletions, tt.existing)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.message, message)
+		})
+	}
+}
+
+func TestSetDeletionBlockedCondition(t *testing.T) {
+	guard := &lynqv1.DeletionGuard{MaxDeletions: ptr.To[int32](1)}
+	status := &lynqv1.LynqHubStatus{}
+
+	setDeletionBlockedCondition(status, guard, true, "sync would delete 2 of 2 LynqNodes, exceeding maxDeletions=1")
+	condition := meta.FindStatusCondition(status.Conditions, ConditionTypeDeletionBlocked)
+	require.NotNil(t, condition)
+	assert.Equal(t, metav1.ConditionTrue, condition.Status)
+	assert.Equal(t, "DeletionLimitExceeded", condition.Reason)
+	assert.Contains(t, condition.Message, lynqv1.AnnotationApproveDeletions)
+
+	setDeletionBlockedCondition(status, guard, false, "")
+	condition = meta.FindStatusCondition(status.Conditions, ConditionTypeDeletionBlocked)
+	require.NotNil(t, condition)
+	assert.Equal(t, metav1.ConditionFalse, condition.Status)
+
+	setDeletionBlockedCondition(status, nil, false, "")
+	assert.Nil(t, meta.FindStatusCondition(status.Conditions, ConditionTypeDeletionBlocked))
+}
+
+// TestReconcileDeletionGuard verifies that a sync returning no rows does not delete every node
+// until the deletions are approved via annotation
+func TestReconcileDeletionGuard(t *testing.T) {
+	ctx := context.Background()
+	scheme := runtime.NewScheme()
+	require.NoError(t, lynqv1.AddToScheme(scheme))
+
+	hub := &lynqv1.LynqHub{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       "billing",
+			Namespace:  "default",
+			Finalizers: []string{FinalizerLynqHub},
+		},
+		Spec: lynqv1.LynqHubSpec{
+			Source: lynqv1.DataSource{
+				Type:         lynqv1.SourceTypeInline,
+				SyncInterval: "1m",
+				// The datasource suddenly returns no rows
+				Inline: &lynqv1.InlineSource{Rows: []map[string]string{}},
+			},
+			ValueMappings: lynqv1.ValueMappings{UID: "id", Activate: "active"},
+			DeletionGuard: &lynqv1.DeletionGuard{MaxDeletionPercent: ptr.To[int32](50)},
+		},
+	}
+	form := &lynqv1.LynqForm{
+		ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default"},
+		Spec:       lynqv1.LynqFormSpec{HubID: "billing"},
+	}
+	objects := []client.Object{hub, form}
+	for i := 1; i <= 4; i++ {
+		objects = append(objects, &lynqv1.LynqNode{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      fmt.Sprintf("tenant%d-web", i),
+				Namespace: "default",
+				Labels:    map[string]string{"lynq.sh/hub": "billing"},
+			},
+			Spec: lynqv1.LynqNodeSpec{UID: fmt.Sprintf("tenant%d", i), TemplateRef: "web"},
+		})
+	}
+
+	fakeClient := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(objects...).
+		WithStatusSubresource(&lynqv1.LynqHub{}).
+		Build()
+	recorder := record.NewFakeRecorder(100)
+	r := &LynqHubReconciler{Client: fakeClient, Scheme: scheme, Recorder: recorder}
+	req := ctrl.Request{NamespacedName: client.ObjectKeyFromObject(hub)}
+
+	countNodes := func() int {
+		nodes := &lynqv1.LynqNodeList{}
+		require.NoError(t, fakeClient.List(ctx, nodes))
+		return len(nodes.Items)
+	}
+
+	// First sync: deleting 4 of 4 nodes exceeds 50%, nothing is deleted
+	_, err := r.Reconcile(ctx, req)
+	require.NoError(t, err)
+	assert.Equal(t, 4, countNodes())
+
+	latest := &lynqv1.LynqHub{}
+	require.NoError(t, fakeClient.Get(ctx, req.NamespacedName, latest))
+	condition := meta.FindStatusCondition(latest.Status.Conditions, ConditionTypeDeletionBlocked)
+	require.NotNil(t, condition)
+	assert.Equal(t, metav1.ConditionTrue, condition.Status)
+	assert.True(t, hasEvent(recorder, "DeletionBlocked"), "expected a DeletionBlocked event")
+
+	// Approve: the next sync deletes the nodes and consumes the approval
+	latest.Annotations = map[string]string{lynqv1.AnnotationApproveDeletions: "true"}
+	require.NoError(t, fakeClient.Update(ctx, latest))
+
+	_, err = r.Reconcile(ctx, req)
+	require.NoError(t, err)
+	assert.Equal(t, 0, countNodes())
+
+	require.NoError(t, fakeClient.Get(ctx, req.NamespacedName, latest))
+	assert.NotContains(t, latest.Annotations, lynqv1.AnnotationApproveDeletions)
+	condition = meta.FindStatusCondition(latest.Status.Conditions, ConditionTypeDeletionBlocked)
+	require.NotNil(t, condition)
+	assert.Equal(t, metav1.ConditionFalse, condition.Status)
+}
+
+// hasEvent drains the recorder and reports whether an event with the given reason was recorded
+func hasEvent(recorder *record.FakeRecorder, reason string) bool {
+	found := false
+	for {
+		select {
+		case event := <-recorder.Events:
+			if strings.Contains(event, " "+reason+" ") {
+				found = true
+			}
+		default:
+			return found
+		}
+	}
+}
@@ -225,6 +225,49 @@ func TestShouldUpdateLynqNode_DetectsDataChanges(t *testing.T) {
 			expectUpdate: false,
 			description:  "No change should NOT trigger update",
 		},
+		{
+			name: "should detect nested json value change",
+			nodeData: map[string]string{
+				"lynq.sh/hostOrUrl":           "http://example.com",
+				"lynq.sh/activate":            "true",
+				"lynq.sh/extra":               `{"settings":{"features":{"sso":false}}}`,
+				"lynq.sh/template-generation": "1",
+			},
+			rowData: datasource.NodeRow{
+				UID:       "node1",
+				HostOrURL: "http://example.com",
+				Activate:  "true",
+				Extra:     map[string]string{"settings": `{"features": {"sso": true}}`},
+				Typed: map[string]interface{}{
+					"settings": map[string]interface{}{"features": map[string]interface{}{"sso": true}}, // Changed
+				},
+			},
+			expectUpdate: true,
+			description:  "A change inside a decoded json value should trigger update",
+		},
+		{
+			name: "should NOT update when only json formatting differs",
+			nodeData: map[string]string{
+				"lynq.sh/hostOrUrl":           "http://example.com",
+				"lynq.sh/activate":            "true",
+				"lynq.sh/extra":               `{"settings":{"features":{"sso":true},"regions":["eu","us"]}}`,
+				"lynq.sh/template-generation": "1",
+			},
+			rowData: datasource.NodeRow{
+				UID:       "node1",
+				HostOrURL: "http://example.com",
+				Activate:  "true",
+				Extra:     map[string]string{"settings": `{"regions": ["eu", "us"], "features": {"sso": true}}`},
+				Typed: map[string]interface{}{
+					"settings": map[string]interface{}{
+						"regions":  []interface{}{"eu", "us"},
+						"features": map[string]interface{}{"sso": true},
+					},
+				},
+			},
+			expectUpdate: false,
+			description:  "The decoded structure is compared, not the column text",
+		},
 	}
 
 	for _, tt := range tests {
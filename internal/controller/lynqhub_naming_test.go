@@ -0,0 +1,179 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	lynqv1 "github.com/k8s-lynq/lynq/api/v1"
+	"github.com/k8s-lynq/lynq/internal/datasource"
+	"github.com/k8s-lynq/lynq/internal/template"
+)
+
+func TestSafeObjectName(t *testing.T) {
+	assert.Equal(t, "acme-web", safeObjectName("acme-web"), "valid names are kept")
+	assert.Equal(t, "acme.io-web", safeObjectName("acme.io-web"))
+
+	email := safeObjectName("Alice@Example.com-web")
+	assert.True(t, strings.HasPrefix(email, "alice-example-com-web-"), email)
+	assert.Empty(t, validation.IsDNS1123Subdomain(email))
+	assert.Equal(t, email, safeObjectName("Alice@Example.com-web"), "deterministic")
+	assert.NotEqual(t, email, safeObjectName("alice@example.com-web"), "different inputs keep different names")
+
+	long := safeObjectName(strings.Repeat("a", 300))
+	assert.Len(t, long, validation.DNS1123SubdomainMaxLength)
+	assert.Empty(t, validation.IsDNS1123Subdomain(long))
+
+	hashOnly := safeObjectName("@@@")
+	assert.Len(t, hashOnly, 10)
+	assert.Empty(t, validation.IsDNS1123Subdomain(hashOnly))
+}
+
+func TestUIDLabelValue(t *testing.T) {
+	assert.Equal(t, "Beta_Corp.1", uidLabelValue("Beta_Corp.1"), "valid label values are kept")
+
+	email := uidLabelValue("alice@example.com")
+	assert.True(t, strings.HasPrefix(email, "alice-example.com-"), email)
+	assert.Empty(t, validation.IsValidLabelValue(email))
+
+	long := uidLabelValue(strings.Repeat("a", 64))
+	assert.Len(t, long, validation.LabelValueMaxLength)
+	assert.Empty(t, validation.IsValidLabelValue(long))
+	assert.NotEqual(t, long, uidLabelValue(strings.Repeat("a", 65)))
+}
+
+func TestLynqNodeName(t *testing.T) {
+	hub := &lynqv1.LynqHub{ObjectMeta: metav1.ObjectMeta{Name: "billing"}}
+	form := &lynqv1.LynqForm{ObjectMeta: metav1.ObjectMeta{Name: "web"}}
+	row := datasource.NodeRow{UID: "acme", Extra: map[string]string{"region": "eu"}}
+	engine := template.NewEngine()
+
+	name, err := lynqNodeName(engine, hub, form, row)
+	require.NoError(t, err)
+	assert.Equal(t, "acme-web", name, "default name")
+	assert.Equal(t, []*lynqv1.LynqForm{form}, defaultNamedForms(hub, []*lynqv1.LynqForm{form}))
+
+	hub.Spec.NodeNameTemplate = "{{ .hubId }}-{{ .uid }}"
+	name, err = lynqNodeName(engine, hub, form, row)
+	require.NoError(t, err)
+	assert.Equal(t, "billing-acme", name, "hub template")
+	assert.Empty(t, defaultNamedForms(hub, []*lynqv1.LynqForm{form}))
+
+	form.Spec.NodeNameTemplate = "{{ .region }}-{{ .uid }}-{{ .templateRef }}"
+	name, err = lynqNodeName(engine, hub, form, row)
+	require.NoError(t, err)
+	assert.Equal(t, "eu-acme-web", name, "form template overrides the hub's")
+
+	form.Spec.NodeNameTemplate = "{{ .uid"
+	_, err = lynqNodeName(engine, hub, form, row)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to render nodeNameTemplate")
+}
+
+func TestReconcileNodeNameTemplate(t *testing.T) {
+	ctx := context.Background()
+	scheme := runtime.NewScheme()
+	require.NoError(t, lynqv1.AddToScheme(scheme))
+	require.NoError(t, corev1.AddToScheme(scheme))
+
+	hub := &lynqv1.LynqHub{
+		ObjectMeta: metav1.ObjectMeta{Name: "billing", Namespace: "default", Finalizers: []string{FinalizerLynqHub}},
+		Spec: lynqv1.LynqHubSpec{
+			Source: lynqv1.DataSource{
+				Type:         lynqv1.SourceTypeConfigMap,
+				SyncInterval: "1m",
+				ConfigMap:    &lynqv1.ConfigMapSource{Name: "tenants", Key: "rows.csv"},
+			},
+			ValueMappings:      lynqv1.ValueMappings{UID: "id", Activate: "active"},
+			ExtraValueMappings: map[string]string{"team": "team"},
+			NodeNameTemplate:   "{{ .uid }}-{{ .templateRef }}",
+		},
+	}
+	web := &lynqv1.LynqForm{
+		ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default"},
+		Spec:       lynqv1.LynqFormSpec{HubID: "billing"},
+	}
+	// Two customers in the same team render the same name
+	team := &lynqv1.LynqForm{
+		ObjectMeta: metav1.ObjectMeta{Name: "team", Namespace: "default"},
+		Spec:       lynqv1.LynqFormSpec{HubID: "billing", NodeNameTemplate: "team-{{ .team }}"},
+	}
+	rows := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "tenants", Namespace: "default"},
+		Data:       map[string]string{"rows.csv": "id,active,team\nalice@example.com,1,red\nbob@example.com,1,red\n"},
+	}
+
+	fakeClient := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(hub, web, team, rows).
+		WithStatusSubresource(&lynqv1.LynqHub{}).
+		Build()
+	recorder := record.NewFakeRecorder(100)
+	r := &LynqHubReconciler{Client: fakeClient, Scheme: scheme, Recorder: recorder}
+	req := ctrl.Request{NamespacedName: client.ObjectKeyFromObject(hub)}
+
+	_, err := r.Reconcile(ctx, req)
+	require.NoError(t, err)
+
+	nodes := &lynqv1.LynqNodeList{}
+	require.NoError(t, fakeClient.List(ctx, nodes))
+	require.Len(t, nodes.Items, 3, "e-mail uids are not rejected; the conflicting team node is skipped")
+	byKey := make(map[string]lynqv1.LynqNode, len(nodes.Items))
+	for _, node := range nodes.Items {
+		byKey[node.Spec.TemplateRef+"/"+node.Spec.UID] = node
+		assert.Empty(t, validation.IsDNS1123Subdomain(node.Name))
+		assert.Equal(t, uidLabelValue(node.Spec.UID), node.Labels["lynq.sh/uid"])
+	}
+	alice := byKey["web/alice@example.com"]
+	assert.True(t, strings.HasPrefix(alice.Name, "alice-example-com-web-"), alice.Name)
+	assert.Equal(t, "team-red", byKey["team/alice@example.com"].Name, "the first row keeps the name")
+
+	var events []string
+	for len(recorder.Events) > 0 {
+		events = append(events, <-recorder.Events)
+	}
+	assert.Contains(t, strings.Join(events, "\n"),
+		`NodeNameConflict LynqNode name "team-red" for uid bob@example.com (LynqForm team) is already used by uid alice@example.com (LynqForm team)`)
+
+	// Nodes are found by the uid label
+	found, err := r.findLynqNode(ctx, hub, "web", "alice@example.com")
+	require.NoError(t, err)
+	require.NotNil(t, found)
+	assert.Equal(t, alice.Name, found.Name)
+	found, err = r.findLynqNode(ctx, hub, "team", "bob@example.com")
+	require.NoError(t, err)
+	assert.Nil(t, found)
+
+	// A second sync matches the existing nodes by uid and form, not by name
+	_, err = r.Reconcile(ctx, req)
+	require.NoError(t, err)
+	require.NoError(t, fakeClient.List(ctx, nodes))
+	assert.Len(t, nodes.Items, 3)
+}
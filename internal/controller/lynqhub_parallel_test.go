@@ -0,0 +1,234 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	lynqv1 "github.com/k8s-lynq/lynq/api/v1"
+	"github.com/k8s-lynq/lynq/internal/datasource"
+	"github.com/k8s-lynq/lynq/internal/template"
+)
+
+// parallelTestHub returns a configmap hub with one LynqForm and the given rows
+func parallelTestHub(rows int) (*lynqv1.LynqHub, *lynqv1.LynqForm, *corev1.ConfigMap) {
+	hub := &lynqv1.LynqHub{
+		ObjectMeta: metav1.ObjectMeta{Name: "billing", Namespace: "default", Finalizers: []string{FinalizerLynqHub}},
+		Spec: lynqv1.LynqHubSpec{
+			Source: lynqv1.DataSource{
+				Type:         lynqv1.SourceTypeConfigMap,
+				SyncInterval: "1m",
+				ConfigMap:    &lynqv1.ConfigMapSource{Name: "tenants", Key: "rows.csv"},
+			},
+			ValueMappings: lynqv1.ValueMappings{UID: "id", Activate: "active"},
+			Parallelism:   8,
+		},
+	}
+	form := &lynqv1.LynqForm{
+		ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default", Generation: 1},
+		Spec:       lynqv1.LynqFormSpec{HubID: "billing"},
+	}
+	var csv strings.Builder
+	csv.WriteString("id,active\n")
+	for i := 0; i < rows; i++ {
+		fmt.Fprintf(&csv, "tenant-%02d,1\n", i)
+	}
+	configMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "tenants", Namespace: "default"},
+		Data:       map[string]string{"rows.csv": csv.String()},
+	}
+	return hub, form, configMap
+}
+
+func TestApplyNodeWrites(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, lynqv1.AddToScheme(scheme))
+
+	var inFlight, maxInFlight atomic.Int32
+	fakeClient := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithInterceptorFuncs(interceptor.Funcs{
+			Create: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+				current := inFlight.Add(1)
+				defer inFlight.Add(-1)
+				for {
+					seen := maxInFlight.Load()
+					if current <= seen || maxInFlight.CompareAndSwap(seen, current) {
+						break
+					}
+				}
+				time.Sleep(5 * time.Millisecond)
+				if strings.HasPrefix(obj.GetName(), "broken") {
+					return fmt.Errorf("admission webhook denied the request")
+				}
+				return c.Create(ctx, obj, opts...)
+			},
+		}).
+		Build()
+	r := &LynqHubReconciler{Client: fakeClient, Scheme: scheme, Recorder: record.NewFakeRecorder(100)}
+
+	hub := &lynqv1.LynqHub{ObjectMeta: metav1.ObjectMeta{Name: "billing", Namespace: "default"}}
+	form := &lynqv1.LynqForm{ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default"}}
+	var writes []nodeWrite
+	for i := 0; i < 20; i++ {
+		writes = append(writes, nodeWrite{template: form, row: datasource.NodeRow{UID: fmt.Sprintf("tenant-%02d", i)}})
+	}
+	writes = append(writes, nodeWrite{template: form, row: datasource.NodeRow{UID: "broken"}})
+
+	errs := r.applyNodeWrites(context.Background(), hub, template.NewEngine(), writes, 4)
+	require.Len(t, errs, len(writes))
+	for i := 0; i < 20; i++ {
+		assert.NoError(t, errs[i])
+	}
+	require.Error(t, errs[20], "errors are returned in the order of the writes")
+	assert.LessOrEqual(t, maxInFlight.Load(), int32(4))
+	assert.Greater(t, maxInFlight.Load(), int32(1), "writes run in parallel")
+
+	nodes := &lynqv1.LynqNodeList{}
+	require.NoError(t, fakeClient.List(context.Background(), nodes))
+	assert.Len(t, nodes.Items, 20)
+}
+
+func TestReconcileParallelMaxSkew(t *testing.T) {
+	ctx := context.Background()
+	scheme := runtime.NewScheme()
+	require.NoError(t, lynqv1.AddToScheme(scheme))
+	require.NoError(t, corev1.AddToScheme(scheme))
+
+	hub, form, rows := parallelTestHub(6)
+	form.Spec.Rollout = &lynqv1.RolloutConfig{MaxSkew: 2}
+	fakeClient := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(hub, form, rows).
+		WithStatusSubresource(&lynqv1.LynqHub{}).
+		Build()
+	recorder := record.NewFakeRecorder(100)
+	r := &LynqHubReconciler{Client: fakeClient, Scheme: scheme, Recorder: recorder}
+
+	_, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: client.ObjectKeyFromObject(hub)})
+	require.NoError(t, err)
+
+	nodes := &lynqv1.LynqNodeList{}
+	require.NoError(t, fakeClient.List(ctx, nodes))
+	assert.Len(t, nodes.Items, 2, "parallelism 8 still creates at most maxSkew nodes")
+
+	var events []string
+	for len(recorder.Events) > 0 {
+		events = append(events, <-recorder.Events)
+	}
+	assert.Contains(t, strings.Join(events, "\n"), "LynqForm 'web': 4 node updates throttled (maxSkew=2")
+}
+
+func TestReconcileNodeWriteFailures(t *testing.T) {
+	ctx := context.Background()
+	scheme := runtime.NewScheme()
+	require.NoError(t, lynqv1.AddToScheme(scheme))
+	require.NoError(t, corev1.AddToScheme(scheme))
+
+	hub, form, rows := parallelTestHub(5)
+	var failing atomic.Bool
+	failing.Store(true)
+	fakeClient := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(hub, form, rows).
+		WithStatusSubresource(&lynqv1.LynqHub{}).
+		WithInterceptorFuncs(interceptor.Funcs{
+			Create: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+				if node, ok := obj.(*lynqv1.LynqNode); ok && failing.Load() &&
+					(node.Spec.UID == "tenant-01" || node.Spec.UID == "tenant-03") {
+					return apierrors.NewForbidden(lynqv1.GroupVersion.WithResource("lynqnodes").GroupResource(), node.Name,
+						fmt.Errorf("exceeded quota"))
+				}
+				return c.Create(ctx, obj, opts...)
+			},
+		}).
+		Build()
+	recorder := record.NewFakeRecorder(100)
+	r := &LynqHubReconciler{Client: fakeClient, Scheme: scheme, Recorder: recorder}
+	req := ctrl.Request{NamespacedName: client.ObjectKeyFromObject(hub)}
+
+	_, err := r.Reconcile(ctx, req)
+	require.NoError(t, err)
+
+	got := &lynqv1.LynqHub{}
+	require.NoError(t, fakeClient.Get(ctx, req.NamespacedName, got))
+	assert.Equal(t, int32(2), got.Status.NodeWriteFailureCount)
+	require.Len(t, got.Status.NodeWriteFailures, 2)
+	uids := []string{got.Status.NodeWriteFailures[0].UID, got.Status.NodeWriteFailures[1].UID}
+	assert.ElementsMatch(t, []string{"tenant-01", "tenant-03"}, uids)
+	assert.Equal(t, "Create", got.Status.NodeWriteFailures[0].Operation)
+	assert.Equal(t, "web", got.Status.NodeWriteFailures[0].Form)
+	assert.Contains(t, got.Status.NodeWriteFailures[0].Message, "exceeded quota")
+
+	var events []string
+	for len(recorder.Events) > 0 {
+		events = append(events, <-recorder.Events)
+	}
+	assert.Contains(t, strings.Join(events, "\n"), "NodeWritesFailed 2 of 5 LynqNode writes failed")
+
+	// The next sync retries the failed writes and clears them from status
+	failing.Store(false)
+	_, err = r.Reconcile(ctx, req)
+	require.NoError(t, err)
+	require.NoError(t, fakeClient.Get(ctx, req.NamespacedName, got))
+	assert.Zero(t, got.Status.NodeWriteFailureCount)
+	assert.Empty(t, got.Status.NodeWriteFailures)
+
+	nodes := &lynqv1.LynqNodeList{}
+	require.NoError(t, fakeClient.List(ctx, nodes))
+	assert.Len(t, nodes.Items, 5)
+}
+
+func TestRecordNodeWriteFailures(t *testing.T) {
+	var failures []lynqv1.NodeWriteFailure
+	for i := 0; i < maxNodeWriteFailures+10; i++ {
+		failures = append(failures, lynqv1.NodeWriteFailure{
+			UID: fmt.Sprintf("tenant-%d", i), Form: "web", Operation: "Update", Message: strings.Repeat("x", maxSyncErrorLength+1),
+		})
+	}
+	status := &lynqv1.LynqHubStatus{}
+	recordNodeWriteFailures(status, failures)
+	assert.Equal(t, int32(maxNodeWriteFailures+10), status.NodeWriteFailureCount)
+	require.Len(t, status.NodeWriteFailures, maxNodeWriteFailures)
+	assert.Len(t, status.NodeWriteFailures[0].Message, maxSyncErrorLength+len("..."))
+	assert.Len(t, failures[0].Message, maxSyncErrorLength+1, "the failures passed in are not modified")
+
+	recordNodeWriteFailures(status, nil)
+	assert.Zero(t, status.NodeWriteFailureCount)
+	assert.Nil(t, status.NodeWriteFailures)
+
+	summary := summarizeNodeWriteFailures(failures[:5])
+	assert.True(t, strings.HasPrefix(summary, "Update tenant-0 (LynqForm web): "), summary)
+	assert.True(t, strings.HasSuffix(summary, "; and 2 more"), summary)
+}
@@ -0,0 +1,223 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	lynqv1 "github.com/k8s-lynq/lynq/api/v1"
+	"github.com/k8s-lynq/lynq/internal/datasource"
+)
+
+func TestScreenRows(t *testing.T) {
+	templates := []*lynqv1.LynqForm{{ObjectMeta: metav1.ObjectMeta{Name: "web"}}}
+	rows := []datasource.NodeRow{
+		{UID: "acme"},
+		{UID: ""},
+		{UID: "acme", Activate: "second"},
+		{UID: "Beta_Corp"},
+		{UID: "gamma.io"},
+		{UID: strings.Repeat("a", 64)},
+		{UID: "beta-corp"},
+	}
+
+	tests := []struct {
+		name     string
+		policy   lynqv1.InvalidRowPolicy
+		wantUIDs []string
+		rejected []lynqv1.RejectedRow
+	}{
+		{
+			name:     "skip",
+			policy:   lynqv1.InvalidRowPolicySkip,
+			wantUIDs: []string{"acme", "gamma.io", "beta-corp"},
+			rejected: []lynqv1.RejectedRow{
+				{Reason: lynqv1.RowRejectedEmptyUID, Message: "row has an empty uid"},
+				{UID: "acme", Reason: lynqv1.RowRejectedDuplicateUID, Message: `uid "acme" is used by more than one row; the first row is synced`},
+				{UID: "Beta_Corp", Reason: lynqv1.RowRejectedInvalidUID},
+				{UID: strings.Repeat("a", 64), Reason: lynqv1.RowRejectedInvalidUID},
+			},
+		},
+		{
+			name:     "sanitize",
+			policy:   lynqv1.InvalidRowPolicySanitize,
+			wantUIDs: []string{"acme", "beta-corp", "gamma.io", strings.Repeat("a", 63)},
+			rejected: []lynqv1.RejectedRow{
+				{Reason: lynqv1.RowRejectedEmptyUID, Message: "row has an empty uid"},
+				{UID: "acme", Reason: lynqv1.RowRejectedDuplicateUID, Message: `uid "acme" is used by more than one row; the first row is synced`},
+				{UID: "beta-corp", Reason: lynqv1.RowRejectedDuplicateUID, Message: `uid "beta-corp" sanitizes to "beta-corp", which another row already uses`},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			valid, rejected := screenRows(rows, templates, tt.policy)
+			uids := make([]string, 0, len(valid))
+			for _, row := range valid {
+				uids = append(uids, row.UID)
+			}
+			assert.Equal(t, tt.wantUIDs, uids)
+			require.Len(t, rejected, len(tt.rejected))
+			for i, want := range tt.rejected {
+				assert.Equal(t, want.UID, rejected[i].UID)
+				assert.Equal(t, want.Reason, rejected[i].Reason)
+				if want.Message != "" {
+					assert.Equal(t, want.Message, rejected[i].Message)
+				}
+			}
+		})
+	}
+	assert.Equal(t, "Beta_Corp", rows[3].UID, "input rows must not be modified")
+
+	kept, _ := screenRows(rows[:3], templates, lynqv1.InvalidRowPolicySkip)
+	require.Len(t, kept, 1)
+	assert.Empty(t, kept[0].Activate, "the first row of a duplicate uid is kept")
+}
+
+func TestUIDProblemNameTooLong(t *testing.T) {
+	templates := []*lynqv1.LynqForm{{ObjectMeta: metav1.ObjectMeta{Name: strings.Repeat("t", 250)}}}
+
+	reason, message := uidProblem("acme", templates)
+	assert.Equal(t, lynqv1.RowRejectedNameTooLong, reason)
+	assert.Contains(t, message, "longer than 253 characters")
+
+	assert.Equal(t, "ac", sanitizeUID("acme", templates), "truncated to fit the LynqNode name")
+	assert.Empty(t, sanitizeUID("__", nil), "nothing valid left")
+}
+
+func TestRecordRejectedRows(t *testing.T) {
+	status := &lynqv1.LynqHubStatus{}
+	acme := lynqv1.RejectedRow{UID: "Acme", Reason: lynqv1.RowRejectedInvalidUID, Message: "acme is invalid"}
+	beta := lynqv1.RejectedRow{UID: "Beta", Reason: lynqv1.RowRejectedInvalidUID, Message: "beta is invalid"}
+
+	// Full sync
+	recordRejectedRows(status, []lynqv1.RejectedRow{acme}, lynqv1.InvalidRowPolicySkip, false, nil)
+	assert.Equal(t, []lynqv1.RejectedRow{acme}, status.RejectedRows)
+	assert.Equal(t, int32(1), status.RejectedRowCount)
+	condition := meta.FindStatusCondition(status.Conditions, ConditionTypeRowsRejected)
+	require.NotNil(t, condition)
+	assert.Equal(t, metav1.ConditionTrue, condition.Status)
+	assert.Equal(t, "RowsSkipped", condition.Reason)
+	assert.Equal(t, "1 rows are not synced: acme is invalid", condition.Message)
+
+	// Incremental sync that did not read Acme keeps it
+	recordRejectedRows(status, []lynqv1.RejectedRow{beta}, lynqv1.InvalidRowPolicySkip, true, map[string]struct{}{"Beta": {}})
+	assert.Equal(t, []lynqv1.RejectedRow{acme, beta}, status.RejectedRows)
+	assert.Equal(t, int32(2), status.RejectedRowCount)
+
+	// Incremental sync that read the fixed Acme row drops it
+	recordRejectedRows(status, nil, lynqv1.InvalidRowPolicySkip, true, map[string]struct{}{"Acme": {}})
+	assert.Equal(t, []lynqv1.RejectedRow{beta}, status.RejectedRows)
+	assert.Equal(t, int32(1), status.RejectedRowCount)
+
+	// The list is bounded, the count is not
+	many := make([]lynqv1.RejectedRow, maxRejectedRows+5)
+	recordRejectedRows(status, many, lynqv1.InvalidRowPolicyFail, false, nil)
+	assert.Len(t, status.RejectedRows, maxRejectedRows)
+	assert.Equal(t, int32(maxRejectedRows+5), status.RejectedRowCount)
+	condition = meta.FindStatusCondition(status.Conditions, ConditionTypeRowsRejected)
+	assert.Equal(t, "SyncHalted", condition.Reason)
+	assert.Contains(t, condition.Message, "and 52 more")
+
+	recordRejectedRows(status, nil, lynqv1.InvalidRowPolicyFail, true, nil)
+	assert.Empty(t, status.RejectedRows)
+	assert.Zero(t, status.RejectedRowCount)
+	condition = meta.FindStatusCondition(status.Conditions, ConditionTypeRowsRejected)
+	assert.Equal(t, metav1.ConditionFalse, condition.Status)
+	assert.Equal(t, "AllRowsValid", condition.Reason)
+}
+
+func TestReconcileInvalidRows(t *testing.T) {
+	for _, tt := range []struct {
+		policy    lynqv1.InvalidRowPolicy
+		wantNodes []string
+	}{
+		{policy: lynqv1.InvalidRowPolicySkip, wantNodes: []string{"acme-web", "beta-web"}},
+		{policy: lynqv1.InvalidRowPolicySanitize, wantNodes: []string{"acme-web", "beta-web", "gamma-corp-web"}},
+		{policy: lynqv1.InvalidRowPolicyFail},
+	} {
+		t.Run(string(tt.policy), func(t *testing.T) {
+			ctx := context.Background()
+			scheme := runtime.NewScheme()
+			require.NoError(t, lynqv1.AddToScheme(scheme))
+			require.NoError(t, corev1.AddToScheme(scheme))
+
+			hub := &lynqv1.LynqHub{
+				ObjectMeta: metav1.ObjectMeta{Name: "billing", Namespace: "default", Finalizers: []string{FinalizerLynqHub}},
+				Spec: lynqv1.LynqHubSpec{
+					Source: lynqv1.DataSource{
+						Type:         lynqv1.SourceTypeConfigMap,
+						SyncInterval: "1m",
+						ConfigMap:    &lynqv1.ConfigMapSource{Name: "tenants", Key: "rows.csv"},
+					},
+					ValueMappings:    lynqv1.ValueMappings{UID: "id", Activate: "active"},
+					InvalidRowPolicy: tt.policy,
+				},
+			}
+			form := &lynqv1.LynqForm{
+				ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default"},
+				Spec:       lynqv1.LynqFormSpec{HubID: "billing"},
+			}
+			rows := &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Name: "tenants", Namespace: "default"},
+				Data:       map[string]string{"rows.csv": "id,active\nacme,1\nbeta,1\nacme,1\nGamma_Corp,1\n"},
+			}
+
+			fakeClient := fake.NewClientBuilder().
+				WithScheme(scheme).
+				WithObjects(hub, form, rows).
+				WithStatusSubresource(&lynqv1.LynqHub{}).
+				Build()
+			r := &LynqHubReconciler{Client: fakeClient, Scheme: scheme, Recorder: record.NewFakeRecorder(100)}
+			req := ctrl.Request{NamespacedName: client.ObjectKeyFromObject(hub)}
+
+			_, err := r.Reconcile(ctx, req)
+			require.NoError(t, err)
+
+			nodes := &lynqv1.LynqNodeList{}
+			require.NoError(t, fakeClient.List(ctx, nodes))
+			var names []string
+			for _, node := range nodes.Items {
+				names = append(names, node.Name)
+			}
+			assert.ElementsMatch(t, tt.wantNodes, names)
+
+			latest := &lynqv1.LynqHub{}
+			require.NoError(t, fakeClient.Get(ctx, req.NamespacedName, latest))
+			assert.NotEmpty(t, latest.Status.RejectedRows)
+			assert.Equal(t, "acme", latest.Status.RejectedRows[0].UID)
+			assert.Equal(t, lynqv1.RowRejectedDuplicateUID, latest.Status.RejectedRows[0].Reason)
+			condition := meta.FindStatusCondition(latest.Status.Conditions, ConditionTypeRowsRejected)
+			require.NotNil(t, condition)
+			assert.Equal(t, metav1.ConditionTrue, condition.Status)
+		})
+	}
+}
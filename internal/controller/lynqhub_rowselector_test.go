@@ -0,0 +1,201 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	lynqv1 "github.com/k8s-lynq/lynq/api/v1"
+	"github.com/k8s-lynq/lynq/internal/datasource"
+	"github.com/k8s-lynq/lynq/internal/template"
+)
+
+func TestRowSelected(t *testing.T) {
+	hub := &lynqv1.LynqHub{ObjectMeta: metav1.ObjectMeta{Name: "billing"}}
+	enterprise := datasource.NodeRow{UID: "acme", Extra: map[string]string{"plan": "enterprise", "region": "eu", "sla": ""}}
+	free := datasource.NodeRow{UID: "beta", Extra: map[string]string{"plan": "free", "region": "us", "sla": "gold"}}
+
+	tests := []struct {
+		name     string
+		selector *lynqv1.RowSelector
+		want     []bool // enterprise, free
+	}{
+		{name: "no selector", selector: nil, want: []bool{true, true}},
+		{
+			name:     "matchValues",
+			selector: &lynqv1.RowSelector{MatchValues: map[string]string{"plan": "enterprise"}},
+			want:     []bool{true, false},
+		},
+		{
+			name:     "matchValues on uid",
+			selector: &lynqv1.RowSelector{MatchValues: map[string]string{"uid": "beta"}},
+			want:     []bool{false, true},
+		},
+		{
+			name: "In",
+			selector: &lynqv1.RowSelector{MatchExpressions: []lynqv1.RowSelectorRequirement{
+				{Key: "region", Operator: lynqv1.RowSelectorOpIn, Values: []string{"eu", "ap"}},
+			}},
+			want: []bool{true, false},
+		},
+		{
+			name: "NotIn",
+			selector: &lynqv1.RowSelector{MatchExpressions: []lynqv1.RowSelectorRequirement{
+				{Key: "region", Operator: lynqv1.RowSelectorOpNotIn, Values: []string{"eu"}},
+			}},
+			want: []bool{false, true},
+		},
+		{
+			name: "Exists treats empty values as missing",
+			selector: &lynqv1.RowSelector{MatchExpressions: []lynqv1.RowSelectorRequirement{
+				{Key: "sla", Operator: lynqv1.RowSelectorOpExists},
+			}},
+			want: []bool{false, true},
+		},
+		{
+			name: "DoesNotExist on an unmapped key",
+			selector: &lynqv1.RowSelector{MatchExpressions: []lynqv1.RowSelectorRequirement{
+				{Key: "tier", Operator: lynqv1.RowSelectorOpDoesNotExist},
+			}},
+			want: []bool{true, true},
+		},
+		{
+			name:     "expression",
+			selector: &lynqv1.RowSelector{Expression: `{{ or (eq .plan "enterprise") (eq .sla "gold") }}`},
+			want:     []bool{true, true},
+		},
+		{
+			name: "all parts must match",
+			selector: &lynqv1.RowSelector{
+				MatchValues: map[string]string{"plan": "enterprise"},
+				Expression:  `{{ eq .region "us" }}`,
+			},
+			want: []bool{false, false},
+		},
+	}
+	engine := template.NewEngine()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			form := &lynqv1.LynqForm{
+				ObjectMeta: metav1.ObjectMeta{Name: "premium"},
+				Spec:       lynqv1.LynqFormSpec{RowSelector: tt.selector},
+			}
+			for i, row := range []datasource.NodeRow{enterprise, free} {
+				got, err := rowSelected(engine, hub, form, row)
+				require.NoError(t, err)
+				assert.Equal(t, tt.want[i], got, row.UID)
+			}
+		})
+	}
+
+	form := &lynqv1.LynqForm{Spec: lynqv1.LynqFormSpec{RowSelector: &lynqv1.RowSelector{Expression: "{{ .tier }}"}}}
+	_, err := rowSelected(engine, hub, form, enterprise)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to render rowSelector.expression")
+}
+
+func TestReconcileRowSelector(t *testing.T) {
+	ctx := context.Background()
+	scheme := runtime.NewScheme()
+	require.NoError(t, lynqv1.AddToScheme(scheme))
+	require.NoError(t, corev1.AddToScheme(scheme))
+
+	hub := &lynqv1.LynqHub{
+		ObjectMeta: metav1.ObjectMeta{Name: "billing", Namespace: "default", Finalizers: []string{FinalizerLynqHub}},
+		Spec: lynqv1.LynqHubSpec{
+			Source: lynqv1.DataSource{
+				Type:         lynqv1.SourceTypeConfigMap,
+				SyncInterval: "1m",
+				ConfigMap:    &lynqv1.ConfigMapSource{Name: "tenants", Key: "rows.csv"},
+			},
+			ValueMappings:      lynqv1.ValueMappings{UID: "id", Activate: "active"},
+			ExtraValueMappings: map[string]string{"plan": "plan"},
+		},
+	}
+	web := &lynqv1.LynqForm{
+		ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default"},
+		Spec:       lynqv1.LynqFormSpec{HubID: "billing"},
+	}
+	addons := &lynqv1.LynqForm{
+		ObjectMeta: metav1.ObjectMeta{Name: "premium-addons", Namespace: "default"},
+		Spec: lynqv1.LynqFormSpec{
+			HubID:       "billing",
+			RowSelector: &lynqv1.RowSelector{MatchValues: map[string]string{"plan": "enterprise"}},
+		},
+	}
+	rows := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "tenants", Namespace: "default"},
+		Data:       map[string]string{"rows.csv": "id,active,plan\nacme,1,enterprise\nbeta,1,free\ngamma,1,free\n"},
+	}
+
+	fakeClient := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(hub, web, addons, rows).
+		WithStatusSubresource(&lynqv1.LynqHub{}).
+		Build()
+	r := &LynqHubReconciler{Client: fakeClient, Scheme: scheme, Recorder: record.NewFakeRecorder(100)}
+	req := ctrl.Request{NamespacedName: client.ObjectKeyFromObject(hub)}
+
+	nodeNames := func() []string {
+		nodes := &lynqv1.LynqNodeList{}
+		require.NoError(t, fakeClient.List(ctx, nodes))
+		names := make([]string, 0, len(nodes.Items))
+		for _, node := range nodes.Items {
+			names = append(names, node.Name)
+		}
+		sort.Strings(names)
+		return names
+	}
+
+	_, err := r.Reconcile(ctx, req)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"acme-premium-addons", "acme-web", "beta-web", "gamma-web"}, nodeNames())
+
+	got := &lynqv1.LynqHub{}
+	require.NoError(t, fakeClient.Get(ctx, req.NamespacedName, got))
+	assert.Equal(t, int32(4), got.Status.Desired, "only selected pairs are desired")
+
+	// A row that stops matching loses the form's node; a row that starts matching gains it
+	require.NoError(t, fakeClient.Get(ctx, client.ObjectKeyFromObject(rows), rows))
+	rows.Data["rows.csv"] = "id,active,plan\nacme,1,free\nbeta,1,enterprise\ngamma,1,free\n"
+	require.NoError(t, fakeClient.Update(ctx, rows))
+
+	_, err = r.Reconcile(ctx, req)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"acme-web", "beta-premium-addons", "beta-web", "gamma-web"}, nodeNames())
+
+	require.NoError(t, fakeClient.Get(ctx, req.NamespacedName, got))
+	// The web nodes of acme and beta are updated with the new plan
+	assert.Equal(t, &lynqv1.SyncChanges{Created: 1, Updated: 2, Deleted: 1}, got.Status.LastSyncChanges)
+	require.Len(t, got.Status.Forms, 2)
+	assert.Equal(t, "premium-addons", got.Status.Forms[0].Name)
+	assert.Equal(t, int32(1), got.Status.Forms[0].Desired)
+	assert.Equal(t, "web", got.Status.Forms[1].Name)
+	assert.Equal(t, int32(3), got.Status.Forms[1].Desired)
+}
@@ -0,0 +1,159 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	lynqv1 "github.com/k8s-lynq/lynq/api/v1"
+	"github.com/k8s-lynq/lynq/internal/datasource"
+)
+
+// schemaTestHub returns a hub reading the nodes table through the sqlmock driver
+func schemaTestHub(dsn string) *lynqv1.LynqHub {
+	return &lynqv1.LynqHub{
+		ObjectMeta: metav1.ObjectMeta{Name: "hub", Namespace: "default", Generation: 2},
+		Spec: lynqv1.LynqHubSpec{
+			Source: lynqv1.DataSource{
+				Type: lynqv1.SourceTypeSQL,
+				SQL:  &lynqv1.SQLSource{Driver: "sqlmock", Dialect: lynqv1.SQLDialectSQLite, DSN: dsn, Table: "nodes"},
+			},
+			ValueMappings:      lynqv1.ValueMappings{UID: "id", Activate: "active"},
+			ExtraValueMappings: map[string]string{"plan": "tenant_plan"},
+		},
+	}
+}
+
+func TestRecordSchemaCheck(t *testing.T) {
+	status := &lynqv1.LynqHubStatus{}
+
+	issues := []datasource.SchemaIssue{
+		{Field: "extraValueMappings.plan", Column: "tenant_plan", Reason: datasource.SchemaIssueColumnNotFound,
+			Message: "column `tenant_plan` not found in `nodes` (extraValueMappings.plan)"},
+		{Field: "valueMappings.uid", Column: "id", Reason: datasource.SchemaIssueNullableUID,
+			Message: "uid column `id` in `nodes` is nullable; a NULL uid cannot identify a node"},
+	}
+	recordSchemaCheck(status, 3, issues, nil)
+	condition := meta.FindStatusCondition(status.Conditions, ConditionTypeSchemaValid)
+	require.NotNil(t, condition)
+	assert.Equal(t, metav1.ConditionFalse, condition.Status)
+	assert.Equal(t, "SchemaMismatch", condition.Reason)
+	assert.Equal(t, int64(3), condition.ObservedGeneration)
+	assert.Equal(t, issues[0].Message+"; "+issues[1].Message, condition.Message)
+	require.Len(t, status.SchemaIssues, 2)
+	assert.Equal(t, lynqv1.SchemaIssue{Field: "extraValueMappings.plan", Column: "tenant_plan", Reason: "ColumnNotFound",
+		Message: issues[0].Message}, status.SchemaIssues[0])
+
+	recordSchemaCheck(status, 4, nil, errors.New("permission denied"))
+	condition = meta.FindStatusCondition(status.Conditions, ConditionTypeSchemaValid)
+	assert.Equal(t, metav1.ConditionUnknown, condition.Status)
+	assert.Equal(t, "InspectionFailed", condition.Reason)
+	assert.Empty(t, status.SchemaIssues)
+
+	recordSchemaCheck(status, 4, nil, nil)
+	condition = meta.FindStatusCondition(status.Conditions, ConditionTypeSchemaValid)
+	assert.Equal(t, metav1.ConditionTrue, condition.Status)
+	assert.Equal(t, "ColumnsFound", condition.Reason)
+}
+
+func TestSchemaCheckDue(t *testing.T) {
+	hub := schemaTestHub("unused")
+	assert.True(t, schemaCheckDue(hub), "never checked")
+
+	recordSchemaCheck(&hub.Status, hub.Generation, nil, nil)
+	assert.False(t, schemaCheckDue(hub), "passed for this generation")
+
+	hub.Generation++
+	assert.True(t, schemaCheckDue(hub), "spec changed")
+
+	recordSchemaCheck(&hub.Status, hub.Generation, []datasource.SchemaIssue{{Reason: datasource.SchemaIssueColumnNotFound}}, nil)
+	assert.True(t, schemaCheckDue(hub), "issues are re-checked on every sync")
+
+	hub.Spec.Source = lynqv1.DataSource{Type: lynqv1.SourceTypeInline, Inline: &lynqv1.InlineSource{}}
+	assert.False(t, schemaCheckDue(hub), "not a SQL source")
+}
+
+func TestCheckSchema(t *testing.T) {
+	_, mock, err := sqlmock.NewWithDSN("sqlmock_check_schema")
+	require.NoError(t, err)
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "nodes" WHERE 1 = 0`)).
+		WillReturnRows(sqlmock.NewRowsWithColumnDefinition(
+			sqlmock.NewColumn("id").OfType("TEXT", "").Nullable(false),
+			sqlmock.NewColumn("active").OfType("INTEGER", int64(0)).Nullable(false),
+			sqlmock.NewColumn("plan").OfType("TEXT", "").Nullable(true),
+		))
+
+	hub := schemaTestHub("sqlmock_check_schema")
+	scheme := runtime.NewScheme()
+	require.NoError(t, lynqv1.AddToScheme(scheme))
+	recorder := record.NewFakeRecorder(10)
+	r := &LynqHubReconciler{Client: fake.NewClientBuilder().WithScheme(scheme).Build(), Scheme: scheme, Recorder: recorder}
+
+	update := r.checkSchema(context.Background(), hub)
+	require.NotNil(t, update)
+	update(&hub.Status)
+	assert.NoError(t, mock.ExpectationsWereMet())
+
+	condition := meta.FindStatusCondition(hub.Status.Conditions, ConditionTypeSchemaValid)
+	require.NotNil(t, condition)
+	assert.Equal(t, metav1.ConditionFalse, condition.Status)
+	assert.Equal(t, "column `tenant_plan` not found in `nodes` (extraValueMappings.plan)", condition.Message)
+	assert.Contains(t, <-recorder.Events, "SchemaMismatch")
+
+	// Switching to a non-SQL source clears the result
+	hub.Spec.Source = lynqv1.DataSource{Type: lynqv1.SourceTypeInline, Inline: &lynqv1.InlineSource{}}
+	update = r.checkSchema(context.Background(), hub)
+	require.NotNil(t, update)
+	update(&hub.Status)
+	assert.Nil(t, meta.FindStatusCondition(hub.Status.Conditions, ConditionTypeSchemaValid))
+	assert.Empty(t, hub.Status.SchemaIssues)
+	assert.Nil(t, r.checkSchema(context.Background(), hub))
+}
+
+func TestSchemaWarnings(t *testing.T) {
+	_, mock, err := sqlmock.NewWithDSN("sqlmock_schema_warnings")
+	require.NoError(t, err)
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "nodes" WHERE 1 = 0`)).
+		WillReturnRows(sqlmock.NewRowsWithColumnDefinition(
+			sqlmock.NewColumn("id").OfType("TEXT", "").Nullable(false),
+			sqlmock.NewColumn("active").OfType("INTEGER", int64(0)).Nullable(false),
+		))
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, lynqv1.AddToScheme(scheme))
+	r := &LynqHubReconciler{Client: fake.NewClientBuilder().WithScheme(scheme).Build(), Scheme: scheme}
+
+	warnings, err := r.SchemaWarnings(context.Background(), schemaTestHub("sqlmock_schema_warnings"))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"column `tenant_plan` not found in `nodes` (extraValueMappings.plan)"}, warnings)
+	assert.NoError(t, mock.ExpectationsWereMet())
+
+	_, err = r.SchemaWarnings(context.Background(), schemaTestHub("sqlmock_unknown_dsn"))
+	assert.Error(t, err, "unreachable database")
+}
@@ -0,0 +1,472 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	lynqv1 "github.com/k8s-lynq/lynq/api/v1"
+	"github.com/k8s-lynq/lynq/internal/datasource"
+)
+
+func TestRowSetHash(t *testing.T) {
+	rows := []datasource.NodeRow{
+		{UID: "acme", Activate: "1", Extra: map[string]string{"plan": "pro", "region": "eu"}},
+		{UID: "beta", Activate: "1", Extra: map[string]string{"plan": "free"}},
+	}
+	reordered := []datasource.NodeRow{rows[1], rows[0]}
+	changed := []datasource.NodeRow{rows[0], {UID: "beta", Activate: "1", Extra: map[string]string{"plan": "pro"}}}
+
+	hash := rowSetHash(rows)
+	assert.Len(t, hash, 16)
+	assert.Equal(t, hash, rowSetHash(reordered), "row order must not change the hash")
+	assert.NotEqual(t, hash, rowSetHash(changed))
+	assert.NotEqual(t, hash, rowSetHash(rows[:1]))
+	assert.Equal(t, "beta", rows[1].UID, "input rows must not be reordered")
+}
+
+func TestRecordSyncSnapshot(t *testing.T) {
+	start := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	status := &lynqv1.LynqHubStatus{}
+
+	// Failures before any successful sync
+	recordSyncFailure(status, start, errors.New("dial tcp: connection refused"))
+	require.NotNil(t, status.Snapshot)
+	assert.Equal(t, int32(1), status.Snapshot.ConsecutiveFailures)
+	assert.Equal(t, "dial tcp: connection refused", status.Snapshot.LastError)
+	assert.Nil(t, status.Snapshot.LastSuccessfulSyncTime)
+	condition := meta.FindStatusCondition(status.Conditions, ConditionTypeDataStale)
+	require.NotNil(t, condition)
+	assert.Equal(t, metav1.ConditionTrue, condition.Status)
+	assert.Contains(t, condition.Message, "no successful sync yet")
+
+	// Success resets failures and records the row set
+	recordSyncSuccess(status, start.Add(time.Minute), 3, "abc")
+	assert.Equal(t, int32(0), status.Snapshot.ConsecutiveFailures)
+	assert.Empty(t, status.Snapshot.LastError)
+	assert.Equal(t, int32(3), status.Snapshot.RowCount)
+	assert.Equal(t, "abc", status.Snapshot.Hash)
+	assert.True(t, status.Snapshot.LastSuccessfulSyncTime.Time.Equal(start.Add(time.Minute)))
+	assert.NotNil(t, status.Snapshot.LastFailureTime, "last failure time is kept as history")
+	condition = meta.FindStatusCondition(status.Conditions, ConditionTypeDataStale)
+	require.NotNil(t, condition)
+	assert.Equal(t, metav1.ConditionFalse, condition.Status)
+	assert.Equal(t, "DataCurrent", condition.Reason)
+
+	// Incremental syncs keep the previous hash
+	recordSyncSuccess(status, start.Add(2*time.Minute), 4, "")
+	assert.Equal(t, "abc", status.Snapshot.Hash)
+	assert.Equal(t, int32(4), status.Snapshot.RowCount)
+
+	// Failures keep the snapshot and report its age
+	recordSyncFailure(status, start.Add(12*time.Minute), errors.New(strings.Repeat("x", 2*maxSyncErrorLength)))
+	recordSyncFailure(status, start.Add(14*time.Minute), errors.New("timeout"))
+	assert.Equal(t, int32(2), status.Snapshot.ConsecutiveFailures)
+	assert.Equal(t, int32(4), status.Snapshot.RowCount)
+	assert.Equal(t, "timeout", status.Snapshot.LastError)
+	condition = meta.FindStatusCondition(status.Conditions, ConditionTypeDataStale)
+	require.NotNil(t, condition)
+	assert.Equal(t, metav1.ConditionTrue, condition.Status)
+	assert.Equal(t, "DatasourceUnavailable", condition.Reason)
+	assert.Equal(t, "Datasource unavailable for 2 consecutive syncs; serving data from 2025-01-01T12:02:00Z (12m0s old)",
+		condition.Message)
+
+	// Long errors are truncated
+	recordSyncFailure(status, start.Add(16*time.Minute), errors.New(strings.Repeat("x", 2*maxSyncErrorLength)))
+	assert.Len(t, status.Snapshot.LastError, maxSyncErrorLength+len("..."))
+}
+
+func TestFormSyncStatuses(t *testing.T) {
+	templates := []*lynqv1.LynqForm{
+		{ObjectMeta: metav1.ObjectMeta{Name: "web"}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "addons"}},
+	}
+	node := func(form string, ready metav1.ConditionStatus) lynqv1.LynqNode {
+		n := lynqv1.LynqNode{Spec: lynqv1.LynqNodeSpec{TemplateRef: form}}
+		if ready != "" {
+			n.Status.Conditions = []metav1.Condition{{Type: "Ready", Status: ready}}
+		}
+		return n
+	}
+	nodes := &lynqv1.LynqNodeList{Items: []lynqv1.LynqNode{
+		node("web", metav1.ConditionTrue),
+		node("web", metav1.ConditionFalse),
+		node("web", ""),
+		node("addons", metav1.ConditionTrue),
+		node("removed", metav1.ConditionFalse),
+	}}
+
+	forms := formSyncStatuses(templates, nodes, map[string]int32{"web": 4, "addons": 1}, map[string]int{"web": 1})
+	assert.Equal(t, []lynqv1.FormSyncStatus{
+		{Name: "addons", Desired: 1, Ready: 1},
+		{Name: "web", Desired: 4, Ready: 1, Failed: 1, Throttled: 1},
+	}, forms, "sorted by name; nodes of other forms and without a Ready condition are not counted")
+
+	assert.Nil(t, formSyncStatuses(nil, nodes, nil, nil))
+}
+
+func TestRecordLastSync(t *testing.T) {
+	start := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	status := &lynqv1.LynqHubStatus{}
+
+	recordLastSync(status, start, start.Add(1500*time.Millisecond+300*time.Microsecond), lynqv1.SyncChanges{Created: 2, Deleted: 1}, "")
+	assert.True(t, status.LastSyncTime.Time.Equal(start))
+	assert.Equal(t, 1500*time.Millisecond, status.LastSyncDuration.Duration)
+	assert.Equal(t, &lynqv1.SyncChanges{Created: 2, Deleted: 1}, status.LastSyncChanges)
+	assert.Empty(t, status.LastSyncError)
+
+	recordLastSync(status, start, start, lynqv1.SyncChanges{}, strings.Repeat("x", maxSyncErrorLength+1))
+	assert.Len(t, status.LastSyncError, maxSyncErrorLength+len("..."))
+}
+
+func TestSetHubStaleCondition(t *testing.T) {
+	status := &lynqv1.LynqFormStatus{}
+	hub := &lynqv1.LynqHub{ObjectMeta: metav1.ObjectMeta{Name: "billing"}}
+
+	// Hub without a DataStale condition yet
+	setHubStaleCondition(status, hub)
+	assert.Nil(t, meta.FindStatusCondition(status.Conditions, ConditionTypeHubStale))
+
+	recordSyncFailure(&hub.Status, time.Now(), errors.New("connection refused"))
+	setHubStaleCondition(status, hub)
+	condition := meta.FindStatusCondition(status.Conditions, ConditionTypeHubStale)
+	require.NotNil(t, condition)
+	assert.Equal(t, metav1.ConditionTrue, condition.Status)
+	assert.Equal(t, "DatasourceUnavailable", condition.Reason)
+	assert.Contains(t, condition.Message, "LynqHub 'billing': Datasource unavailable")
+
+	// Missing hub removes the condition
+	setHubStaleCondition(status, nil)
+	assert.Nil(t, meta.FindStatusCondition(status.Conditions, ConditionTypeHubStale))
+}
+
+func TestReconcileDatasourceUnavailable(t *testing.T) {
+	ctx := context.Background()
+	scheme := runtime.NewScheme()
+	require.NoError(t, lynqv1.AddToScheme(scheme))
+	require.NoError(t, corev1.AddToScheme(scheme))
+
+	hub := &lynqv1.LynqHub{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       "billing",
+			Namespace:  "default",
+			Finalizers: []string{FinalizerLynqHub},
+		},
+		Spec: lynqv1.LynqHubSpec{
+			Source: lynqv1.DataSource{
+				Type:         lynqv1.SourceTypeConfigMap,
+				SyncInterval: "1m",
+				ConfigMap:    &lynqv1.ConfigMapSource{Name: "tenants", Key: "rows.csv"},
+			},
+			ValueMappings: lynqv1.ValueMappings{UID: "id", Activate: "active"},
+		},
+	}
+	form := &lynqv1.LynqForm{
+		ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default"},
+		Spec:       lynqv1.LynqFormSpec{HubID: "billing"},
+	}
+	rows := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "tenants", Namespace: "default"},
+		Data:       map[string]string{"rows.csv": "id,active\nacme,1\nbeta,1\n"},
+	}
+
+	fakeClient := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(hub, form, rows).
+		WithStatusSubresource(&lynqv1.LynqHub{}).
+		Build()
+	r := &LynqHubReconciler{Client: fakeClient, Scheme: scheme, Recorder: record.NewFakeRecorder(100)}
+	req := ctrl.Request{NamespacedName: client.ObjectKeyFromObject(hub)}
+
+	// Successful sync records the snapshot
+	_, err := r.Reconcile(ctx, req)
+	require.NoError(t, err)
+
+	latest := &lynqv1.LynqHub{}
+	require.NoError(t, fakeClient.Get(ctx, req.NamespacedName, latest))
+	require.NotNil(t, latest.Status.Snapshot)
+	assert.Equal(t, int32(2), latest.Status.Snapshot.RowCount)
+	assert.NotEmpty(t, latest.Status.Snapshot.Hash)
+	assert.NotNil(t, latest.Status.Snapshot.LastSuccessfulSyncTime)
+	assert.Equal(t, int32(2), latest.Status.Desired)
+	lastSuccess := latest.Status.Snapshot.LastSuccessfulSyncTime
+	require.NotNil(t, latest.Status.LastSyncTime)
+	require.NotNil(t, latest.Status.LastSyncDuration)
+	assert.Equal(t, &lynqv1.SyncChanges{Created: 2}, latest.Status.LastSyncChanges)
+	assert.Empty(t, latest.Status.LastSyncError)
+	assert.Equal(t, []lynqv1.FormSyncStatus{{Name: "web", Desired: 2}}, latest.Status.Forms)
+
+	// Datasource becomes unavailable: nodes and the desired count are kept, staleness is reported
+	require.NoError(t, fakeClient.Delete(ctx, rows))
+	// Failures are retried on the hub's backoff, starting at syncInterval and doubling
+	for i, base := range []time.Duration{time.Minute, 2 * time.Minute} {
+		result, err := r.Reconcile(ctx, req)
+		require.NoError(t, err, "failure %d", i+1)
+		assert.GreaterOrEqual(t, result.RequeueAfter, base)
+		assert.LessOrEqual(t, result.RequeueAfter, base+time.Duration(float64(base)*retryJitter))
+	}
+
+	require.NoError(t, fakeClient.Get(ctx, req.NamespacedName, latest))
+	require.NotNil(t, latest.Status.Snapshot)
+	assert.Equal(t, int32(2), latest.Status.Snapshot.ConsecutiveFailures)
+	assert.Equal(t, int32(2), latest.Status.Snapshot.RowCount)
+	assert.Equal(t, lastSuccess, latest.Status.Snapshot.LastSuccessfulSyncTime)
+	assert.Contains(t, latest.Status.Snapshot.LastError, "tenants")
+	assert.Equal(t, int32(2), latest.Status.Desired)
+	assert.NotNil(t, latest.Status.Snapshot.NextRetryTime)
+	assert.Contains(t, latest.Status.LastSyncError, "tenants", "the last sync reports its error")
+	assert.Equal(t, &lynqv1.SyncChanges{}, latest.Status.LastSyncChanges)
+	assert.Equal(t, []lynqv1.FormSyncStatus{{Name: "web", Desired: 2}}, latest.Status.Forms, "per-form counts are kept")
+
+	nodes := &lynqv1.LynqNodeList{}
+	require.NoError(t, fakeClient.List(ctx, nodes))
+	assert.Len(t, nodes.Items, 2)
+
+	stale := meta.FindStatusCondition(latest.Status.Conditions, ConditionTypeDataStale)
+	require.NotNil(t, stale)
+	assert.Equal(t, metav1.ConditionTrue, stale.Status)
+	ready := meta.FindStatusCondition(latest.Status.Conditions, ConditionTypeReady)
+	require.NotNil(t, ready)
+	assert.Equal(t, metav1.ConditionFalse, ready.Status)
+
+	// Recovery clears the failures
+	rows.ResourceVersion = ""
+	require.NoError(t, fakeClient.Create(ctx, rows))
+	_, err = r.Reconcile(ctx, req)
+	require.NoError(t, err)
+
+	require.NoError(t, fakeClient.Get(ctx, req.NamespacedName, latest))
+	assert.Equal(t, int32(0), latest.Status.Snapshot.ConsecutiveFailures)
+	assert.Nil(t, latest.Status.Snapshot.NextRetryTime)
+	assert.Empty(t, latest.Status.LastSyncError)
+	assert.Equal(t, &lynqv1.SyncChanges{}, latest.Status.LastSyncChanges, "nothing changed")
+	stale = meta.FindStatusCondition(latest.Status.Conditions, ConditionTypeDataStale)
+	require.NotNil(t, stale)
+	assert.Equal(t, metav1.ConditionFalse, stale.Status)
+}
+
+func TestBackoffDelay(t *testing.T) {
+	tests := []struct {
+		name         string
+		syncInterval time.Duration
+		maxBackoff   time.Duration
+		failures     int32
+		want         time.Duration
+	}{
+		{"first failure waits syncInterval", 30 * time.Second, 10 * time.Minute, 1, 30 * time.Second},
+		{"doubles per failure", 30 * time.Second, 10 * time.Minute, 3, 2 * time.Minute},
+		{"capped at maxBackoff", 30 * time.Second, 10 * time.Minute, 10, 10 * time.Minute},
+		{"many failures do not overflow", 30 * time.Second, 10 * time.Minute, 1000, 10 * time.Minute},
+		{"never shorter than syncInterval", 5 * time.Minute, time.Minute, 4, 5 * time.Minute},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, backoffDelay(tt.syncInterval, tt.maxBackoff, tt.failures))
+		})
+	}
+}
+
+func TestNextSyncRetry(t *testing.T) {
+	hub := &lynqv1.LynqHub{Status: lynqv1.LynqHubStatus{Snapshot: &lynqv1.DataSnapshotStatus{ConsecutiveFailures: 3}}}
+
+	schedule := nextSyncRetry(hub, 30*time.Second)
+	assert.Equal(t, int32(4), schedule.failures)
+	assert.False(t, schedule.circuitOpen, "default threshold is 5")
+	assert.GreaterOrEqual(t, schedule.delay, 4*time.Minute)
+	assert.LessOrEqual(t, schedule.delay, 4*time.Minute+time.Duration(float64(4*time.Minute)*retryJitter))
+
+	// The fifth failure opens the circuit for the cooldown
+	threshold := int32(4)
+	hub.Spec.Retry = &lynqv1.SyncRetryPolicy{CircuitBreakerThreshold: &threshold, CircuitBreakerCooldown: "15m"}
+	schedule = nextSyncRetry(hub, 30*time.Second)
+	assert.True(t, schedule.circuitOpen)
+	assert.Equal(t, int32(4), schedule.threshold)
+	assert.GreaterOrEqual(t, schedule.delay, 15*time.Minute)
+
+	// Threshold 0 disables the circuit breaker
+	threshold = 0
+	hub.Spec.Retry.MaxBackoff = "1m"
+	schedule = nextSyncRetry(hub, 30*time.Second)
+	assert.False(t, schedule.circuitOpen)
+	assert.LessOrEqual(t, schedule.delay, time.Minute+time.Duration(float64(time.Minute)*retryJitter))
+}
+
+func TestRecordSyncRetry(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	status := &lynqv1.LynqHubStatus{}
+
+	// Below the threshold only the next retry is recorded
+	recordSyncFailure(status, now, errors.New("timeout"))
+	recordSyncRetry(status, now, syncRetry{delay: time.Minute, failures: 1, threshold: 2})
+	assert.True(t, status.Snapshot.NextRetryTime.Time.Equal(now.Add(time.Minute)))
+	assert.Nil(t, meta.FindStatusCondition(status.Conditions, ConditionTypeCircuitOpen))
+
+	// Reaching the threshold opens the circuit
+	recordSyncFailure(status, now, errors.New("timeout"))
+	recordSyncRetry(status, now, syncRetry{delay: 5 * time.Minute, failures: 2, threshold: 2, circuitOpen: true})
+	condition := meta.FindStatusCondition(status.Conditions, ConditionTypeCircuitOpen)
+	require.NotNil(t, condition)
+	assert.Equal(t, metav1.ConditionTrue, condition.Status)
+	assert.Equal(t, "FailureThresholdReached", condition.Reason)
+	assert.Equal(t, "2 consecutive failed syncs (threshold 2); the datasource is not queried until 2025-01-01T12:05:00Z",
+		condition.Message)
+
+	// A successful probe closes it
+	recordSyncSuccess(status, now.Add(5*time.Minute), 2, "abc")
+	assert.Nil(t, status.Snapshot.NextRetryTime)
+	condition = meta.FindStatusCondition(status.Conditions, ConditionTypeCircuitOpen)
+	require.NotNil(t, condition)
+	assert.Equal(t, metav1.ConditionFalse, condition.Status)
+	assert.Equal(t, "SyncSucceeded", condition.Reason)
+}
+
+func TestCircuitOpenFor(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	next := metav1.NewTime(now.Add(3 * time.Minute))
+	hub := &lynqv1.LynqHub{
+		ObjectMeta: metav1.ObjectMeta{Generation: 2},
+		Status: lynqv1.LynqHubStatus{
+			ObservedGeneration: 2,
+			Snapshot:           &lynqv1.DataSnapshotStatus{ConsecutiveFailures: 5, NextRetryTime: &next},
+		},
+	}
+	assert.Zero(t, circuitOpenFor(hub, now), "closed without the condition")
+
+	meta.SetStatusCondition(&hub.Status.Conditions, metav1.Condition{
+		Type: ConditionTypeCircuitOpen, Status: metav1.ConditionTrue, Reason: "FailureThresholdReached",
+	})
+	assert.Equal(t, 3*time.Minute, circuitOpenFor(hub, now))
+	assert.Zero(t, circuitOpenFor(hub, now.Add(4*time.Minute)), "cooldown elapsed: probe")
+
+	hub.Generation = 3
+	assert.Zero(t, circuitOpenFor(hub, now), "spec changes probe right away")
+}
+
+func TestReconcileCircuitBreaker(t *testing.T) {
+	ctx := context.Background()
+	scheme := runtime.NewScheme()
+	require.NoError(t, lynqv1.AddToScheme(scheme))
+	require.NoError(t, corev1.AddToScheme(scheme))
+
+	threshold := int32(2)
+	hub := &lynqv1.LynqHub{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       "billing",
+			Namespace:  "default",
+			Finalizers: []string{FinalizerLynqHub},
+		},
+		Spec: lynqv1.LynqHubSpec{
+			Source: lynqv1.DataSource{
+				Type:         lynqv1.SourceTypeConfigMap,
+				SyncInterval: "1m",
+				ConfigMap:    &lynqv1.ConfigMapSource{Name: "tenants", Key: "rows.csv"},
+			},
+			ValueMappings: lynqv1.ValueMappings{UID: "id", Activate: "active"},
+			Retry:         &lynqv1.SyncRetryPolicy{CircuitBreakerThreshold: &threshold, CircuitBreakerCooldown: "10m"},
+		},
+	}
+	form := &lynqv1.LynqForm{
+		ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default"},
+		Spec:       lynqv1.LynqFormSpec{HubID: "billing"},
+	}
+
+	fakeClient := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(hub, form).
+		WithStatusSubresource(&lynqv1.LynqHub{}).
+		Build()
+	recorder := record.NewFakeRecorder(100)
+	r := &LynqHubReconciler{Client: fakeClient, Scheme: scheme, Recorder: recorder}
+	req := ctrl.Request{NamespacedName: client.ObjectKeyFromObject(hub)}
+
+	// The ConfigMap is missing: the second failure opens the circuit
+	for i := 0; i < 2; i++ {
+		_, err := r.Reconcile(ctx, req)
+		require.NoError(t, err)
+	}
+	latest := &lynqv1.LynqHub{}
+	require.NoError(t, fakeClient.Get(ctx, req.NamespacedName, latest))
+	assert.True(t, meta.IsStatusConditionTrue(latest.Status.Conditions, ConditionTypeCircuitOpen))
+	assert.Equal(t, int32(2), latest.Status.Snapshot.ConsecutiveFailures)
+
+	// While open, reconciles do not query the datasource
+	result, err := r.Reconcile(ctx, req)
+	require.NoError(t, err)
+	assert.Greater(t, result.RequeueAfter, 9*time.Minute)
+	require.NoError(t, fakeClient.Get(ctx, req.NamespacedName, latest))
+	assert.Equal(t, int32(2), latest.Status.Snapshot.ConsecutiveFailures, "no query while the circuit is open")
+
+	// A spec change probes right away; the fixed source closes the circuit
+	require.NoError(t, fakeClient.Create(ctx, &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "tenants", Namespace: "default"},
+		Data:       map[string]string{"rows.csv": "id,active\nacme,1\n"},
+	}))
+	latest.Spec.Source.SyncInterval = "2m"
+	latest.Generation++
+	require.NoError(t, fakeClient.Update(ctx, latest))
+	result, err = r.Reconcile(ctx, req)
+	require.NoError(t, err)
+	assert.Equal(t, 2*time.Minute, result.RequeueAfter)
+
+	require.NoError(t, fakeClient.Get(ctx, req.NamespacedName, latest))
+	condition := meta.FindStatusCondition(latest.Status.Conditions, ConditionTypeCircuitOpen)
+	require.NotNil(t, condition)
+	assert.Equal(t, metav1.ConditionFalse, condition.Status)
+	assert.Equal(t, int32(0), latest.Status.Snapshot.ConsecutiveFailures)
+
+	var events []string
+	for len(recorder.Events) > 0 {
+		events = append(events, <-recorder.Events)
+	}
+	assert.Contains(t, strings.Join(events, "\n"), "CircuitOpened")
+	assert.Contains(t, strings.Join(events, "\n"), "CircuitClosed")
+}
+
+func TestQueryTimeoutError(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Nanosecond)
+	defer cancel()
+	<-ctx.Done()
+
+	err := queryTimeoutError(ctx, 30*time.Second, ctx.Err())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "query did not finish within 30s (source.queryTimeout)")
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+	assert.NoError(t, queryTimeoutError(ctx, 30*time.Second, nil))
+	other := errors.New("access denied")
+	assert.Equal(t, other, queryTimeoutError(context.Background(), 30*time.Second, other))
+
+	hub := &lynqv1.LynqHub{}
+	assert.Equal(t, defaultQueryTimeout, queryTimeout(hub))
+	hub.Spec.Source.QueryTimeout = "5s"
+	assert.Equal(t, 5*time.Second, queryTimeout(hub))
+}
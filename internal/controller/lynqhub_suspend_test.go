@@ -0,0 +1,213 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	lynqv1 "github.com/k8s-lynq/lynq/api/v1"
+)
+
+func TestSetFormSuspendedCondition(t *testing.T) {
+	form := &lynqv1.LynqForm{Spec: lynqv1.LynqFormSpec{HubID: "billing"}}
+	hub := &lynqv1.LynqHub{ObjectMeta: metav1.ObjectMeta{Name: "billing"}}
+	status := &lynqv1.LynqFormStatus{}
+
+	setFormSuspendedCondition(status, form, hub)
+	assert.Nil(t, meta.FindStatusCondition(status.Conditions, ConditionTypeSuspended), "added once suspended")
+
+	hub.Spec.Suspend = true
+	setFormSuspendedCondition(status, form, hub)
+	condition := meta.FindStatusCondition(status.Conditions, ConditionTypeSuspended)
+	require.NotNil(t, condition)
+	assert.Equal(t, metav1.ConditionTrue, condition.Status)
+	assert.Equal(t, "HubSuspended", condition.Reason)
+
+	form.Spec.Suspend = true
+	setFormSuspendedCondition(status, form, hub)
+	assert.Equal(t, "Suspended", meta.FindStatusCondition(status.Conditions, ConditionTypeSuspended).Reason)
+
+	form.Spec.Suspend = false
+	hub.Spec.Suspend = false
+	setFormSuspendedCondition(status, form, hub)
+	condition = meta.FindStatusCondition(status.Conditions, ConditionTypeSuspended)
+	assert.Equal(t, metav1.ConditionFalse, condition.Status)
+	assert.Equal(t, "Resumed", condition.Reason)
+}
+
+func TestReconcileSuspend(t *testing.T) {
+	ctx := context.Background()
+	scheme := runtime.NewScheme()
+	require.NoError(t, lynqv1.AddToScheme(scheme))
+	require.NoError(t, corev1.AddToScheme(scheme))
+
+	hub := &lynqv1.LynqHub{
+		ObjectMeta: metav1.ObjectMeta{Name: "billing", Namespace: "default", Finalizers: []string{FinalizerLynqHub}},
+		Spec: lynqv1.LynqHubSpec{
+			Source: lynqv1.DataSource{
+				Type:         lynqv1.SourceTypeConfigMap,
+				SyncInterval: "1m",
+				ConfigMap:    &lynqv1.ConfigMapSource{Name: "tenants", Key: "rows.csv"},
+			},
+			ValueMappings: lynqv1.ValueMappings{UID: "id", Activate: "active"},
+		},
+	}
+	web := &lynqv1.LynqForm{
+		ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default"},
+		Spec:       lynqv1.LynqFormSpec{HubID: "billing"},
+	}
+	api := &lynqv1.LynqForm{
+		ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "default"},
+		Spec:       lynqv1.LynqFormSpec{HubID: "billing"},
+	}
+	rows := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "tenants", Namespace: "default"},
+		Data:       map[string]string{"rows.csv": "id,active\nacme,1\nbeta,1\n"},
+	}
+
+	sourceReads := 0
+	fakeClient := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(hub, web, api, rows).
+		WithStatusSubresource(&lynqv1.LynqHub{}).
+		WithInterceptorFuncs(interceptor.Funcs{
+			Get: func(ctx context.Context, c client.WithWatch, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+				if _, ok := obj.(*corev1.ConfigMap); ok && key.Name == "tenants" {
+					sourceReads++
+				}
+				return c.Get(ctx, key, obj, opts...)
+			},
+		}).
+		Build()
+	recorder := record.NewFakeRecorder(100)
+	r := &LynqHubReconciler{Client: fakeClient, Scheme: scheme, Recorder: recorder}
+	req := ctrl.Request{NamespacedName: client.ObjectKeyFromObject(hub)}
+
+	nodeKeys := func() []string {
+		nodes := &lynqv1.LynqNodeList{}
+		require.NoError(t, fakeClient.List(ctx, nodes))
+		keys := make([]string, 0, len(nodes.Items))
+		for _, node := range nodes.Items {
+			keys = append(keys, node.Spec.TemplateRef+"/"+node.Spec.UID)
+		}
+		sort.Strings(keys)
+		return keys
+	}
+	setSpec := func(obj client.Object, mutate func()) {
+		require.NoError(t, fakeClient.Get(ctx, client.ObjectKeyFromObject(obj), obj))
+		mutate()
+		require.NoError(t, fakeClient.Update(ctx, obj))
+	}
+
+	_, err := r.Reconcile(ctx, req)
+	require.NoError(t, err)
+	require.Equal(t, []string{"api/acme", "api/beta", "web/acme", "web/beta"}, nodeKeys())
+
+	// A suspended hub does not read its source or change nodes
+	setSpec(hub, func() { hub.Spec.Suspend = true })
+	setSpec(rows, func() { rows.Data["rows.csv"] = "id,active\nacme,1\ngamma,1\n" })
+	reads := sourceReads
+	result, err := r.Reconcile(ctx, req)
+	require.NoError(t, err)
+	assert.Equal(t, ctrl.Result{}, result, "no periodic sync while suspended")
+	assert.Equal(t, reads, sourceReads)
+	assert.Equal(t, []string{"api/acme", "api/beta", "web/acme", "web/beta"}, nodeKeys())
+
+	updated := &lynqv1.LynqHub{}
+	require.NoError(t, fakeClient.Get(ctx, req.NamespacedName, updated))
+	suspended := meta.FindStatusCondition(updated.Status.Conditions, ConditionTypeSuspended)
+	require.NotNil(t, suspended)
+	assert.Equal(t, metav1.ConditionTrue, suspended.Status)
+	assert.True(t, meta.IsStatusConditionTrue(updated.Status.Conditions, "Ready"), "Ready of the last sync is kept")
+	assert.Equal(t, int32(4), updated.Status.Desired)
+
+	// Resuming the hub syncs again, except for the nodes of the suspended form
+	setSpec(api, func() { api.Spec.Suspend = true })
+	setSpec(hub, func() { hub.Spec.Suspend = false })
+	_, err = r.Reconcile(ctx, req)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"api/acme", "api/beta", "web/acme", "web/gamma"}, nodeKeys())
+
+	require.NoError(t, fakeClient.Get(ctx, req.NamespacedName, updated))
+	suspended = meta.FindStatusCondition(updated.Status.Conditions, ConditionTypeSuspended)
+	require.NotNil(t, suspended)
+	assert.Equal(t, metav1.ConditionFalse, suspended.Status)
+	assert.Equal(t, "Resumed", suspended.Reason)
+	require.NotNil(t, updated.Status.LastSyncChanges)
+	assert.Equal(t, lynqv1.SyncChanges{Created: 1, Deleted: 1}, *updated.Status.LastSyncChanges)
+
+	var events []string
+	for len(recorder.Events) > 0 {
+		events = append(events, <-recorder.Events)
+	}
+	assert.Contains(t, events, "Normal Suspended Sync suspended; the datasource is not queried and LynqNodes are not changed")
+	assert.Contains(t, events, "Normal Resumed Sync resumed")
+}
+
+// TestUpdateStatusResumedEvent tests that Resumed is reported once, when the Suspended condition is cleared
+func TestUpdateStatusResumedEvent(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, lynqv1.AddToScheme(scheme))
+
+	hub := &lynqv1.LynqHub{
+		ObjectMeta: metav1.ObjectMeta{Name: "billing", Namespace: "default"},
+		Status: lynqv1.LynqHubStatus{Conditions: []metav1.Condition{{
+			Type:   ConditionTypeSuspended,
+			Status: metav1.ConditionTrue,
+			Reason: "Suspended",
+		}}},
+	}
+	failWrites := true
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).
+		WithObjects(hub).WithStatusSubresource(&lynqv1.LynqHub{}).
+		WithInterceptorFuncs(interceptor.Funcs{
+			SubResourceUpdate: func(ctx context.Context, c client.Client, subResourceName string, obj client.Object, opts ...client.SubResourceUpdateOption) error {
+				if failWrites {
+					return assert.AnError
+				}
+				return c.SubResource(subResourceName).Update(ctx, obj, opts...)
+			},
+		}).Build()
+	recorder := record.NewFakeRecorder(10)
+	r := &LynqHubReconciler{Client: fakeClient, Scheme: scheme, Recorder: recorder}
+	ctx := context.Background()
+
+	r.updateStatus(ctx, hub, 0, 0, 0, 0, true)
+	assert.Empty(t, recorder.Events, "the condition was not cleared")
+
+	failWrites = false
+	r.updateStatus(ctx, hub, 0, 0, 0, 0, true)
+	require.Len(t, recorder.Events, 1)
+	assert.Equal(t, "Normal Resumed Sync resumed", <-recorder.Events)
+
+	r.updateStatus(ctx, hub, 0, 0, 0, 0, true)
+	assert.Empty(t, recorder.Events, "already resumed")
+}
@@ -19,6 +19,7 @@ package controller
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -32,6 +33,7 @@ import (
 
 	lynqv1 "github.com/k8s-lynq/lynq/api/v1"
 	"github.com/k8s-lynq/lynq/internal/datasource"
+	"github.com/k8s-lynq/lynq/internal/template"
 )
 
 // TestGetExistingNodes tests the getExistingLynqNodes function
@@ -854,7 +856,7 @@ func TestUpdateNodeWithConflictHandling(t *testing.T) {
 	}
 
 	// Call updateLynqNode - should succeed with retry logic
-	err := r.updateLynqNode(ctx, registry, tmpl, node, row)
+	err := r.updateLynqNode(ctx, registry, template.NewEngine(), tmpl, node, row)
 	assert.NoError(t, err, "updateLynqNode should succeed even with potential conflicts")
 
 	// Verify node was updated
@@ -925,7 +927,7 @@ func TestCreateNodeIgnoresAlreadyExists(t *testing.T) {
 	}
 
 	// Call createLynqNode - should return AlreadyExists error
-	err := r.createLynqNode(ctx, registry, tmpl, row)
+	err := r.createLynqNode(ctx, registry, template.NewEngine(), tmpl, row)
 
 	// Verify that AlreadyExists error is returned (will be ignored by caller)
 	assert.Error(t, err, "createLynqNode should return error when node already exists")
@@ -1388,3 +1390,40 @@ func TestCanUpdateNode(t *testing.T) {
 		})
 	}
 }
+
+// TestUpdateStatusAppliesStatusUpdates tests that additional status updates are written with the counts
+func TestUpdateStatusAppliesStatusUpdates(t *testing.T) {
+	ctx := context.Background()
+	scheme := runtime.NewScheme()
+	require.NoError(t, lynqv1.AddToScheme(scheme))
+
+	registry := &lynqv1.LynqHub{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-registry",
+			Namespace: "default",
+		},
+	}
+
+	fakeClient := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(registry).
+		WithStatusSubresource(registry).
+		Build()
+
+	r := &LynqHubReconciler{
+		Client: fakeClient,
+		Scheme: scheme,
+	}
+
+	watermark := metav1.NewTime(time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC))
+	r.updateStatus(ctx, registry, 1, 3, 3, 0, true, func(status *lynqv1.LynqHubStatus) {
+		status.IncrementalSync = &lynqv1.IncrementalSyncStatus{Watermark: &watermark, Fingerprint: "fp"}
+	})
+
+	updated := &lynqv1.LynqHub{}
+	require.NoError(t, fakeClient.Get(ctx, types.NamespacedName{Name: registry.Name, Namespace: registry.Namespace}, updated))
+	assert.Equal(t, int32(3), updated.Status.Desired)
+	require.NotNil(t, updated.Status.IncrementalSync)
+	assert.Equal(t, "fp", updated.Status.IncrementalSync.Fingerprint)
+	assert.True(t, watermark.Equal(updated.Status.IncrementalSync.Watermark))
+}
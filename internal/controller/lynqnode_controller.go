@@ -18,7 +18,6 @@ package controller
 
 import (
 	"context"
-	"encoding/json"
 	errorsStd "errors"
 	"fmt"
 	"reflect"
@@ -27,6 +26,7 @@ import (
 	"time"
 
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -50,6 +50,7 @@ import (
 
 	lynqv1 "github.com/k8s-lynq/lynq/api/v1"
 	"github.com/k8s-lynq/lynq/internal/apply"
+	"github.com/k8s-lynq/lynq/internal/datasource"
 	"github.com/k8s-lynq/lynq/internal/graph"
 	"github.com/k8s-lynq/lynq/internal/metrics"
 	"github.com/k8s-lynq/lynq/internal/readiness"
@@ -132,6 +133,10 @@ const (
 	ConditionTypeConflicted  = "Conflicted"
 	ConditionTypeDegraded    = "Degraded"
 
+	// ConditionTypeSuspended is set on LynqHubs and LynqForms with spec.suspend, and on
+	// LynqNodes paused with the lynq.sh/paused annotation
+	ConditionTypeSuspended = "Suspended"
+
 	// Resource formatting
 	NoResourcesMessage = "no resources"
 
@@ -212,6 +217,18 @@ func (r *LynqNodeReconciler) Reconcile(ctx context.Context, req ctrl.Request) (c
 	// Determine reconcile type and use appropriate reconciliation path
 	reconcileType := r.determineReconcileType(node)
 
+	// A paused node keeps its resources as they are; adding the finalizer and cleanup still run
+	if reconcileType == ReconcileTypeSpec || reconcileType == ReconcileTypeStatus {
+		if node.Annotations[lynqv1.AnnotationPaused] == AnnotationValueTrue {
+			return r.reconcilePaused(ctx, node)
+		}
+		if meta.IsStatusConditionTrue(node.Status.Conditions, ConditionTypeSuspended) {
+			r.StatusManager.PublishCondition(node, ConditionTypeSuspended, metav1.ConditionFalse, "Resumed",
+				"Annotation "+lynqv1.AnnotationPaused+" removed; resources are applied again")
+			r.Recorder.Eventf(node, corev1.EventTypeNormal, "Resumed", "LynqNode resumed")
+		}
+	}
+
 	switch reconcileType {
 	case ReconcileTypeCleanup:
 		// LynqNode being deleted - handle cleanup
@@ -238,6 +255,19 @@ func (r *LynqNodeReconciler) Reconcile(ctx context.Context, req ctrl.Request) (c
 	}
 }
 
+// reconcilePaused reports a paused node without applying, checking or deleting any resource
+func (r *LynqNodeReconciler) reconcilePaused(ctx context.Context, node *lynqv1.LynqNode) (ctrl.Result, error) {
+	log.FromContext(ctx).V(1).Info("LynqNode paused, skipping apply", "node", node.Name)
+	if !meta.IsStatusConditionTrue(node.Status.Conditions, ConditionTypeSuspended) {
+		r.StatusManager.PublishCondition(node, ConditionTypeSuspended, metav1.ConditionTrue, "Paused",
+			"Annotation "+lynqv1.AnnotationPaused+"=true is set; resources are not applied")
+		r.Recorder.Eventf(node, corev1.EventTypeNormal, "Paused",
+			"LynqNode paused by annotation %s; resources are not applied", lynqv1.AnnotationPaused)
+	}
+	// The annotation change that resumes the node triggers the next reconcile
+	return ctrl.Result{}, nil
+}
+
 // applyResources applies all resources and returns counts for ready, failed, changed, conflicted, and skipped resources
 // skippedIds contains the IDs of resources that were skipped due to dependency failures
 //
@@ -755,12 +785,9 @@ func (r *LynqNodeReconciler) buildTemplateVariablesFromAnnotations(node *lynqv1.
 	}
 
 	// Parse extra values from JSON
-	extraJSON := node.Annotations["lynq.sh/extra"]
-	extraValues := make(map[string]string)
-	if extraJSON != "" {
-		if err := json.Unmarshal([]byte(extraJSON), &extraValues); err != nil {
-			return nil, fmt.Errorf("failed to unmarshal extra values: %w", err)
-		}
+	extraValues, err := decodeExtraValues(node.Annotations["lynq.sh/extra"])
+	if err != nil {
+		return nil, err
 	}
 
 	vars := template.BuildVariables(node.Spec.UID, hostOrURL, activate, extraValues)
@@ -778,6 +805,25 @@ func (r *LynqNodeReconciler) buildTemplateVariablesFromAnnotations(node *lynqv1.
 	return vars, nil
 }
 
+// decodeExtraValues decodes the lynq.sh/extra annotation
+// Strings stay strings; typed values written by the hub controller come back as
+// int64 (whole JSON numbers), float64, bool or, for json values, nested maps and lists.
+func decodeExtraValues(extraJSON string) (map[string]interface{}, error) {
+	if extraJSON == "" {
+		return make(map[string]interface{}), nil
+	}
+
+	decoded, err := datasource.DecodeJSON(extraJSON)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal extra values: %w", err)
+	}
+	extraValues, ok := decoded.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("failed to unmarshal extra values: expected a JSON object")
+	}
+	return extraValues, nil
+}
+
 // collectResourcesFromLynqNode collects all resources from LynqNode.Spec
 func (r *LynqNodeReconciler) collectResourcesFromLynqNode(node *lynqv1.LynqNode) []lynqv1.TResource {
 	var resources []lynqv1.TResource
@@ -921,8 +967,8 @@ func (r *LynqNodeReconciler) renderUnstructured(
 
 		switch val := v.(type) {
 		case string:
-			// Try to render as template
-			rendered, err := engine.Render(val, vars)
+			// Try to render as template (single-action templates keep their result type)
+			rendered, err := engine.RenderTyped(val, vars)
 			if err != nil {
 				// Return error to mark resource as failed (e.g., missing variable reference)
 				return nil, fmt.Errorf("template rendering failed for field %q: %w", k, err)
@@ -950,7 +996,7 @@ func (r *LynqNodeReconciler) renderUnstructured(
 					}
 					renderedArray[i] = rendered
 				} else if itemStr, ok := item.(string); ok {
-					rendered, err := engine.Render(itemStr, vars)
+					rendered, err := engine.RenderTyped(itemStr, vars)
 					if err != nil {
 						return nil, fmt.Errorf("template rendering failed for array string %q[%d]: %w", k, i, err)
 					}
@@ -981,7 +1027,11 @@ func shouldKeepString(resourceKind string, fieldPath []string) bool {
 	}
 
 	currentKey := fieldPath[len(fieldPath)-1]
-	if currentKey == "apiVersion" || currentKey == "kind" {
+	switch currentKey {
+	case "apiVersion", "kind":
+		return true
+	case "args", "command":
+		// Container arguments are string lists even when a typed variable renders them
 		return true
 	}
 
@@ -993,6 +1043,9 @@ func shouldKeepString(resourceKind string, fieldPath []string) bool {
 	switch parentKey {
 	case "annotations", "labels", "matchLabels", "nodeSelector":
 		return true
+	case "env":
+		// Environment variable values are always strings
+		return currentKey == "value"
 	case "data":
 		return resourceKind == resourceKindConfigMap || resourceKind == resourceKindSecret
 	case "binaryData", "stringData":
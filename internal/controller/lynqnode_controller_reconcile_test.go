@@ -23,6 +23,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -371,3 +372,69 @@ func TestCleanupNodeResources(t *testing.T) {
 		})
 	}
 }
+
+// TestReconcile_PausedNode tests that a paused node is not applied until the annotation is removed
+func TestReconcile_PausedNode(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, lynqv1.AddToScheme(scheme))
+	require.NoError(t, corev1.AddToScheme(scheme))
+
+	node := &lynqv1.LynqNode{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       "test-node",
+			Namespace:  "default",
+			Generation: 2,
+			Finalizers: []string{LynqNodeFinalizer},
+			Annotations: map[string]string{
+				"lynq.sh/hostOrUrl":     "https://example.com",
+				"lynq.sh/activate":      "true",
+				lynqv1.AnnotationPaused: "true",
+			},
+		},
+		Spec: lynqv1.LynqNodeSpec{
+			UID:         "test-uid",
+			TemplateRef: "test-template",
+		},
+		Status: lynqv1.LynqNodeStatus{ObservedGeneration: 1},
+	}
+	fakeClient := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(node).
+		WithStatusSubresource(node).
+		Build()
+	recorder := record.NewFakeRecorder(100)
+	r := &LynqNodeReconciler{
+		Client:        fakeClient,
+		Scheme:        scheme,
+		Recorder:      recorder,
+		StatusManager: status.NewManager(fakeClient, status.WithSyncMode()),
+	}
+	ctx := context.Background()
+	req := ctrl.Request{NamespacedName: client.ObjectKeyFromObject(node)}
+
+	result, err := r.Reconcile(ctx, req)
+	require.NoError(t, err)
+	assert.Equal(t, ctrl.Result{}, result, "the annotation change triggers the next reconcile")
+
+	updated := &lynqv1.LynqNode{}
+	require.NoError(t, fakeClient.Get(ctx, req.NamespacedName, updated))
+	assert.Equal(t, int64(1), updated.Status.ObservedGeneration, "the new generation is not applied")
+	suspended := meta.FindStatusCondition(updated.Status.Conditions, ConditionTypeSuspended)
+	require.NotNil(t, suspended)
+	assert.Equal(t, metav1.ConditionTrue, suspended.Status)
+	assert.Equal(t, "Paused", suspended.Reason)
+	assert.Contains(t, <-recorder.Events, "Paused")
+
+	// Removing the annotation resumes the node
+	delete(updated.Annotations, lynqv1.AnnotationPaused)
+	require.NoError(t, fakeClient.Update(ctx, updated))
+	_, err = r.Reconcile(ctx, req)
+	require.NoError(t, err)
+
+	require.NoError(t, fakeClient.Get(ctx, req.NamespacedName, updated))
+	assert.Equal(t, updated.Generation, updated.Status.ObservedGeneration)
+	suspended = meta.FindStatusCondition(updated.Status.Conditions, ConditionTypeSuspended)
+	require.NotNil(t, suspended)
+	assert.Equal(t, metav1.ConditionFalse, suspended.Status)
+	assert.Equal(t, "Resumed", suspended.Reason)
+}
@@ -41,7 +41,7 @@ func TestBuildTemplateVariablesFromAnnotations(t *testing.T) {
 		wantUID      string
 		wantHost     string
 		wantActivate string
-		wantExtra    map[string]string
+		wantExtra    map[string]interface{}
 		wantErr      bool
 	}{
 		{
@@ -61,12 +61,49 @@ func TestBuildTemplateVariablesFromAnnotations(t *testing.T) {
 			wantUID:      "node-123",
 			wantHost:     "https://example.com",
 			wantActivate: "true",
-			wantExtra: map[string]string{
+			wantExtra: map[string]interface{}{
 				"region": "us-west-2",
 				"plan":   "premium",
 			},
 			wantErr: false,
 		},
+		{
+			name: "typed extra values",
+			node: &lynqv1.LynqNode{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						"lynq.sh/extra": `{"replicas":3,"cpu":0.5,"ha":true,"port":"8080","settings":{"seats":10,"regions":["eu"]}}`,
+					},
+				},
+				Spec: lynqv1.LynqNodeSpec{
+					UID: "node-typed",
+				},
+			},
+			wantUID:      "node-typed",
+			wantHost:     "node-typed",
+			wantActivate: "true",
+			wantExtra: map[string]interface{}{
+				"replicas": int64(3),
+				"cpu":      0.5,
+				"ha":       true,
+				"port":     "8080",
+				"settings": map[string]interface{}{"seats": int64(10), "regions": []interface{}{"eu"}},
+			},
+		},
+		{
+			name: "extra annotation is not an object",
+			node: &lynqv1.LynqNode{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						"lynq.sh/extra": `["a"]`,
+					},
+				},
+				Spec: lynqv1.LynqNodeSpec{
+					UID: "node-list",
+				},
+			},
+			wantErr: true,
+		},
 		{
 			name: "missing hostOrUrl defaults to UID",
 			node: &lynqv1.LynqNode{
@@ -82,7 +119,7 @@ func TestBuildTemplateVariablesFromAnnotations(t *testing.T) {
 			wantUID:      "node-456",
 			wantHost:     "node-456",
 			wantActivate: "1",
-			wantExtra:    map[string]string{},
+			wantExtra:    map[string]interface{}{},
 			wantErr:      false,
 		},
 		{
@@ -100,7 +137,7 @@ func TestBuildTemplateVariablesFromAnnotations(t *testing.T) {
 			wantUID:      "node-789",
 			wantHost:     "https://tenant.example.com",
 			wantActivate: "true",
-			wantExtra:    map[string]string{},
+			wantExtra:    map[string]interface{}{},
 			wantErr:      false,
 		},
 		{
@@ -549,7 +586,7 @@ func TestRenderUnstructured_NoTemplates(t *testing.T) {
 	}
 	ctx := context.Background()
 	engine := template.NewEngine()
-	vars := template.BuildVariables("test-uid", "https://example.com", "true", map[string]string{})
+	vars := template.BuildVariables("test-uid", "https://example.com", "true", map[string]interface{}{})
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -719,6 +756,87 @@ func TestRenderUnstructured_TypedFunctions(t *testing.T) {
 	}
 }
 
+func TestRenderUnstructured_TypedVariables(t *testing.T) {
+	scheme := runtime.NewScheme()
+	r := &LynqNodeReconciler{
+		Client: fake.NewClientBuilder().WithScheme(scheme).Build(),
+		Scheme: scheme,
+	}
+	vars := template.Variables{"replicas": int64(3), "port": int64(8080), "debug": true}
+
+	data := map[string]interface{}{
+		"spec": map[string]interface{}{
+			"replicas": "{{ .replicas }}",
+			"template": map[string]interface{}{
+				"metadata": map[string]interface{}{
+					"labels": map[string]interface{}{"replicas": "{{ .replicas }}"},
+				},
+				"containers": []interface{}{
+					map[string]interface{}{
+						"args":  []interface{}{"--port", "{{ .port }}"},
+						"ports": []interface{}{map[string]interface{}{"containerPort": "{{ .port }}"}},
+						"env": []interface{}{
+							map[string]interface{}{"name": "DEBUG", "value": "{{ .debug }}"},
+						},
+					},
+				},
+			},
+			"description": "{{ .replicas }} replicas",
+		},
+	}
+
+	got, err := r.renderUnstructured(context.Background(), data, template.NewEngine(), vars, "Deployment", nil)
+	require.NoError(t, err)
+
+	spec := got["spec"].(map[string]interface{})
+	assert.Equal(t, int64(3), spec["replicas"])
+	assert.Equal(t, "3 replicas", spec["description"])
+	podTemplate := spec["template"].(map[string]interface{})
+	labels := podTemplate["metadata"].(map[string]interface{})["labels"].(map[string]interface{})
+	assert.Equal(t, "3", labels["replicas"])
+	container := podTemplate["containers"].([]interface{})[0].(map[string]interface{})
+	assert.Equal(t, []interface{}{"--port", "8080"}, container["args"])
+	assert.Equal(t, int64(8080), container["ports"].([]interface{})[0].(map[string]interface{})["containerPort"])
+	assert.Equal(t, "true", container["env"].([]interface{})[0].(map[string]interface{})["value"])
+}
+
+// TestRenderUnstructuredComputedValuesStayStrings tests that only typed row variables change
+// type: computed results in ordinary string fields render as before
+func TestRenderUnstructuredComputedValuesStayStrings(t *testing.T) {
+	scheme := runtime.NewScheme()
+	r := &LynqNodeReconciler{
+		Client: fake.NewClientBuilder().WithScheme(scheme).Build(),
+		Scheme: scheme,
+	}
+	vars := template.Variables{"uid": "acme", "plan": "pro"}
+
+	data := map[string]interface{}{
+		"spec": map[string]interface{}{
+			"hostname": "{{ add 1 2 }}",
+			"template": map[string]interface{}{
+				"spec": map[string]interface{}{
+					"subdomain": "{{ len .uid }}",
+					"containers": []interface{}{
+						map[string]interface{}{
+							"ports": []interface{}{map[string]interface{}{"name": "{{ eq .plan \"pro\" }}"}},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	got, err := r.renderUnstructured(context.Background(), data, template.NewEngine(), vars, "Deployment", nil)
+	require.NoError(t, err)
+
+	spec := got["spec"].(map[string]interface{})
+	assert.Equal(t, "3", spec["hostname"])
+	podSpec := spec["template"].(map[string]interface{})["spec"].(map[string]interface{})
+	assert.Equal(t, "4", podSpec["subdomain"])
+	container := podSpec["containers"].([]interface{})[0].(map[string]interface{})
+	assert.Equal(t, "true", container["ports"].([]interface{})[0].(map[string]interface{})["name"])
+}
+
 // TestCheckOnceCreated tests "created-once" annotation check
 func TestCheckOnceCreated(t *testing.T) {
 	tests := []struct {
@@ -0,0 +1,155 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package datasource
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// Cache keeps datasource adapters open across syncs so that connection pools are reused
+// instead of being re-established on every reconcile.
+//
+// Entries are keyed by owner (e.g. "{namespace}/{name}/{UID}" of a LynqHub) and remember a hash
+// of the type and the fully resolved Config, including credentials and TLS material. A changed
+// spec or a rotated Secret therefore yields a different hash, and the stale adapter is closed
+// and replaced.
+//
+// Adapters returned by Get are shared; callers must not Close them.
+type Cache struct {
+	mu      sync.Mutex
+	entries map[string]*cacheEntry
+
+	// newDatasource creates adapters; replaced in tests
+	newDatasource func(SourceType, Config) (Datasource, error)
+}
+
+type cacheEntry struct {
+	hash string
+	ds   Datasource
+}
+
+// NewCache creates an empty datasource cache
+func NewCache() *Cache {
+	return &Cache{
+		entries:       make(map[string]*cacheEntry),
+		newDatasource: NewDatasource,
+	}
+}
+
+// Get returns the cached adapter for key if it was created from the same type and config;
+// otherwise it closes any stale adapter and creates a new one.
+// Calls for the same key must not run concurrently (controllers reconcile one object at a time).
+func (c *Cache) Get(key string, sourceType SourceType, config Config) (Datasource, error) {
+	hash, err := ConfigHash(sourceType, config)
+	if err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	if entry, ok := c.entries[key]; ok && entry.hash == hash {
+		c.mu.Unlock()
+		return entry.ds, nil
+	}
+	c.mu.Unlock()
+
+	// Evict before connecting so a changed config never leaves the old adapter behind,
+	// even when the new connection fails
+	c.Evict(key)
+
+	// Connect outside the lock; adapters ping the server, which must not block other keys
+	ds, err := c.newDatasource(sourceType, config)
+	if err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries[key] = &cacheEntry{hash: hash, ds: ds}
+	return ds, nil
+}
+
+// Evict closes and removes the adapter for key, if any
+func (c *Cache) Evict(key string) {
+	c.mu.Lock()
+	entry, ok := c.entries[key]
+	delete(c.entries, key)
+	c.mu.Unlock()
+
+	if ok {
+		_ = entry.ds.Close() // Best effort close
+	}
+}
+
+// EvictOwner closes and removes the adapter for owner and every adapter keyed "{owner}/..."
+// (the additional sources of the owner)
+func (c *Cache) EvictOwner(owner string) {
+	c.mu.Lock()
+	var evicted []*cacheEntry
+	for key, entry := range c.entries {
+		if key == owner || strings.HasPrefix(key, owner+"/") {
+			evicted = append(evicted, entry)
+			delete(c.entries, key)
+		}
+	}
+	c.mu.Unlock()
+
+	for _, entry := range evicted {
+		_ = entry.ds.Close() // Best effort close
+	}
+}
+
+// Len returns the number of open adapters
+func (c *Cache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.entries)
+}
+
+// Close closes and removes all adapters
+func (c *Cache) Close() error {
+	c.mu.Lock()
+	entries := c.entries
+	c.entries = make(map[string]*cacheEntry)
+	c.mu.Unlock()
+
+	var errs []error
+	for key, entry := range entries {
+		if err := entry.ds.Close(); err != nil {
+			errs = append(errs, fmt.Errorf("failed to close datasource %s: %w", key, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// ConfigHash returns a stable hash of the source type and config
+func ConfigHash(sourceType SourceType, config Config) (string, error) {
+	data, err := json.Marshal(struct {
+		Type   SourceType
+		Config Config
+	}{sourceType, config})
+	if err != nil {
+		return "", fmt.Errorf("failed to hash datasource config: %w", err)
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), nil
+}
@@ -0,0 +1,172 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package datasource
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeDatasource records whether it was closed
+type fakeDatasource struct {
+	closed   bool
+	closeErr error
+}
+
+func (f *fakeDatasource) QueryNodes(context.Context, QueryConfig) ([]NodeRow, error) {
+	return nil, nil
+}
+
+func (f *fakeDatasource) Close() error {
+	f.closed = true
+	return f.closeErr
+}
+
+func newFakeCache() (*Cache, *[]*fakeDatasource) {
+	var created []*fakeDatasource
+	cache := NewCache()
+	cache.newDatasource = func(SourceType, Config) (Datasource, error) {
+		ds := &fakeDatasource{}
+		created = append(created, ds)
+		return ds, nil
+	}
+	return cache, &created
+}
+
+func TestCache_Get(t *testing.T) {
+	config := Config{Host: "db", Port: 3306, Username: "reader", Password: "old"}
+
+	t.Run("reuses adapter for unchanged config", func(t *testing.T) {
+		cache, created := newFakeCache()
+
+		first, err := cache.Get("hub-a", SourceTypeMySQL, config)
+		require.NoError(t, err)
+		second, err := cache.Get("hub-a", SourceTypeMySQL, config)
+		require.NoError(t, err)
+
+		assert.Same(t, first, second)
+		assert.Len(t, *created, 1)
+		assert.Equal(t, 1, cache.Len())
+	})
+
+	t.Run("replaces adapter when credentials rotate", func(t *testing.T) {
+		cache, created := newFakeCache()
+
+		first, err := cache.Get("hub-a", SourceTypeMySQL, config)
+		require.NoError(t, err)
+
+		rotated := config
+		rotated.Password = "new"
+		second, err := cache.Get("hub-a", SourceTypeMySQL, rotated)
+		require.NoError(t, err)
+
+		assert.NotSame(t, first, second)
+		assert.True(t, (*created)[0].closed, "stale adapter must be closed")
+		assert.False(t, (*created)[1].closed)
+		assert.Equal(t, 1, cache.Len())
+	})
+
+	t.Run("keys are independent", func(t *testing.T) {
+		cache, created := newFakeCache()
+
+		_, err := cache.Get("hub-a", SourceTypeMySQL, config)
+		require.NoError(t, err)
+		_, err = cache.Get("hub-b", SourceTypeMySQL, config)
+		require.NoError(t, err)
+
+		assert.Len(t, *created, 2)
+		assert.Equal(t, 2, cache.Len())
+	})
+
+	t.Run("failed connection evicts stale adapter", func(t *testing.T) {
+		cache, created := newFakeCache()
+		_, err := cache.Get("hub-a", SourceTypeMySQL, config)
+		require.NoError(t, err)
+
+		cache.newDatasource = func(SourceType, Config) (Datasource, error) {
+			return nil, errors.New("connection refused")
+		}
+		changed := config
+		changed.Host = "db2"
+		_, err = cache.Get("hub-a", SourceTypeMySQL, changed)
+		require.Error(t, err)
+
+		assert.True(t, (*created)[0].closed)
+		assert.Equal(t, 0, cache.Len())
+	})
+}
+
+func TestCache_EvictAndClose(t *testing.T) {
+	cache, created := newFakeCache()
+	config := Config{Host: "db"}
+
+	_, err := cache.Get("hub-a", SourceTypeMySQL, config)
+	require.NoError(t, err)
+	_, err = cache.Get("hub-b", SourceTypeMySQL, config)
+	require.NoError(t, err)
+
+	cache.Evict("hub-a")
+	cache.Evict("unknown")
+	assert.True(t, (*created)[0].closed)
+	assert.False(t, (*created)[1].closed)
+	assert.Equal(t, 1, cache.Len())
+
+	(*created)[1].closeErr = errors.New("boom")
+	err = cache.Close()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to close datasource hub-b")
+	assert.True(t, (*created)[1].closed)
+	assert.Equal(t, 0, cache.Len())
+}
+
+func TestCache_EvictOwner(t *testing.T) {
+	cache, created := newFakeCache()
+	for _, key := range []string{"hub-a", "hub-a/billing", "hub-ab"} {
+		_, err := cache.Get(key, SourceTypeMySQL, Config{Host: "db"})
+		require.NoError(t, err)
+	}
+
+	cache.EvictOwner("hub-a")
+	assert.True(t, (*created)[0].closed)
+	assert.True(t, (*created)[1].closed)
+	assert.False(t, (*created)[2].closed, "other owners sharing a prefix are kept")
+	assert.Equal(t, 1, cache.Len())
+}
+
+func TestConfigHash(t *testing.T) {
+	config := Config{Host: "db", Params: map[string]string{"a": "1", "b": "2"}}
+
+	first, err := ConfigHash(SourceTypeMySQL, config)
+	require.NoError(t, err)
+	second, err := ConfigHash(SourceTypeMySQL, Config{Host: "db", Params: map[string]string{"b": "2", "a": "1"}})
+	require.NoError(t, err)
+	assert.Equal(t, first, second, "map order must not affect the hash")
+
+	other, err := ConfigHash(SourceTypePostgreSQL, config)
+	require.NoError(t, err)
+	assert.NotEqual(t, first, other)
+
+	withCA := config
+	withCA.CACert = "pem"
+	changed, err := ConfigHash(SourceTypeMySQL, withCA)
+	require.NoError(t, err)
+	assert.NotEqual(t, first, changed)
+}
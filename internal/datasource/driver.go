@@ -0,0 +1,154 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package datasource
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net/url"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	_ "modernc.org/sqlite" // Registers the pure-Go "sqlite" driver, so hubs can read .db files
+)
+
+// SQLAdapter implements the Datasource interface for any registered database/sql driver
+// It shares the query building and scanning of the MySQL and PostgreSQL adapters; only the
+// dialect (identifier quoting and bind parameters) and the DSN differ.
+type SQLAdapter struct {
+	db      *sql.DB
+	dialect sqlDialect
+}
+
+// NewSQLAdapter creates a datasource adapter for the driver named in config.Driver
+func NewSQLAdapter(config Config) (*SQLAdapter, error) {
+	if !slices.Contains(sql.Drivers(), config.Driver) {
+		return nil, fmt.Errorf("sql driver %q is not registered (available: %s)", config.Driver, strings.Join(sql.Drivers(), ", "))
+	}
+	dialect, err := dialectFor(config.Driver, config.Dialect)
+	if err != nil {
+		return nil, err
+	}
+
+	db, err := sql.Open(config.Driver, buildSQLDSN(config.DSN, config.Params))
+	if err != nil {
+		return nil, fmt.Errorf("failed to open %s connection: %w", config.Driver, err)
+	}
+
+	// Set connection pool settings
+	configurePool(db, config)
+
+	// Test connection
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := db.PingContext(ctx); err != nil {
+		_ = db.Close() // Best effort close on error
+		return nil, fmt.Errorf("failed to ping %s: %w", config.Driver, err)
+	}
+
+	return &SQLAdapter{db: db, dialect: dialect}, nil
+}
+
+// QueryNodes queries active nodes from the database
+func (a *SQLAdapter) QueryNodes(ctx context.Context, config QueryConfig) ([]NodeRow, error) {
+	nodes, err := querySQLNodes(ctx, a.db, a.dialect, a.dialect.quoteIdentifier(config.Table), config)
+	if err != nil {
+		return nil, err
+	}
+	if err := loadSQLRelations(ctx, a.db, a.dialect, a.dialect.quoteIdentifier, config.Relations, nodes); err != nil {
+		return nil, err
+	}
+	return nodes, nil
+}
+
+// QueryChangedNodes queries rows changed since the given watermark (all rows when since is zero)
+func (a *SQLAdapter) QueryChangedNodes(ctx context.Context, config QueryConfig, since time.Time) (*ChangeSet, error) {
+	changes, err := queryChangedSQLNodes(ctx, a.db, a.dialect, a.dialect.quoteIdentifier(config.Table), config, since)
+	if err != nil {
+		return nil, err
+	}
+	if err := loadSQLRelations(ctx, a.db, a.dialect, a.dialect.quoteIdentifier, config.Relations, changes.Active); err != nil {
+		return nil, err
+	}
+	return changes, nil
+}
+
+// TableSchema reads the columns of table from the result set metadata of an empty query,
+// which works with any driver. Drivers that do not report nullability are treated as NOT NULL.
+func (a *SQLAdapter) TableSchema(ctx context.Context, table string) (*TableSchema, error) {
+	rows, err := a.db.QueryContext(ctx, fmt.Sprintf("SELECT * FROM %s WHERE 1 = 0", a.dialect.quoteIdentifier(table)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to query columns: %w", err)
+	}
+	defer func() {
+		_ = rows.Close() // Best effort close
+	}()
+
+	columnTypes, err := rows.ColumnTypes()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read column types: %w", err)
+	}
+	schema := &TableSchema{Table: table}
+	for _, columnType := range columnTypes {
+		nullable, _ := columnType.Nullable()
+		schema.Columns = append(schema.Columns, Column{
+			Name:     columnType.Name(),
+			Type:     columnType.DatabaseTypeName(),
+			Nullable: nullable,
+		})
+	}
+	return schema, nil
+}
+
+// Close closes the database connection
+func (a *SQLAdapter) Close() error {
+	if a.db != nil {
+		return a.db.Close()
+	}
+	return nil
+}
+
+// buildSQLDSN appends params to the DSN as URL query parameters, in sorted key order
+func buildSQLDSN(dsn string, params map[string]string) string {
+	if len(params) == 0 {
+		return dsn
+	}
+	keys := make([]string, 0, len(params))
+	for key := range params {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var b strings.Builder
+	b.WriteString(dsn)
+	separator := "?"
+	if strings.Contains(dsn, "?") {
+		separator = "&"
+	}
+	for _, key := range keys {
+		b.WriteString(separator)
+		b.WriteString(url.QueryEscape(key))
+		b.WriteString("=")
+		b.WriteString(url.QueryEscape(params[key]))
+		separator = "&"
+	}
+	return b.String()
+}
@@ -0,0 +1,177 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package datasource
+
+import (
+	"context"
+	"database/sql"
+	"path/filepath"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSQLAdapter_QueryNodes(t *testing.T) {
+	// The sqlmock driver is registered as "sqlmock"; the adapter opens the mock by its DSN
+	_, mock, err := sqlmock.NewWithDSN("file:tenants.db?_pragma=busy_timeout%285000%29&mode=ro")
+	require.NoError(t, err)
+
+	adapter, err := NewSQLAdapter(Config{
+		Driver:  "sqlmock",
+		Dialect: "sqlite",
+		DSN:     "file:tenants.db",
+		Params:  map[string]string{"mode": "ro", "_pragma": "busy_timeout(5000)"},
+	})
+	require.NoError(t, err)
+	defer func() { _ = adapter.Close() }()
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT "id", "active", "plan" FROM "tenants" WHERE "region" = ?`)).
+		WithArgs("eu").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "active", "plan"}).
+			AddRow("acme", "1", "pro").
+			AddRow("beta", "0", "free"))
+
+	rows, err := adapter.QueryNodes(context.Background(), QueryConfig{
+		Table:         "tenants",
+		ValueMappings: ValueMappings{UID: "id", Activate: "active"},
+		ExtraMappings: map[string]string{"plan": "plan"},
+		Filters:       []Filter{{Column: "region", Operator: "eq", Values: []string{"eu"}}},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []NodeRow{{UID: "acme", Activate: "1", Extra: map[string]string{"plan": "pro"}}}, rows)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSQLAdapter_QueryChangedNodes(t *testing.T) {
+	_, mock, err := sqlmock.NewWithDSN("sqlmock_sql_adapter_changed")
+	require.NoError(t, err)
+
+	adapter, err := NewSQLAdapter(Config{Driver: "sqlmock", Dialect: "postgresql", DSN: "sqlmock_sql_adapter_changed"})
+	require.NoError(t, err)
+	defer func() { _ = adapter.Close() }()
+
+	since := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	updated := since.Add(time.Hour)
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT "id", "active", "updated_at" FROM "tenants" WHERE "updated_at" >= $1`)).
+		WithArgs(since).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "active", "updated_at"}).
+			AddRow("acme", "1", updated).
+			AddRow("beta", "0", since))
+
+	changes, err := adapter.QueryChangedNodes(context.Background(), QueryConfig{
+		Table:           "tenants",
+		ValueMappings:   ValueMappings{UID: "id", Activate: "active"},
+		UpdatedAtColumn: "updated_at",
+	}, since)
+	require.NoError(t, err)
+	require.Len(t, changes.Active, 1)
+	assert.Equal(t, "acme", changes.Active[0].UID)
+	assert.Equal(t, []string{"beta"}, changes.InactiveUIDs)
+	assert.Equal(t, updated, changes.Watermark)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+// TestSQLAdapter_SQLiteFile tests that a sql source reads a .db file with the bundled sqlite driver
+func TestSQLAdapter_SQLiteFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "tenants.db")
+	db, err := sql.Open("sqlite", path)
+	require.NoError(t, err)
+	_, err = db.Exec(`CREATE TABLE tenants (id TEXT, active INTEGER, plan TEXT, region TEXT);
+		INSERT INTO tenants VALUES ('acme', 1, 'pro', 'eu'), ('beta', 0, 'free', 'eu'), ('gamma', 1, 'pro', 'us');`)
+	require.NoError(t, err)
+	require.NoError(t, db.Close())
+
+	ds, err := NewDatasource(SourceTypeSQL, Config{
+		Driver: "sqlite",
+		DSN:    "file:" + path,
+		Params: map[string]string{"mode": "ro"},
+	})
+	require.NoError(t, err)
+	defer func() { _ = ds.Close() }()
+
+	rows, err := ds.QueryNodes(context.Background(), QueryConfig{
+		Table:         "tenants",
+		ValueMappings: ValueMappings{UID: "id", Activate: "active"},
+		ExtraMappings: map[string]string{"plan": "plan"},
+		Filters:       []Filter{{Column: "region", Operator: "eq", Values: []string{"eu"}}},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []NodeRow{{UID: "acme", Activate: "1", Extra: map[string]string{"plan": "pro"}}}, rows)
+}
+
+func TestNewSQLAdapter_Errors(t *testing.T) {
+	tests := []struct {
+		name          string
+		config        Config
+		errorContains string
+	}{
+		{
+			name:          "unregistered driver",
+			config:        Config{Driver: "oracle", DSN: "oracle://db"},
+			errorContains: `sql driver "oracle" is not registered`,
+		},
+		{
+			name:          "unknown driver without dialect",
+			config:        Config{Driver: "sqlmock", DSN: "sqlmock_db_unused"},
+			errorContains: `dialect is required for driver "sqlmock"`,
+		},
+		{
+			name:          "unsupported dialect",
+			config:        Config{Driver: "sqlmock", Dialect: "oracle", DSN: "sqlmock_db_unused"},
+			errorContains: `unsupported SQL dialect "oracle"`,
+		},
+		{
+			name:          "ping failure",
+			config:        Config{Driver: "sqlmock", Dialect: "sqlite", DSN: "sqlmock_unknown_dsn"},
+			errorContains: "failed to ping sqlmock",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			adapter, err := NewSQLAdapter(tt.config)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.errorContains)
+			assert.Nil(t, adapter)
+		})
+	}
+}
+
+func TestDialectFor(t *testing.T) {
+	for driver, want := range map[string]string{"mysql": "`a`", "pgx": `"a"`, "postgres": `"a"`, "sqlite": `"a"`, "sqlite3": `"a"`} {
+		dialect, err := dialectFor(driver, "")
+		require.NoError(t, err, driver)
+		assert.Equal(t, want, dialect.quoteIdentifier("a"), driver)
+	}
+
+	dialect, err := dialectFor("mysql", "sqlite")
+	require.NoError(t, err)
+	assert.Equal(t, `"a""b"`, dialect.quoteIdentifier(`a"b`), "explicit dialect wins over the driver name")
+	assert.Equal(t, "?", dialect.placeholder(2))
+}
+
+func TestBuildSQLDSN(t *testing.T) {
+	assert.Equal(t, "file:/data/tenants.db", buildSQLDSN("file:/data/tenants.db", nil))
+	assert.Equal(t, "file:/data/tenants.db?cache=shared&mode=ro",
+		buildSQLDSN("file:/data/tenants.db", map[string]string{"mode": "ro", "cache": "shared"}))
+	assert.Equal(t, "postgres://db/nodes?sslmode=disable&application_name=lynq+operator",
+		buildSQLDSN("postgres://db/nodes?sslmode=disable", map[string]string{"application_name": "lynq operator"}))
+}
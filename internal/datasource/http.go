@@ -0,0 +1,468 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package datasource
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/ohler55/ojg/jp"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/k8s-lynq/lynq/internal/activation"
+)
+
+const (
+	// defaultHTTPTimeout is the per-request timeout used when none is configured
+	defaultHTTPTimeout = 30 * time.Second
+
+	// defaultHTTPMaxPages bounds the number of requests per query
+	defaultHTTPMaxPages = 1000
+
+	// maxHTTPResponseBytes bounds the size of a single response body
+	maxHTTPResponseBytes = 32 << 20
+)
+
+// HTTP pagination strategies
+const (
+	HTTPPaginationNone   = "none"
+	HTTPPaginationCursor = "cursor"
+	HTTPPaginationPage   = "page"
+)
+
+// HTTPPagination holds pagination settings for the HTTP adapter
+type HTTPPagination struct {
+	// Type is one of none (or empty), cursor or page
+	Type string
+
+	// CursorPath is the JSONPath of the next cursor in the response body (cursor)
+	CursorPath string
+	// CursorParam is the query parameter carrying the cursor (cursor, default "cursor")
+	CursorParam string
+
+	// PageParam is the query parameter carrying the page number (page, default "page")
+	PageParam string
+	// StartPage is the number of the first page (page)
+	StartPage int
+
+	// PageSizeParam and PageSize are sent on every request when both are set
+	PageSizeParam string
+	PageSize      int
+
+	// MaxPages limits the number of requests per query (default 1000)
+	MaxPages int
+}
+
+// HTTPAdapter implements the Datasource interface for HTTP endpoints returning JSON
+type HTTPAdapter struct {
+	client      *http.Client
+	baseURL     *url.URL
+	headers     map[string]string
+	bearerToken string
+	username    string
+	password    string
+	itemsPath   jp.Expr
+	cursorPath  jp.Expr
+	pagination  HTTPPagination
+}
+
+// NewHTTPAdapter creates a new HTTP datasource adapter
+// No request is made until QueryNodes is called.
+func NewHTTPAdapter(config Config) (*HTTPAdapter, error) {
+	if config.URL == "" {
+		return nil, fmt.Errorf("HTTP datasource URL is required")
+	}
+	baseURL, err := url.Parse(config.URL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid HTTP datasource URL: %w", err)
+	}
+	if baseURL.Scheme != "http" && baseURL.Scheme != "https" {
+		return nil, fmt.Errorf("unsupported HTTP datasource URL scheme %q", baseURL.Scheme)
+	}
+
+	itemsPathStr := config.ItemsPath
+	if itemsPathStr == "" {
+		itemsPathStr = "$"
+	}
+	itemsPath, err := jp.ParseString(itemsPathStr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid itemsPath %q: %w", itemsPathStr, err)
+	}
+
+	pagination := config.Pagination
+	if pagination.MaxPages <= 0 {
+		pagination.MaxPages = defaultHTTPMaxPages
+	}
+
+	var cursorPath jp.Expr
+	switch pagination.Type {
+	case "", HTTPPaginationNone:
+		pagination.Type = HTTPPaginationNone
+	case HTTPPaginationCursor:
+		if pagination.CursorPath == "" {
+			return nil, fmt.Errorf("cursorPath is required for cursor pagination")
+		}
+		cursorPath, err = jp.ParseString(pagination.CursorPath)
+		if err != nil {
+			return nil, fmt.Errorf("invalid cursorPath %q: %w", pagination.CursorPath, err)
+		}
+		if pagination.CursorParam == "" {
+			pagination.CursorParam = "cursor"
+		}
+	case HTTPPaginationPage:
+		if pagination.PageParam == "" {
+			pagination.PageParam = "page"
+		}
+	default:
+		return nil, fmt.Errorf("unsupported pagination type: %s", pagination.Type)
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if config.CACert != "" || config.ClientCert != "" || config.ClientKey != "" {
+		tlsConfig := &tls.Config{MinVersion: tls.VersionTLS12}
+		if err := applyTLSMaterial(tlsConfig, config); err != nil {
+			return nil, err
+		}
+		transport.TLSClientConfig = tlsConfig
+	}
+
+	timeout := config.Timeout
+	if timeout <= 0 {
+		timeout = defaultHTTPTimeout
+	}
+
+	return &HTTPAdapter{
+		client:      &http.Client{Transport: transport, Timeout: timeout},
+		baseURL:     baseURL,
+		headers:     config.Headers,
+		bearerToken: config.BearerToken,
+		username:    config.Username,
+		password:    config.Password,
+		itemsPath:   itemsPath,
+		cursorPath:  cursorPath,
+		pagination:  pagination,
+	}, nil
+}
+
+// QueryNodes fetches all pages from the endpoint and maps active items to node rows
+// Any failed page fails the whole query so that partial results never cause node deletions.
+func (a *HTTPAdapter) QueryNodes(ctx context.Context, config QueryConfig) ([]NodeRow, error) {
+	mapping, err := compileHTTPMapping(config)
+	if err != nil {
+		return nil, err
+	}
+
+	var nodes []NodeRow
+	cursor := ""
+	page := a.pagination.StartPage
+
+	for request := 1; ; request++ {
+		body, err := a.fetch(ctx, a.pageURL(cursor, page))
+		if err != nil {
+			return nil, err
+		}
+
+		items, err := a.extractItems(body)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, item := range items {
+			// Filter: only include active nodes
+			if mapping.isActive(item) {
+				nodes = append(nodes, mapping.toRow(item))
+			}
+		}
+
+		// Determine whether another page has to be requested
+		switch a.pagination.Type {
+		case HTTPPaginationCursor:
+			next := firstJSONValue(a.cursorPath, body)
+			if next == "" {
+				return nodes, nil
+			}
+			if next == cursor {
+				return nil, fmt.Errorf("pagination cursor did not advance (cursor %q)", cursor)
+			}
+			cursor = next
+		case HTTPPaginationPage:
+			if len(items) == 0 || (a.pagination.PageSizeParam != "" && a.pagination.PageSize > 0 && len(items) < a.pagination.PageSize) {
+				return nodes, nil
+			}
+			page++
+		default:
+			return nodes, nil
+		}
+
+		if request >= a.pagination.MaxPages {
+			return nil, fmt.Errorf("pagination exceeded maxPages (%d)", a.pagination.MaxPages)
+		}
+	}
+}
+
+// Close releases idle connections held by the HTTP client
+func (a *HTTPAdapter) Close() error {
+	if a.client != nil {
+		a.client.CloseIdleConnections()
+	}
+	return nil
+}
+
+// pageURL returns the request URL for the given cursor/page, preserving configured query parameters
+func (a *HTTPAdapter) pageURL(cursor string, page int) string {
+	u := *a.baseURL
+	query := u.Query()
+
+	switch a.pagination.Type {
+	case HTTPPaginationCursor:
+		if cursor != "" {
+			query.Set(a.pagination.CursorParam, cursor)
+		}
+	case HTTPPaginationPage:
+		query.Set(a.pagination.PageParam, strconv.Itoa(page))
+	}
+	if a.pagination.PageSizeParam != "" && a.pagination.PageSize > 0 {
+		query.Set(a.pagination.PageSizeParam, strconv.Itoa(a.pagination.PageSize))
+	}
+
+	u.RawQuery = query.Encode()
+	return u.String()
+}
+
+// fetch performs a single authenticated GET and decodes the JSON response
+func (a *HTTPAdapter) fetch(ctx context.Context, requestURL string) (interface{}, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, requestURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build request: %w", err)
+	}
+
+	req.Header.Set("Accept", "application/json")
+	for key, value := range a.headers {
+		req.Header.Set(key, value)
+	}
+	if a.bearerToken != "" {
+		req.Header.Set("Authorization", "Bearer "+a.bearerToken)
+	} else if a.username != "" {
+		req.SetBasicAuth(a.username, a.password)
+	}
+
+	resp, err := a.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query nodes: %w", err)
+	}
+	defer func() {
+		_ = resp.Body.Close() // Best effort close
+	}()
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxHTTPResponseBytes+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+	if len(data) > maxHTTPResponseBytes {
+		return nil, fmt.Errorf("response body exceeds %d bytes", maxHTTPResponseBytes)
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		// The body may hold tenant data or credentials, so it is only logged at debug level;
+		// the error ends up in hub events and status
+		snippet := data
+		if len(snippet) > 256 {
+			snippet = snippet[:256]
+		}
+		log.FromContext(ctx).V(1).Info("Unexpected HTTP datasource response",
+			"status", resp.StatusCode, "body", string(bytes.TrimSpace(snippet)))
+		return nil, fmt.Errorf("unexpected status %d (content type %q) from %s",
+			resp.StatusCode, resp.Header.Get("Content-Type"), a.baseURL.Redacted())
+	}
+
+	// UseNumber keeps integer IDs intact instead of converting them to float64
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var body interface{}
+	if err := decoder.Decode(&body); err != nil {
+		return nil, fmt.Errorf("failed to decode JSON response: %w", err)
+	}
+
+	return body, nil
+}
+
+// extractItems resolves itemsPath against the response body
+// A path resolving to an array yields its elements; a path matching nothing is an error
+// so that a changed response shape is not mistaken for an empty node list.
+func (a *HTTPAdapter) extractItems(body interface{}) ([]interface{}, error) {
+	results := a.itemsPath.Get(body)
+	if len(results) == 0 {
+		return nil, fmt.Errorf("itemsPath %q matched nothing in the response", a.itemsPath.String())
+	}
+	if len(results) == 1 {
+		if items, ok := results[0].([]interface{}); ok {
+			return items, nil
+		}
+	}
+	return results, nil
+}
+
+// httpMapping holds the compiled JSONPath expressions of the value mappings
+type httpMapping struct {
+	uid       jp.Expr
+	hostOrURL jp.Expr
+	activate  jp.Expr
+	extra     map[string]jp.Expr
+	types     map[string]ValueType
+
+	// rule decides activation; columns holds the paths referenced by its expression
+	rule    *activation.Rule
+	columns map[string]jp.Expr
+}
+
+// compileHTTPMapping parses the value mappings as JSONPath expressions relative to an item
+func compileHTTPMapping(config QueryConfig) (*httpMapping, error) {
+	parse := func(field, path string) (jp.Expr, error) {
+		expr, err := jp.ParseString(path)
+		if err != nil {
+			return nil, fmt.Errorf("invalid JSONPath %q for %s: %w", path, field, err)
+		}
+		return expr, nil
+	}
+
+	var err error
+	m := &httpMapping{
+		extra: make(map[string]jp.Expr, len(config.ExtraMappings)),
+		types: config.ExtraTypes,
+	}
+	if m.uid, err = parse("uid", config.ValueMappings.UID); err != nil {
+		return nil, err
+	}
+	if config.ValueMappings.HostOrURL != "" {
+		if m.hostOrURL, err = parse("hostOrUrl", config.ValueMappings.HostOrURL); err != nil {
+			return nil, err
+		}
+	}
+	if config.ValueMappings.Activate != "" {
+		if m.activate, err = parse("activate", config.ValueMappings.Activate); err != nil {
+			return nil, err
+		}
+	}
+	for key, path := range config.ExtraMappings {
+		if m.extra[key], err = parse(key, path); err != nil {
+			return nil, err
+		}
+	}
+
+	if m.rule, err = activationRule(config.ValueMappings); err != nil {
+		return nil, err
+	}
+	columns := m.rule.Columns()
+	m.columns = make(map[string]jp.Expr, len(columns))
+	for _, column := range columns {
+		if m.columns[column], err = parse("activateExpression", column); err != nil {
+			return nil, err
+		}
+	}
+	return m, nil
+}
+
+// isActive evaluates the activation rule against a single JSON item
+// Expression columns are JSONPaths relative to the item; null or missing values are NULL.
+func (m *httpMapping) isActive(item interface{}) bool {
+	// Without an activate path every item is active, unless an expression says otherwise
+	activate, activateValid := defaultActivateValue, true
+	if m.activate != nil {
+		activate, activateValid = lookupJSONValue(m.activate, item)
+	}
+	return m.rule.IsActive(activate, activateValid, func(column string) (string, bool) {
+		return lookupJSONValue(m.columns[column], item)
+	})
+}
+
+// toRow maps a single JSON item to a node row
+func (m *httpMapping) toRow(item interface{}) NodeRow {
+	row := NodeRow{
+		UID:      firstJSONValue(m.uid, item),
+		Activate: defaultActivateValue,
+		Extra:    make(map[string]string, len(m.extra)),
+	}
+	if m.activate != nil {
+		row.Activate = firstJSONValue(m.activate, item)
+	}
+	if m.hostOrURL != nil {
+		row.HostOrURL = firstJSONValue(m.hostOrURL, item)
+	}
+	for key, expr := range m.extra {
+		row.Extra[key] = firstJSONValue(expr, item)
+	}
+
+	// "auto" uses the JSON type of each value
+	types := resolveValueTypes(m.types, func(key string) ValueType {
+		if results := m.extra[key].Get(item); len(results) > 0 {
+			return jsonValueType(results[0])
+		}
+		return ValueTypeString
+	})
+	applyValueTypes(&row, types)
+	return row
+}
+
+// firstJSONValue returns the first match of expr in data as a string ("" when nothing matches)
+func firstJSONValue(expr jp.Expr, data interface{}) string {
+	results := expr.Get(data)
+	if len(results) == 0 {
+		return ""
+	}
+	return jsonValueToString(results[0])
+}
+
+// lookupJSONValue returns the first match of expr in data; ok is false when nothing matches or the match is null
+func lookupJSONValue(expr jp.Expr, data interface{}) (string, bool) {
+	if expr == nil {
+		return "", false
+	}
+	results := expr.Get(data)
+	if len(results) == 0 || results[0] == nil {
+		return "", false
+	}
+	return jsonValueToString(results[0]), true
+}
+
+// jsonValueToString converts a decoded JSON value to its string form
+// null becomes an empty string; objects and arrays are re-encoded as JSON.
+func jsonValueToString(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case json.Number:
+		return v.String()
+	case bool:
+		return strconv.FormatBool(v)
+	default:
+		encoded, err := json.Marshal(v)
+		if err != nil {
+			return fmt.Sprint(v)
+		}
+		return string(encoded)
+	}
+}
@@ -0,0 +1,413 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package datasource
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var httpTestQueryConfig = QueryConfig{
+	ValueMappings: ValueMappings{
+		UID:      "$.id",
+		Activate: "active",
+	},
+	ExtraMappings: map[string]string{
+		"plan":   "$.billing.plan",
+		"region": "region",
+	},
+}
+
+func TestNewHTTPAdapter(t *testing.T) {
+	tests := []struct {
+		name       string
+		config     Config
+		errMessage string
+	}{
+		{
+			name:   "minimal configuration",
+			config: Config{URL: "https://billing.example.com/tenants"},
+		},
+		{
+			name:       "missing url",
+			config:     Config{},
+			errMessage: "HTTP datasource URL is required",
+		},
+		{
+			name:       "unsupported scheme",
+			config:     Config{URL: "ftp://billing.example.com/tenants"},
+			errMessage: "unsupported HTTP datasource URL scheme",
+		},
+		{
+			name:       "invalid items path",
+			config:     Config{URL: "https://billing.example.com/tenants", ItemsPath: "$.data[?("},
+			errMessage: "invalid itemsPath",
+		},
+		{
+			name: "cursor pagination without cursor path",
+			config: Config{
+				URL:        "https://billing.example.com/tenants",
+				Pagination: HTTPPagination{Type: HTTPPaginationCursor},
+			},
+			errMessage: "cursorPath is required",
+		},
+		{
+			name: "unsupported pagination",
+			config: Config{
+				URL:        "https://billing.example.com/tenants",
+				Pagination: HTTPPagination{Type: "link"},
+			},
+			errMessage: "unsupported pagination type: link",
+		},
+		{
+			name: "invalid CA certificate",
+			config: Config{
+				URL:    "https://billing.example.com/tenants",
+				CACert: "not a certificate",
+			},
+			errMessage: "failed to parse CA certificate",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			adapter, err := NewHTTPAdapter(tt.config)
+			if tt.errMessage != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errMessage)
+				return
+			}
+			require.NoError(t, err)
+			assert.NoError(t, adapter.Close())
+		})
+	}
+}
+
+func TestHTTPAdapter_QueryNodes(t *testing.T) {
+	t.Run("single response with auth and headers", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "Bearer secret-token", r.Header.Get("Authorization"))
+			assert.Equal(t, "2024-01", r.Header.Get("X-Api-Version"))
+			writeJSON(w, map[string]interface{}{
+				"data": []interface{}{
+					map[string]interface{}{"id": 1001, "active": true, "billing": map[string]interface{}{"plan": "pro"}, "region": "eu"},
+					map[string]interface{}{"id": 1002, "active": false, "billing": map[string]interface{}{"plan": "free"}},
+					map[string]interface{}{"id": "acme", "active": "1", "region": nil},
+				},
+			})
+		}))
+		defer server.Close()
+
+		adapter, err := NewHTTPAdapter(Config{
+			URL:         server.URL,
+			ItemsPath:   "$.data",
+			BearerToken: "secret-token",
+			Headers:     map[string]string{"X-Api-Version": "2024-01"},
+		})
+		require.NoError(t, err)
+
+		rows, err := adapter.QueryNodes(context.Background(), httpTestQueryConfig)
+		require.NoError(t, err)
+		assert.Equal(t, []NodeRow{
+			{UID: "1001", Activate: "true", Extra: map[string]string{"plan": "pro", "region": "eu"}},
+			{UID: "acme", Activate: "1", Extra: map[string]string{"plan": "", "region": ""}},
+		}, rows)
+	})
+
+	t.Run("typed values", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			writeJSON(w, []interface{}{
+				map[string]interface{}{"id": "t1", "active": true, "replicas": 3, "cpu": 0.5, "ha": true, "port": "8080", "name": "web",
+					"settings": map[string]interface{}{"sso": true, "seats": 5}},
+				map[string]interface{}{"id": "t2", "active": true, "replicas": nil, "cpu": 2, "ha": "no", "port": "http", "name": 7},
+			})
+		}))
+		defer server.Close()
+
+		adapter, err := NewHTTPAdapter(Config{URL: server.URL})
+		require.NoError(t, err)
+
+		rows, err := adapter.QueryNodes(context.Background(), QueryConfig{
+			ValueMappings: ValueMappings{UID: "$.id", Activate: "$.active"},
+			ExtraMappings: map[string]string{"replicas": "$.replicas", "cpu": "$.cpu", "ha": "$.ha", "port": "$.port", "name": "$.name", "settings": "$.settings"},
+			ExtraTypes: map[string]ValueType{
+				"replicas": ValueTypeAuto,
+				"cpu":      ValueTypeAuto,
+				"ha":       ValueTypeAuto,
+				"port":     ValueTypeInt,
+				"name":     ValueTypeString,
+				"settings": ValueTypeAuto,
+			},
+		})
+		require.NoError(t, err)
+		require.Len(t, rows, 2)
+		assert.Equal(t, map[string]interface{}{
+			"replicas": int64(3), "cpu": 0.5, "ha": true, "port": int64(8080),
+			"settings": map[string]interface{}{"sso": true, "seats": int64(5)},
+		}, rows[0].Typed)
+		// "auto" follows the JSON type of each item
+		assert.Equal(t, map[string]interface{}{"cpu": int64(2)}, rows[1].Typed)
+		assert.Equal(t, "7", rows[1].Extra["name"])
+	})
+
+	t.Run("basic auth with root array", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			username, password, ok := r.BasicAuth()
+			assert.True(t, ok)
+			assert.Equal(t, "reader", username)
+			assert.Equal(t, "p@ss", password)
+			writeJSON(w, []interface{}{
+				map[string]interface{}{"id": "t1", "active": "yes"},
+			})
+		}))
+		defer server.Close()
+
+		adapter, err := NewHTTPAdapter(Config{URL: server.URL, Username: "reader", Password: "p@ss"})
+		require.NoError(t, err)
+
+		rows, err := adapter.QueryNodes(context.Background(), httpTestQueryConfig)
+		require.NoError(t, err)
+		require.Len(t, rows, 1)
+		assert.Equal(t, "t1", rows[0].UID)
+	})
+
+	t.Run("cursor pagination", func(t *testing.T) {
+		var cursors []string
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			cursor := r.URL.Query().Get("after")
+			cursors = append(cursors, cursor)
+			assert.Equal(t, "50", r.URL.Query().Get("limit"))
+			assert.Equal(t, "true", r.URL.Query().Get("expand"))
+			switch cursor {
+			case "":
+				writeJSON(w, map[string]interface{}{
+					"items": []interface{}{map[string]interface{}{"id": "a", "active": true}},
+					"meta":  map[string]interface{}{"next": "c2"},
+				})
+			case "c2":
+				writeJSON(w, map[string]interface{}{
+					"items": []interface{}{map[string]interface{}{"id": "b", "active": true}},
+					"meta":  map[string]interface{}{"next": nil},
+				})
+			}
+		}))
+		defer server.Close()
+
+		adapter, err := NewHTTPAdapter(Config{
+			URL:       server.URL + "?expand=true",
+			ItemsPath: "$.items",
+			Pagination: HTTPPagination{
+				Type:          HTTPPaginationCursor,
+				CursorPath:    "$.meta.next",
+				CursorParam:   "after",
+				PageSizeParam: "limit",
+				PageSize:      50,
+			},
+		})
+		require.NoError(t, err)
+
+		rows, err := adapter.QueryNodes(context.Background(), httpTestQueryConfig)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"", "c2"}, cursors)
+		assert.Equal(t, []string{"a", "b"}, rowUIDs(rows))
+	})
+
+	t.Run("page pagination stops on empty page", func(t *testing.T) {
+		pages := map[string][]interface{}{
+			"1": {map[string]interface{}{"id": "a", "active": true}},
+			"2": {map[string]interface{}{"id": "b", "active": true}},
+		}
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			items := pages[r.URL.Query().Get("page")]
+			if items == nil {
+				items = []interface{}{}
+			}
+			writeJSON(w, map[string]interface{}{"data": items})
+		}))
+		defer server.Close()
+
+		adapter, err := NewHTTPAdapter(Config{
+			URL:        server.URL,
+			ItemsPath:  "$.data",
+			Pagination: HTTPPagination{Type: HTTPPaginationPage, StartPage: 1},
+		})
+		require.NoError(t, err)
+
+		rows, err := adapter.QueryNodes(context.Background(), httpTestQueryConfig)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"a", "b"}, rowUIDs(rows))
+	})
+
+	t.Run("max pages exceeded", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			writeJSON(w, map[string]interface{}{"data": []interface{}{map[string]interface{}{"id": "a", "active": true}}})
+		}))
+		defer server.Close()
+
+		adapter, err := NewHTTPAdapter(Config{
+			URL:        server.URL,
+			ItemsPath:  "$.data",
+			Pagination: HTTPPagination{Type: HTTPPaginationPage, MaxPages: 3},
+		})
+		require.NoError(t, err)
+
+		_, err = adapter.QueryNodes(context.Background(), httpTestQueryConfig)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "pagination exceeded maxPages (3)")
+	})
+
+	t.Run("cursor that does not advance", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			writeJSON(w, map[string]interface{}{"data": []interface{}{}, "next": "same"})
+		}))
+		defer server.Close()
+
+		adapter, err := NewHTTPAdapter(Config{
+			URL:        server.URL,
+			ItemsPath:  "$.data",
+			Pagination: HTTPPagination{Type: HTTPPaginationCursor, CursorPath: "$.next"},
+		})
+		require.NoError(t, err)
+
+		_, err = adapter.QueryNodes(context.Background(), httpTestQueryConfig)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "pagination cursor did not advance")
+	})
+
+	t.Run("non-2xx status", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			http.Error(w, "forbidden: token abc123 expired", http.StatusForbidden)
+		}))
+		defer server.Close()
+
+		adapter, err := NewHTTPAdapter(Config{URL: server.URL})
+		require.NoError(t, err)
+
+		_, err = adapter.QueryNodes(context.Background(), httpTestQueryConfig)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), `unexpected status 403 (content type "text/plain; charset=utf-8")`)
+		assert.NotContains(t, err.Error(), "abc123", "the response body is not reported")
+	})
+
+	t.Run("items path matches nothing", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			writeJSON(w, map[string]interface{}{"results": []interface{}{}})
+		}))
+		defer server.Close()
+
+		adapter, err := NewHTTPAdapter(Config{URL: server.URL, ItemsPath: "$.data"})
+		require.NoError(t, err)
+
+		_, err = adapter.QueryNodes(context.Background(), httpTestQueryConfig)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), `itemsPath "$.data" matched nothing`)
+	})
+
+	t.Run("invalid json", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte("<html>"))
+		}))
+		defer server.Close()
+
+		adapter, err := NewHTTPAdapter(Config{URL: server.URL})
+		require.NoError(t, err)
+
+		_, err = adapter.QueryNodes(context.Background(), httpTestQueryConfig)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to decode JSON response")
+	})
+
+	t.Run("activate expression", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			writeJSON(w, []interface{}{
+				map[string]interface{}{"id": "a", "billing": map[string]interface{}{"status": "active"}, "deletedAt": nil},
+				map[string]interface{}{"id": "b", "billing": map[string]interface{}{"status": "active"}, "deletedAt": "2025-01-01"},
+				map[string]interface{}{"id": "c", "billing": map[string]interface{}{"status": "churned"}},
+				map[string]interface{}{"id": "d", "billing": map[string]interface{}{"status": "trial"}},
+			})
+		}))
+		defer server.Close()
+
+		adapter, err := NewHTTPAdapter(Config{URL: server.URL})
+		require.NoError(t, err)
+
+		rows, err := adapter.QueryNodes(context.Background(), QueryConfig{
+			ValueMappings: ValueMappings{
+				UID:                "id",
+				ActivateExpression: "billing.status in ('active', 'trial') AND deletedAt IS NULL",
+			},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, []NodeRow{
+			{UID: "a", Activate: "true", Extra: map[string]string{}},
+			{UID: "d", Activate: "true", Extra: map[string]string{}},
+		}, rows)
+	})
+
+	t.Run("invalid mapping", func(t *testing.T) {
+		adapter, err := NewHTTPAdapter(Config{URL: "http://127.0.0.1:1"})
+		require.NoError(t, err)
+
+		_, err = adapter.QueryNodes(context.Background(), QueryConfig{
+			ValueMappings: ValueMappings{UID: "$.id[?(", Activate: "active"},
+		})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "for uid")
+	})
+}
+
+func TestJSONValueToString(t *testing.T) {
+	tests := []struct {
+		name  string
+		value interface{}
+		want  string
+	}{
+		{name: "nil", value: nil, want: ""},
+		{name: "string", value: "acme", want: "acme"},
+		{name: "number", value: json.Number("12345678901234567890"), want: "12345678901234567890"},
+		{name: "bool", value: true, want: "true"},
+		{name: "object", value: map[string]interface{}{"a": "b"}, want: `{"a":"b"}`},
+		{name: "array", value: []interface{}{"x", json.Number("1")}, want: `["x",1]`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, jsonValueToString(tt.value))
+		})
+	}
+}
+
+func writeJSON(w http.ResponseWriter, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+func rowUIDs(rows []NodeRow) []string {
+	uids := make([]string, 0, len(rows))
+	for _, row := range rows {
+		uids = append(uids, row.UID)
+	}
+	sort.Strings(uids)
+	return uids
+}
@@ -20,6 +20,7 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"time"
 )
 
 // Datasource defines the interface that all datasource adapters must implement
@@ -31,6 +32,24 @@ type Datasource interface {
 	io.Closer
 }
 
+// IncrementalDatasource is implemented by adapters that can return only the rows
+// changed since a watermark (see QueryConfig.UpdatedAtColumn)
+type IncrementalDatasource interface {
+	// QueryChangedNodes returns rows whose UpdatedAtColumn is at or after since,
+	// or all rows when since is zero
+	QueryChangedNodes(ctx context.Context, config QueryConfig, since time.Time) (*ChangeSet, error)
+}
+
+// ChangeSet is the result of an incremental query
+type ChangeSet struct {
+	// Active contains changed rows that are active
+	Active []NodeRow
+	// InactiveUIDs contains the UIDs of changed rows that are no longer active
+	InactiveUIDs []string
+	// Watermark is the highest UpdatedAtColumn value seen (since, when no row had a newer value)
+	Watermark time.Time
+}
+
 // NodeRow represents a row from the node datasource
 type NodeRow struct {
 	UID string
@@ -39,6 +58,12 @@ type NodeRow struct {
 	HostOrURL string
 	Activate  string
 	Extra     map[string]string
+	// Typed holds the extra values converted to their non-string type (see QueryConfig.ExtraTypes),
+	// keyed like Extra; Extra keeps the string form of every value
+	Typed map[string]interface{}
+	// Relations holds the child rows of each relation, keyed by relation name
+	// (see QueryConfig.Relations); nodes without child rows have an empty list
+	Relations map[string][]map[string]string
 }
 
 // QueryConfig holds configuration for querying nodes
@@ -51,6 +76,41 @@ type QueryConfig struct {
 
 	// Extra column mappings
 	ExtraMappings map[string]string
+
+	// ExtraTypes declares the type of extra values, keyed like ExtraMappings (default string)
+	ExtraTypes map[string]ValueType
+
+	// UpdatedAtColumn is the last-modified timestamp column used by incremental queries
+	UpdatedAtColumn string
+
+	// Filters are predicates pushed down into the query (SQL adapters); all must match
+	Filters []Filter
+
+	// Relations load one-to-many child rows for each node (SQL adapters)
+	Relations []Relation
+}
+
+// Relation maps the child rows referencing a node to a list
+type Relation struct {
+	// Name is the key in NodeRow.Relations
+	Name string
+	// Table is the child table, qualified like the source table
+	Table string
+	// ForeignKey is the child column holding the node UID
+	ForeignKey string
+	// Mappings maps item field names to child columns
+	Mappings map[string]string
+	// OrderBy is the column that orders the items (default: the mapped columns)
+	OrderBy string
+}
+
+// Filter is a single column predicate with bound values
+type Filter struct {
+	Column string
+	// Operator is one of eq, ne, lt, le, gt, ge, in, notIn, like, isNull, isNotNull
+	Operator string
+	// Values holds a single operand for comparisons and like, one or more for in/notIn
+	Values []string
 }
 
 // ValueMappings defines required column mappings
@@ -59,7 +119,12 @@ type ValueMappings struct {
 	// HostOrURL is deprecated since v1.1.11 and will be removed in v1.3.0
 	// Use extraValueMappings with toHost() template function instead
 	HostOrURL string
-	Activate  string
+	// Activate is the activation column; optional when ActivateExpression is set
+	Activate string
+	// ActiveValues, when set, replaces the default truthy values for the activate column
+	ActiveValues []string
+	// ActivateExpression decides activation from other columns, e.g. "deleted_at IS NULL"
+	ActivateExpression string
 }
 
 // Config holds generic datasource configuration
@@ -72,20 +137,82 @@ type Config struct {
 	Password string
 	Database string
 
+	// MySQL fields
+	FailoverHosts  []Endpoint        // Further primary hosts, tried in order after Host
+	Replicas       []Endpoint        // Read replicas
+	ReadPreference string            // replica (default) or primary: which endpoints are tried first
+	TLSMode        string            // disable, preferred, required, verify-ca or verify-full
+	Params         map[string]string // Extra DSN parameters (go-sql-driver/mysql or generic sql)
+
+	// PostgreSQL fields
+	Schema  string
+	SSLMode string
+
+	// Generic database/sql fields (Params and the pool settings are shared with MySQL)
+	Driver  string // Registered database/sql driver name
+	Dialect string // mysql, postgresql or sqlite; inferred from Driver when empty
+	DSN     string
+
+	// HTTP fields (Username/Password are used for basic auth)
+	URL         string
+	Headers     map[string]string
+	BearerToken string
+	ItemsPath   string
+	Pagination  HTTPPagination
+	Timeout     time.Duration
+
+	// Plugin fields (BearerToken and Timeout are shared with HTTP)
+	Endpoint     string            // gRPC target
+	Table        string            // Passed to the plugin with every query
+	PluginConfig map[string]string // Passed to the plugin with every query
+	PluginTLS    bool              // Connect with TLS (CACert/ClientCert/ClientKey are optional)
+
+	// ConfigMap/inline fields
+	RowData   string              // Encoded rows (CSV with header, JSON or YAML list)
+	RowFormat string              // csv, json or yaml
+	Rows      []map[string]string // Already decoded rows (inline), used instead of RowData
+
+	// TLS material (PEM-encoded, typically loaded from Secrets)
+	CACert     string
+	ClientCert string
+	ClientKey  string
+
 	// Connection pool settings (optional, adapter-specific defaults will be used if not set)
 	MaxOpenConns    int
 	MaxIdleConns    int
 	ConnMaxLifetime string // Duration string (e.g., "5m")
 }
 
+// Endpoint is a database server address
+type Endpoint struct {
+	Host string
+	Port int32
+}
+
+// EndpointReporter is implemented by adapters that can read from one of several endpoints
+type EndpointReporter interface {
+	// ActiveEndpoint describes the endpoint used by the last successful query ("" if none)
+	ActiveEndpoint() string
+}
+
 // SourceType represents the type of datasource
 type SourceType string
 
 const (
 	// SourceTypeMySQL represents a MySQL datasource
 	SourceTypeMySQL SourceType = "mysql"
-	// SourceTypePostgreSQL represents a PostgreSQL datasource (planned)
+	// SourceTypePostgreSQL represents a PostgreSQL datasource
 	SourceTypePostgreSQL SourceType = "postgresql"
+	// SourceTypeSQL represents a database reached through a registered database/sql driver
+	SourceTypeSQL SourceType = "sql"
+	// SourceTypeHTTP represents an HTTP/JSON endpoint datasource
+	SourceTypeHTTP SourceType = "http"
+	// SourceTypeConfigMap represents rows stored in a ConfigMap
+	SourceTypeConfigMap SourceType = "configmap"
+	// SourceTypeInline represents rows embedded in the LynqHub spec
+	SourceTypeInline SourceType = "inline"
+	// SourceTypePlugin represents an out-of-process datasource plugin served over gRPC
+	SourceTypePlugin SourceType = "plugin"
 )
 
 // NewDatasource creates a new datasource adapter based on the source type
@@ -94,7 +221,15 @@ func NewDatasource(sourceType SourceType, config Config) (Datasource, error) {
 	case SourceTypeMySQL:
 		return NewMySQLAdapter(config)
 	case SourceTypePostgreSQL:
-		return nil, fmt.Errorf("postgresql datasource not yet implemented (planned for v1.2)")
+		return NewPostgreSQLAdapter(config)
+	case SourceTypeSQL:
+		return NewSQLAdapter(config)
+	case SourceTypeHTTP:
+		return NewHTTPAdapter(config)
+	case SourceTypeConfigMap, SourceTypeInline:
+		return NewStaticAdapter(config)
+	case SourceTypePlugin:
+		return NewPluginAdapter(config)
 	default:
 		return nil, fmt.Errorf("unsupported datasource type: %s", sourceType)
 	}
@@ -43,7 +43,7 @@ func TestNewDatasource(t *testing.T) {
 			wantErr: true, // Will fail without real MySQL, but validates factory logic
 		},
 		{
-			name:       "postgresql datasource (not yet implemented)",
+			name:       "postgresql datasource",
 			sourceType: SourceTypePostgreSQL,
 			config: Config{
 				Host:     "localhost",
@@ -51,9 +51,42 @@ func TestNewDatasource(t *testing.T) {
 				Username: "postgres",
 				Password: "password",
 				Database: "nodes",
+				SSLMode:  "disable",
 			},
+			wantErr:    true, // Will fail without real PostgreSQL, but validates factory logic
+			errMessage: "failed to ping PostgreSQL",
+		},
+		{
+			name:       "http datasource",
+			sourceType: SourceTypeHTTP,
+			config: Config{
+				URL: "https://billing.example.com/api/tenants",
+			},
+			wantErr: false, // No request is made until QueryNodes
+		},
+		{
+			name:       "http datasource without url",
+			sourceType: SourceTypeHTTP,
+			config:     Config{},
 			wantErr:    true,
-			errMessage: "postgresql datasource not yet implemented",
+			errMessage: "HTTP datasource URL is required",
+		},
+		{
+			name:       "configmap datasource",
+			sourceType: SourceTypeConfigMap,
+			config: Config{
+				RowFormat: RowFormatCSV,
+				RowData:   "id,active\nacme,1\n",
+			},
+			wantErr: false,
+		},
+		{
+			name:       "inline datasource",
+			sourceType: SourceTypeInline,
+			config: Config{
+				Rows: []map[string]string{{"id": "acme", "active": "1"}},
+			},
+			wantErr: false,
 		},
 		{
 			name:       "unsupported datasource type",
@@ -0,0 +1,108 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package datasource
+
+// MergeSet holds the rows of an additional source, to be joined to the primary rows by UID
+type MergeSet struct {
+	// Name identifies the source
+	Name string
+	// Rows are the rows read from the source; of several rows with the same UID the first is used
+	Rows []NodeRow
+	// Required drops primary rows without a row in this set
+	Required bool
+}
+
+// MergeRows joins the extra values of the sets into the primary rows by UID
+// For every extra key the first non-empty value wins, in the order primary row, then sets in
+// order; a typed value follows the value that won. Primary rows are not modified. It returns
+// the merged rows and, per set, the number of primary rows that had a row in the set.
+func MergeRows(primary []NodeRow, sets []MergeSet) ([]NodeRow, []int) {
+	indexes := make([]map[string]NodeRow, len(sets))
+	for i, set := range sets {
+		index := make(map[string]NodeRow, len(set.Rows))
+		for _, row := range set.Rows {
+			if _, ok := index[row.UID]; !ok {
+				index[row.UID] = row
+			}
+		}
+		indexes[i] = index
+	}
+
+	matched := make([]int, len(sets))
+	merged := make([]NodeRow, 0, len(primary))
+	for _, row := range primary {
+		if !hasRequiredRows(row.UID, sets, indexes) {
+			continue
+		}
+
+		row.Extra = copyStrings(row.Extra)
+		row.Typed = copyValues(row.Typed)
+		for i := range sets {
+			other, ok := indexes[i][row.UID]
+			if !ok {
+				continue
+			}
+			matched[i]++
+			for key, value := range other.Extra {
+				if existing, ok := row.Extra[key]; ok && (existing != "" || value == "") {
+					continue
+				}
+				row.Extra[key] = value
+				delete(row.Typed, key)
+				if typed, ok := other.Typed[key]; ok {
+					if row.Typed == nil {
+						row.Typed = make(map[string]interface{})
+					}
+					row.Typed[key] = typed
+				}
+			}
+		}
+		merged = append(merged, row)
+	}
+	return merged, matched
+}
+
+// hasRequiredRows reports whether every required set has a row for uid
+func hasRequiredRows(uid string, sets []MergeSet, indexes []map[string]NodeRow) bool {
+	for i, set := range sets {
+		if _, ok := indexes[i][uid]; set.Required && !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// copyStrings returns a copy of m (an empty map for nil)
+func copyStrings(m map[string]string) map[string]string {
+	copied := make(map[string]string, len(m))
+	for key, value := range m {
+		copied[key] = value
+	}
+	return copied
+}
+
+// copyValues returns a copy of m (nil for nil)
+func copyValues(m map[string]interface{}) map[string]interface{} {
+	if m == nil {
+		return nil
+	}
+	copied := make(map[string]interface{}, len(m))
+	for key, value := range m {
+		copied[key] = value
+	}
+	return copied
+}
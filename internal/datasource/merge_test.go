@@ -0,0 +1,69 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package datasource
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMergeRows(t *testing.T) {
+	primary := []NodeRow{
+		{UID: "acme", Activate: "1", Extra: map[string]string{"region": "eu", "plan": ""}},
+		{UID: "beta", Activate: "1", Extra: map[string]string{"region": "us", "plan": "legacy"}},
+		{UID: "gamma", Activate: "1", Extra: map[string]string{"region": "ap"}},
+	}
+	billing := MergeSet{
+		Name: "billing",
+		Rows: []NodeRow{
+			{UID: "acme", Extra: map[string]string{"plan": "pro", "seats": "25"}, Typed: map[string]interface{}{"seats": int64(25)}},
+			{UID: "beta", Extra: map[string]string{"plan": "enterprise", "seats": ""}},
+			{UID: "acme", Extra: map[string]string{"plan": "duplicate"}},
+			{UID: "orphan", Extra: map[string]string{"plan": "free"}},
+		},
+	}
+	limits := MergeSet{
+		Name: "limits",
+		Rows: []NodeRow{
+			{UID: "acme", Extra: map[string]string{"seats": "10", "quota": "5Gi"}},
+			{UID: "beta", Extra: map[string]string{"seats": "50"}, Typed: map[string]interface{}{"seats": int64(50)}},
+		},
+	}
+
+	rows, matched := MergeRows(primary, []MergeSet{billing, limits})
+	assert.Equal(t, []NodeRow{
+		{
+			UID: "acme", Activate: "1",
+			Extra: map[string]string{"region": "eu", "plan": "pro", "seats": "25", "quota": "5Gi"},
+			Typed: map[string]interface{}{"seats": int64(25)},
+		},
+		{
+			UID: "beta", Activate: "1",
+			Extra: map[string]string{"region": "us", "plan": "legacy", "seats": "50"},
+			Typed: map[string]interface{}{"seats": int64(50)},
+		},
+		{UID: "gamma", Activate: "1", Extra: map[string]string{"region": "ap"}},
+	}, rows)
+	assert.Equal(t, []int{2, 2}, matched)
+	assert.Equal(t, "", primary[0].Extra["plan"], "primary rows must not be modified")
+
+	limits.Required = true
+	rows, matched = MergeRows(primary, []MergeSet{billing, limits})
+	assert.Len(t, rows, 2, "gamma has no limits row")
+	assert.Equal(t, []int{2, 2}, matched)
+}
@@ -18,210 +18,378 @@ package datasource
 
 import (
 	"context"
+	"crypto/tls"
+	"crypto/x509"
 	"database/sql"
+	"errors"
 	"fmt"
+	"net"
 	"sort"
+	"strconv"
+	"strings"
+	"sync"
 	"time"
 
-	_ "github.com/go-sql-driver/mysql" // MySQL driver
+	"github.com/go-sql-driver/mysql"
+
+	"github.com/k8s-lynq/lynq/internal/activation"
 )
 
 // MySQLAdapter implements the Datasource interface for MySQL
 type MySQLAdapter struct {
+	// db is the connection used for queries; with several endpoints it is the endpoint in use
 	db *sql.DB
+
+	// endpoints are the candidate servers in read preference order (nil for tests using db directly)
+	endpoints []*mysqlEndpoint
+
+	mu     sync.Mutex
+	active string
 }
 
+// mysqlEndpoint is one server of a multi-host MySQL source
+type mysqlEndpoint struct {
+	addr string
+	role string
+	db   *sql.DB
+}
+
+// String returns the endpoint as reported in the hub status
+func (e *mysqlEndpoint) String() string {
+	return e.addr + " (" + e.role + ")"
+}
+
+// mysqlPingTimeout bounds the connection test of a single endpoint
+const mysqlPingTimeout = 5 * time.Second
+
 // NewMySQLAdapter creates a new MySQL datasource adapter
+// With failover hosts or replicas, the adapter connects to the first healthy endpoint in
+// read preference order and fails only when no endpoint is reachable.
 func NewMySQLAdapter(config Config) (*MySQLAdapter, error) {
-	dsn := fmt.Sprintf("%s:%s@tcp(%s:%d)/%s?parseTime=true",
-		config.Username,
-		config.Password,
-		config.Host,
-		config.Port,
-		config.Database,
-	)
-
-	db, err := sql.Open("mysql", dsn)
+	mysqlConfig, err := buildMySQLConfig(config)
 	if err != nil {
-		return nil, fmt.Errorf("failed to open MySQL connection: %w", err)
+		return nil, err
 	}
 
-	// Set connection pool settings
-	maxOpenConns := config.MaxOpenConns
-	if maxOpenConns == 0 {
-		maxOpenConns = 25 // Default
-	}
-	db.SetMaxOpenConns(maxOpenConns)
-
-	maxIdleConns := config.MaxIdleConns
-	if maxIdleConns == 0 {
-		maxIdleConns = 5 // Default
-	}
-	db.SetMaxIdleConns(maxIdleConns)
+	adapter := &MySQLAdapter{}
+	for _, endpoint := range mysqlEndpoints(config) {
+		endpointConfig := mysqlConfig.Clone()
+		endpointConfig.Addr = net.JoinHostPort(endpoint.Host, strconv.Itoa(int(endpoint.Port)))
+		if endpointConfig.TLS != nil {
+			endpointConfig.TLS.ServerName = endpoint.Host
+		}
 
-	connMaxLifetime := 5 * time.Minute // Default
-	if config.ConnMaxLifetime != "" {
-		if parsed, err := time.ParseDuration(config.ConnMaxLifetime); err == nil {
-			connMaxLifetime = parsed
+		connector, err := mysql.NewConnector(endpointConfig)
+		if err != nil {
+			_ = adapter.Close() // Best effort close on error
+			return nil, fmt.Errorf("failed to open MySQL connection: %w", err)
 		}
+		db := sql.OpenDB(connector)
+
+		// Set connection pool settings
+		configurePool(db, config)
+
+		adapter.endpoints = append(adapter.endpoints, &mysqlEndpoint{addr: endpointConfig.Addr, role: endpoint.role, db: db})
 	}
-	db.SetConnMaxLifetime(connMaxLifetime)
 
 	// Test connection
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-	defer cancel()
-
-	if err := db.PingContext(ctx); err != nil {
-		_ = db.Close() // Best effort close on error
+	if _, err := adapter.healthyEndpoint(context.Background(), nil); err != nil {
+		_ = adapter.Close() // Best effort close on error
 		return nil, fmt.Errorf("failed to ping MySQL: %w", err)
 	}
 
-	return &MySQLAdapter{db: db}, nil
+	return adapter, nil
 }
 
-// QueryNodes queries active nodes from the MySQL database
-func (a *MySQLAdapter) QueryNodes(ctx context.Context, config QueryConfig) ([]NodeRow, error) {
-	// Build column list - start with required fields
-	columns := []string{
-		config.ValueMappings.UID,
+// mysqlRoleEndpoint is an endpoint with its role (primary or replica)
+type mysqlRoleEndpoint struct {
+	Endpoint
+	role string
+}
+
+// mysqlEndpoints returns the configured endpoints in the order they are tried
+func mysqlEndpoints(config Config) []mysqlRoleEndpoint {
+	primaries := []mysqlRoleEndpoint{{Endpoint: Endpoint{Host: config.Host, Port: config.Port}, role: "primary"}}
+	for _, endpoint := range config.FailoverHosts {
+		primaries = append(primaries, mysqlRoleEndpoint{Endpoint: endpoint, role: "primary"})
+	}
+	var replicas []mysqlRoleEndpoint
+	for _, endpoint := range config.Replicas {
+		replicas = append(replicas, mysqlRoleEndpoint{Endpoint: endpoint, role: "replica"})
 	}
 
-	// Add HostOrURL only if specified (deprecated, optional since v1.1.11)
-	includeHostOrURL := config.ValueMappings.HostOrURL != ""
-	if includeHostOrURL {
-		columns = append(columns, config.ValueMappings.HostOrURL)
+	if config.ReadPreference == MySQLReadPreferencePrimary {
+		return append(primaries, replicas...)
 	}
+	return append(replicas, primaries...)
+}
 
-	// Add activate column
-	columns = append(columns, config.ValueMappings.Activate)
+// MySQL read preferences
+const (
+	MySQLReadPreferenceReplica = "replica"
+	MySQLReadPreferencePrimary = "primary"
+)
 
-	// Add extra columns in sorted order for stable queries
-	// Sort the keys to ensure consistent column order
-	extraKeys := make([]string, 0, len(config.ExtraMappings))
-	for key := range config.ExtraMappings {
-		extraKeys = append(extraKeys, key)
-	}
-	sort.Strings(extraKeys)
+// healthyEndpoint pings the endpoints in order, skipping skip, and makes the first reachable one
+// the endpoint in use. The error lists every endpoint that failed.
+func (a *MySQLAdapter) healthyEndpoint(ctx context.Context, skip *mysqlEndpoint) (*mysqlEndpoint, error) {
+	var errs []error
+	for _, endpoint := range a.endpoints {
+		if endpoint == skip {
+			continue
+		}
+		pingCtx, cancel := context.WithTimeout(ctx, mysqlPingTimeout)
+		err := endpoint.db.PingContext(pingCtx)
+		cancel()
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", endpoint, err))
+			continue
+		}
 
-	extraColumns := make([]string, 0, len(config.ExtraMappings))
-	for _, key := range extraKeys {
-		col := config.ExtraMappings[key]
-		columns = append(columns, col)
-		extraColumns = append(extraColumns, col)
+		a.mu.Lock()
+		a.db = endpoint.db
+		a.active = endpoint.String()
+		a.mu.Unlock()
+		return endpoint, nil
+	}
+	if len(errs) == 0 {
+		return nil, fmt.Errorf("no MySQL endpoint configured")
 	}
+	return nil, errors.Join(errs...)
+}
 
-	// Build query
-	query := fmt.Sprintf("SELECT %s FROM %s", joinColumns(columns), config.Table)
+// query runs fn against the database. With several endpoints, fn runs on the first healthy
+// endpoint in read preference order, so a recovered preferred endpoint is used again; when fn
+// fails because that endpoint became unreachable, it is retried once on the next healthy one.
+func (a *MySQLAdapter) query(ctx context.Context, fn func(db *sql.DB) error) error {
+	if len(a.endpoints) <= 1 {
+		a.mu.Lock()
+		db := a.db
+		a.mu.Unlock()
+		return fn(db)
+	}
 
-	// Execute query
-	rows, err := a.db.QueryContext(ctx, query)
+	endpoint, err := a.healthyEndpoint(ctx, nil)
 	if err != nil {
-		return nil, fmt.Errorf("failed to query nodes: %w", err)
+		return fmt.Errorf("no MySQL endpoint available: %w", err)
+	}
+	err = fn(endpoint.db)
+	if err == nil || ctx.Err() != nil {
+		return err
 	}
-	defer func() {
-		_ = rows.Close() // Best effort close
-	}()
 
-	// Scan results
-	var nodes []NodeRow
-	for rows.Next() {
-		row := NodeRow{
-			Extra: make(map[string]string),
-		}
+	// Query errors on a reachable endpoint (e.g. a missing column) are not failed over
+	pingCtx, cancel := context.WithTimeout(ctx, mysqlPingTimeout)
+	defer cancel()
+	if endpoint.db.PingContext(pingCtx) == nil {
+		return err
+	}
+	next, nextErr := a.healthyEndpoint(ctx, endpoint)
+	if nextErr != nil {
+		return fmt.Errorf("%s: %w; no other MySQL endpoint available: %w", endpoint, err, nextErr)
+	}
+	return fn(next.db)
+}
 
-		// Use NullString for required fields to handle NULL values
-		var uid, hostOrURL, activate sql.NullString
+// ActiveEndpoint returns the endpoint used by the last query, e.g. "db-replica-0:3306 (replica)"
+func (a *MySQLAdapter) ActiveEndpoint() string {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.active
+}
 
-		// Prepare scan destinations based on which columns were queried
-		scanDest := []interface{}{&uid}
-		if includeHostOrURL {
-			scanDest = append(scanDest, &hostOrURL)
+// QueryNodes queries active nodes from the MySQL database
+func (a *MySQLAdapter) QueryNodes(ctx context.Context, config QueryConfig) ([]NodeRow, error) {
+	var nodes []NodeRow
+	err := a.query(ctx, func(db *sql.DB) error {
+		var err error
+		nodes, err = querySQLNodes(ctx, db, mysqlDialect, config.Table, config)
+		if err != nil {
+			return err
 		}
-		scanDest = append(scanDest, &activate)
+		return loadSQLRelations(ctx, db, mysqlDialect, mysqlTable, config.Relations, nodes)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return nodes, nil
+}
 
-		// Add extra column destinations
-		extraValues := make([]sql.NullString, len(extraColumns))
-		for i := range extraValues {
-			scanDest = append(scanDest, &extraValues[i])
+// QueryChangedNodes queries rows changed since the given watermark (all rows when since is zero)
+func (a *MySQLAdapter) QueryChangedNodes(ctx context.Context, config QueryConfig, since time.Time) (*ChangeSet, error) {
+	var changes *ChangeSet
+	err := a.query(ctx, func(db *sql.DB) error {
+		var err error
+		changes, err = queryChangedSQLNodes(ctx, db, mysqlDialect, config.Table, config, since)
+		if err != nil {
+			return err
 		}
+		return loadSQLRelations(ctx, db, mysqlDialect, mysqlTable, config.Relations, changes.Active)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return changes, nil
+}
 
-		if err := rows.Scan(scanDest...); err != nil {
-			return nil, fmt.Errorf("failed to scan row: %w", err)
+// TableSchema reads the columns of table from INFORMATION_SCHEMA.COLUMNS
+// Tables are looked up in the connection's database unless qualified as "database.table".
+func (a *MySQLAdapter) TableSchema(ctx context.Context, table string) (*TableSchema, error) {
+	schema := &TableSchema{Table: table, CaseInsensitive: true}
+	err := a.query(ctx, func(db *sql.DB) error {
+		database, name := "", table
+		if i := strings.Index(table, "."); i >= 0 {
+			database, name = table[:i], table[i+1:]
 		}
-
-		// Convert NullString to string (NULL becomes empty string)
-		if uid.Valid {
-			row.UID = uid.String
+		rows, err := db.QueryContext(ctx,
+			"SELECT COLUMN_NAME, DATA_TYPE, IS_NULLABLE FROM INFORMATION_SCHEMA.COLUMNS "+
+				"WHERE TABLE_SCHEMA = COALESCE(NULLIF(?, ''), DATABASE()) AND TABLE_NAME = ? ORDER BY ORDINAL_POSITION",
+			database, name)
+		if err != nil {
+			return fmt.Errorf("failed to query columns: %w", err)
 		}
-		if hostOrURL.Valid {
-			row.HostOrURL = hostOrURL.String
+		schema.Columns, err = scanColumns(rows)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return schema, nil
+}
+
+// mysqlTable returns a child table name verbatim, like the source table
+func mysqlTable(table string) string {
+	return table
+}
+
+// Close closes the database connections
+func (a *MySQLAdapter) Close() error {
+	if len(a.endpoints) == 0 {
+		if a.db != nil {
+			return a.db.Close()
 		}
-		if activate.Valid {
-			row.Activate = activate.String
+		return nil
+	}
+	var errs []error
+	for _, endpoint := range a.endpoints {
+		if err := endpoint.db.Close(); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", endpoint, err))
 		}
+	}
+	return errors.Join(errs...)
+}
 
-		// Map extra values - Build column index map first for stable mapping
-		colIndex := make(map[string]int)
-		for i, col := range extraColumns {
-			colIndex[col] = i
-		}
+// MySQL TLS modes
+const (
+	MySQLTLSModeDisable    = "disable"
+	MySQLTLSModePreferred  = "preferred"
+	MySQLTLSModeRequired   = "required"
+	MySQLTLSModeVerifyCA   = "verify-ca"
+	MySQLTLSModeVerifyFull = "verify-full"
+)
 
-		// Map extra values using stable indices
-		for key, col := range config.ExtraMappings {
-			idx, ok := colIndex[col]
-			if !ok {
-				// Column not in result set (shouldn't happen)
-				row.Extra[key] = ""
-				continue
-			}
-			if extraValues[idx].Valid {
-				row.Extra[key] = extraValues[idx].String
-			} else {
-				row.Extra[key] = "" // Null values become empty strings
-			}
+// buildMySQLConfig builds the driver configuration from the datasource config.
+// Extra parameters are appended to the DSN verbatim and parsed by the driver itself,
+// so invalid values (e.g. a malformed timeout) are reported before connecting.
+func buildMySQLConfig(config Config) (*mysql.Config, error) {
+	keys := make([]string, 0, len(config.Params))
+	for key, value := range config.Params {
+		switch {
+		case key == "tls" || key == "parseTime":
+			return nil, fmt.Errorf("MySQL parameter %q is managed by the operator", key)
+		case key == "" || strings.ContainsAny(key, "&=") || strings.Contains(value, "&"):
+			return nil, fmt.Errorf("invalid MySQL parameter %q", key)
 		}
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
 
-		// Filter: only include active nodes
-		// Note: HostOrURL is deprecated since v1.1.11 and no longer required
-		if isActive(row.Activate) {
-			nodes = append(nodes, row)
-		}
+	// parseTime is required to scan the updated-at column for incremental sync
+	params := []string{"parseTime=true"}
+	for _, key := range keys {
+		params = append(params, key+"="+config.Params[key])
 	}
 
-	if err := rows.Err(); err != nil {
-		return nil, fmt.Errorf("error iterating rows: %w", err)
+	// Only the parameters go through the DSN parser; credentials are set directly so that
+	// special characters in the password need no escaping
+	mysqlConfig, err := mysql.ParseDSN("/?" + strings.Join(params, "&"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid MySQL parameters: %w", err)
 	}
+	mysqlConfig.User = config.Username
+	mysqlConfig.Passwd = config.Password
+	mysqlConfig.Net = "tcp"
+	mysqlConfig.Addr = net.JoinHostPort(config.Host, strconv.Itoa(int(config.Port)))
+	mysqlConfig.DBName = config.Database
 
-	return nodes, nil
+	tlsConfig, err := buildMySQLTLSConfig(config)
+	if err != nil {
+		return nil, err
+	}
+	mysqlConfig.TLS = tlsConfig
+	mysqlConfig.AllowFallbackToPlaintext = config.TLSMode == MySQLTLSModePreferred
+
+	return mysqlConfig, nil
 }
 
-// Close closes the database connection
-func (a *MySQLAdapter) Close() error {
-	if a.db != nil {
-		return a.db.Close()
+// buildMySQLTLSConfig returns the TLS configuration for the TLS mode (nil when TLS is disabled)
+func buildMySQLTLSConfig(config Config) (*tls.Config, error) {
+	tlsConfig := &tls.Config{ServerName: config.Host, MinVersion: tls.VersionTLS12}
+
+	switch config.TLSMode {
+	case "", MySQLTLSModeDisable:
+		return nil, nil
+	case MySQLTLSModePreferred, MySQLTLSModeRequired:
+		// Encrypt only; the server certificate is not verified
+		tlsConfig.InsecureSkipVerify = true //nolint:gosec // explicitly requested by the TLS mode
+	case MySQLTLSModeVerifyCA:
+		// Verify the chain against the CA but skip the host name check
+		tlsConfig.InsecureSkipVerify = true //nolint:gosec // chain is verified in VerifyConnection
+		tlsConfig.VerifyConnection = func(state tls.ConnectionState) error {
+			return verifyCertificateChain(state, tlsConfig.RootCAs)
+		}
+	case MySQLTLSModeVerifyFull:
+	default:
+		return nil, fmt.Errorf("unsupported MySQL TLS mode: %q", config.TLSMode)
+	}
+
+	if err := applyTLSMaterial(tlsConfig, config); err != nil {
+		return nil, err
+	}
+	return tlsConfig, nil
+}
+
+// verifyCertificateChain verifies the peer certificate chain against roots (system roots when nil)
+// without checking the host name
+func verifyCertificateChain(state tls.ConnectionState, roots *x509.CertPool) error {
+	if len(state.PeerCertificates) == 0 {
+		return fmt.Errorf("server did not present a certificate")
+	}
+	intermediates := x509.NewCertPool()
+	for _, cert := range state.PeerCertificates[1:] {
+		intermediates.AddCert(cert)
 	}
-	return nil
+	_, err := state.PeerCertificates[0].Verify(x509.VerifyOptions{
+		Roots:         roots,
+		Intermediates: intermediates,
+	})
+	return err
 }
 
 // Helper functions
 
 func joinColumns(columns []string) string {
-	result := ""
-	for i, col := range columns {
-		if i > 0 {
-			result += ", "
-		}
-		result += "`" + col + "`" // Escape column names
-	}
-	return result
+	return mysqlDialect.joinColumns(columns)
 }
 
 func isActive(value string) bool {
-	// Truthy values: "1", "true", "TRUE", "yes", etc.
-	switch value {
-	case "1", "true", "TRUE", "True", "yes", "YES", "Yes":
-		return true
-	default:
-		return false
-	}
+	return activation.IsTruthy(value)
+}
+
+// defaultActivateValue is the activate value of rows when no activate column is mapped
+const defaultActivateValue = "true"
+
+// activationRule builds the activation rule for the given value mappings
+func activationRule(mappings ValueMappings) (*activation.Rule, error) {
+	return activation.New(mappings.ActiveValues, mappings.ActivateExpression)
 }
@@ -18,8 +18,18 @@ package datasource
 
 import (
 	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
 	"database/sql"
+	"errors"
+	"math/big"
+	"regexp"
 	"testing"
+	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/stretchr/testify/assert"
@@ -71,6 +81,131 @@ func TestNewMySQLAdapter(t *testing.T) {
 	}
 }
 
+func TestBuildMySQLConfig(t *testing.T) {
+	base := Config{
+		Host:     "mysql.example.com",
+		Port:     3306,
+		Username: "reader",
+		Password: "p@ss:w/rd?",
+		Database: "nodes",
+	}
+
+	t.Run("defaults", func(t *testing.T) {
+		cfg, err := buildMySQLConfig(base)
+		require.NoError(t, err)
+		assert.Equal(t, "reader", cfg.User)
+		assert.Equal(t, "p@ss:w/rd?", cfg.Passwd)
+		assert.Equal(t, "tcp", cfg.Net)
+		assert.Equal(t, "mysql.example.com:3306", cfg.Addr)
+		assert.Equal(t, "nodes", cfg.DBName)
+		assert.True(t, cfg.ParseTime)
+		assert.Nil(t, cfg.TLS)
+	})
+
+	t.Run("driver params", func(t *testing.T) {
+		config := base
+		config.Params = map[string]string{"timeout": "5s", "readTimeout": "30s", "charset": "utf8mb4", "sql_mode": "ANSI"}
+		cfg, err := buildMySQLConfig(config)
+		require.NoError(t, err)
+		assert.Equal(t, 5*time.Second, cfg.Timeout)
+		assert.Equal(t, 30*time.Second, cfg.ReadTimeout)
+		assert.Contains(t, cfg.FormatDSN(), "charset=utf8mb4")
+		assert.Equal(t, "ANSI", cfg.Params["sql_mode"])
+	})
+
+	errorTests := []struct {
+		name          string
+		params        map[string]string
+		tlsMode       string
+		errorContains string
+	}{
+		{name: "invalid timeout", params: map[string]string{"timeout": "soon"}, errorContains: "invalid MySQL parameters"},
+		{name: "tls is managed", params: map[string]string{"tls": "true"}, errorContains: `"tls" is managed by the operator`},
+		{name: "parseTime is managed", params: map[string]string{"parseTime": "false"}, errorContains: `"parseTime" is managed by the operator`},
+		{name: "value with separator", params: map[string]string{"charset": "utf8&tls=false"}, errorContains: `invalid MySQL parameter "charset"`},
+		{name: "unknown tls mode", tlsMode: "sometimes", errorContains: `unsupported MySQL TLS mode: "sometimes"`},
+	}
+	for _, tt := range errorTests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := base
+			config.Params = tt.params
+			config.TLSMode = tt.tlsMode
+			_, err := buildMySQLConfig(config)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.errorContains)
+		})
+	}
+}
+
+func TestBuildMySQLTLSConfig(t *testing.T) {
+	tests := []struct {
+		name               string
+		tlsMode            string
+		wantTLS            bool
+		wantSkipVerify     bool
+		wantFallback       bool
+		wantVerifyCallback bool
+	}{
+		{name: "default", tlsMode: ""},
+		{name: "disable", tlsMode: MySQLTLSModeDisable},
+		{name: "preferred", tlsMode: MySQLTLSModePreferred, wantTLS: true, wantSkipVerify: true, wantFallback: true},
+		{name: "required", tlsMode: MySQLTLSModeRequired, wantTLS: true, wantSkipVerify: true},
+		{name: "verify-ca", tlsMode: MySQLTLSModeVerifyCA, wantTLS: true, wantSkipVerify: true, wantVerifyCallback: true},
+		{name: "verify-full", tlsMode: MySQLTLSModeVerifyFull, wantTLS: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg, err := buildMySQLConfig(Config{Host: "mysql.example.com", Port: 3306, TLSMode: tt.tlsMode})
+			require.NoError(t, err)
+			if !tt.wantTLS {
+				assert.Nil(t, cfg.TLS)
+				return
+			}
+			require.NotNil(t, cfg.TLS)
+			assert.Equal(t, "mysql.example.com", cfg.TLS.ServerName)
+			assert.Equal(t, tt.wantSkipVerify, cfg.TLS.InsecureSkipVerify)
+			assert.Equal(t, tt.wantFallback, cfg.AllowFallbackToPlaintext)
+			assert.Equal(t, tt.wantVerifyCallback, cfg.TLS.VerifyConnection != nil)
+		})
+	}
+
+	t.Run("invalid CA certificate", func(t *testing.T) {
+		_, err := buildMySQLConfig(Config{Host: "mysql.example.com", TLSMode: MySQLTLSModeVerifyFull, CACert: "garbage"})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to parse CA certificate")
+	})
+}
+
+func TestVerifyCertificateChain(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "mysql-ca"},
+		DNSNames:              []string{"db.internal"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+
+	trusted := x509.NewCertPool()
+	trusted.AddCert(cert)
+	state := tls.ConnectionState{PeerCertificates: []*x509.Certificate{cert}}
+
+	// The host name (db.internal) is not checked, only the chain
+	assert.NoError(t, verifyCertificateChain(state, trusted))
+	assert.Error(t, verifyCertificateChain(state, x509.NewCertPool()))
+	assert.Error(t, verifyCertificateChain(tls.ConnectionState{}, trusted))
+}
+
 func TestMySQLAdapter_QueryNodes(t *testing.T) {
 	tests := []struct {
 		name          string
@@ -429,3 +564,174 @@ func TestMySQLAdapter_Close(t *testing.T) {
 	err = nilAdapter.Close()
 	assert.NoError(t, err)
 }
+
+func TestMySQLAdapter_QueryChangedNodes(t *testing.T) {
+	queryConfig := QueryConfig{
+		Table: "nodes",
+		ValueMappings: ValueMappings{
+			UID:      "id",
+			Activate: "active",
+		},
+		UpdatedAtColumn: "updated_at",
+	}
+	t1 := time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC)
+	t2 := time.Date(2025, 1, 1, 11, 30, 0, 0, time.UTC)
+
+	t.Run("full query returns all rows and the highest watermark", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		defer func() {
+			_ = db.Close()
+		}()
+
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT `id`, `active`, `updated_at` FROM nodes")).
+			WithoutArgs().
+			WillReturnRows(sqlmock.NewRows([]string{"id", "active", "updated_at"}).
+				AddRow("node1", "1", t2).
+				AddRow("node2", "0", t1).
+				AddRow("node3", "1", nil))
+
+		adapter := &MySQLAdapter{db: db}
+		changes, err := adapter.QueryChangedNodes(context.Background(), queryConfig, time.Time{})
+		require.NoError(t, err)
+
+		assert.Equal(t, []string{"node1", "node3"}, []string{changes.Active[0].UID, changes.Active[1].UID})
+		assert.Equal(t, []string{"node2"}, changes.InactiveUIDs)
+		assert.True(t, changes.Watermark.Equal(t2))
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("incremental query filters by watermark", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		defer func() {
+			_ = db.Close()
+		}()
+
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT `id`, `active`, `updated_at` FROM nodes WHERE `updated_at` >= ?")).
+			WithArgs(t1).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "active", "updated_at"}))
+
+		adapter := &MySQLAdapter{db: db}
+		changes, err := adapter.QueryChangedNodes(context.Background(), queryConfig, t1)
+		require.NoError(t, err)
+
+		assert.Empty(t, changes.Active)
+		assert.Empty(t, changes.InactiveUIDs)
+		assert.True(t, changes.Watermark.Equal(t1), "watermark must not move backwards when nothing changed")
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("requires updated-at column", func(t *testing.T) {
+		adapter := &MySQLAdapter{}
+		_, err := adapter.QueryChangedNodes(context.Background(), QueryConfig{Table: "nodes"}, t1)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "updatedAtColumn is required")
+	})
+}
+
+func TestMySQLEndpoints(t *testing.T) {
+	config := Config{
+		Host:          "primary",
+		Port:          3306,
+		FailoverHosts: []Endpoint{{Host: "standby", Port: 3306}},
+		Replicas:      []Endpoint{{Host: "replica-0", Port: 3307}, {Host: "replica-1", Port: 3307}},
+	}
+	describe := func(endpoints []mysqlRoleEndpoint) []string {
+		var out []string
+		for _, endpoint := range endpoints {
+			out = append(out, endpoint.Host+"/"+endpoint.role)
+		}
+		return out
+	}
+
+	assert.Equal(t, []string{"replica-0/replica", "replica-1/replica", "primary/primary", "standby/primary"},
+		describe(mysqlEndpoints(config)), "replicas are preferred by default")
+
+	config.ReadPreference = MySQLReadPreferencePrimary
+	assert.Equal(t, []string{"primary/primary", "standby/primary", "replica-0/replica", "replica-1/replica"},
+		describe(mysqlEndpoints(config)))
+
+	assert.Equal(t, []string{"primary/primary"}, describe(mysqlEndpoints(Config{Host: "primary"})))
+}
+
+func TestNewMySQLAdapter_AllEndpointsUnreachable(t *testing.T) {
+	_, err := NewMySQLAdapter(Config{
+		Host:     "127.0.0.1",
+		Port:     1,
+		Replicas: []Endpoint{{Host: "127.0.0.1", Port: 2}},
+		Username: "root",
+		Database: "nodes",
+		Params:   map[string]string{"timeout": "1s"},
+	})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to ping MySQL")
+	assert.Contains(t, err.Error(), "127.0.0.1:2 (replica)")
+	assert.Contains(t, err.Error(), "127.0.0.1:1 (primary)")
+}
+
+func TestMySQLAdapter_Failover(t *testing.T) {
+	queryConfig := QueryConfig{Table: "nodes", ValueMappings: ValueMappings{UID: "id", Activate: "active"}}
+	query := regexp.QuoteMeta("SELECT `id`, `active` FROM nodes")
+	rows := func(uid string) *sqlmock.Rows {
+		return sqlmock.NewRows([]string{"id", "active"}).AddRow(uid, "1")
+	}
+
+	newEndpoint := func(t *testing.T, addr, role string) (*mysqlEndpoint, sqlmock.Sqlmock) {
+		db, mock, err := sqlmock.New(sqlmock.MonitorPingsOption(true))
+		require.NoError(t, err)
+		t.Cleanup(func() { _ = db.Close() })
+		return &mysqlEndpoint{addr: addr, role: role, db: db}, mock
+	}
+
+	replica, replicaMock := newEndpoint(t, "replica-0:3306", "replica")
+	primary, primaryMock := newEndpoint(t, "primary:3306", "primary")
+	adapter := &MySQLAdapter{endpoints: []*mysqlEndpoint{replica, primary}}
+
+	// Replica down: the primary serves the query
+	replicaMock.ExpectPing().WillReturnError(errors.New("connection refused"))
+	primaryMock.ExpectPing()
+	primaryMock.ExpectQuery(query).WillReturnRows(rows("from-primary"))
+	nodes, err := adapter.QueryNodes(context.Background(), queryConfig)
+	require.NoError(t, err)
+	assert.Equal(t, "from-primary", nodes[0].UID)
+	assert.Equal(t, "primary:3306 (primary)", adapter.ActiveEndpoint())
+
+	// Replica back: reads move off the primary again
+	replicaMock.ExpectPing()
+	replicaMock.ExpectQuery(query).WillReturnRows(rows("from-replica"))
+	nodes, err = adapter.QueryNodes(context.Background(), queryConfig)
+	require.NoError(t, err)
+	assert.Equal(t, "from-replica", nodes[0].UID)
+	assert.Equal(t, "replica-0:3306 (replica)", adapter.ActiveEndpoint())
+
+	// Replica lost during the query: retried on the primary
+	replicaMock.ExpectPing()
+	replicaMock.ExpectQuery(query).WillReturnError(errors.New("invalid connection"))
+	replicaMock.ExpectPing().WillReturnError(errors.New("connection refused"))
+	primaryMock.ExpectPing()
+	primaryMock.ExpectQuery(query).WillReturnRows(rows("retried"))
+	nodes, err = adapter.QueryNodes(context.Background(), queryConfig)
+	require.NoError(t, err)
+	assert.Equal(t, "retried", nodes[0].UID)
+
+	// Query errors on a reachable endpoint are returned as they are
+	replicaMock.ExpectPing()
+	replicaMock.ExpectQuery(query).WillReturnError(errors.New("unknown column 'active'"))
+	replicaMock.ExpectPing()
+	_, err = adapter.QueryNodes(context.Background(), queryConfig)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "unknown column")
+
+	// No endpoint reachable
+	replicaMock.ExpectPing().WillReturnError(errors.New("connection refused"))
+	primaryMock.ExpectPing().WillReturnError(errors.New("connection refused"))
+	_, err = adapter.QueryNodes(context.Background(), queryConfig)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "no MySQL endpoint available")
+	assert.Contains(t, err.Error(), "replica-0:3306 (replica): connection refused")
+	assert.Equal(t, "replica-0:3306 (replica)", adapter.ActiveEndpoint(), "the last used endpoint is kept")
+
+	assert.NoError(t, replicaMock.ExpectationsWereMet())
+	assert.NoError(t, primaryMock.ExpectationsWereMet())
+}
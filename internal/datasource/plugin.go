@@ -0,0 +1,199 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package datasource
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// The plugin protocol (see proto/lynq/datasource/v1/datasource.proto) uses google.protobuf.Struct
+// messages, so plugins can be written in any language without generated Lynq types:
+//
+//	rpc QueryNodes(google.protobuf.Struct) returns (stream google.protobuf.Struct)
+//
+// The request carries {"table": string, "config": {string: string}}; every response message
+// carries a batch of raw rows in {"rows": [object, ...]}. The query succeeds when the stream
+// ends with status OK. Rows are mapped, activated and typed by the operator exactly like http
+// items, so plugins only fetch data.
+const (
+	// PluginServiceName is the fully qualified gRPC service name plugins implement
+	PluginServiceName = "lynq.datasource.v1.Datasource"
+
+	pluginQueryNodesMethod = "/" + PluginServiceName + "/QueryNodes"
+
+	// defaultPluginTimeout bounds a single query when Config.Timeout is unset
+	defaultPluginTimeout = 30 * time.Second
+)
+
+// pluginQueryNodesStream describes the server-streaming QueryNodes call
+var pluginQueryNodesStream = &grpc.StreamDesc{StreamName: "QueryNodes", ServerStreams: true}
+
+// PluginAdapter implements Datasource by calling an out-of-process plugin over gRPC
+type PluginAdapter struct {
+	conn    *grpc.ClientConn
+	table   string
+	config  map[string]string
+	token   string
+	timeout time.Duration
+}
+
+// NewPluginAdapter connects to the plugin at config.Endpoint and checks that it is reachable
+func NewPluginAdapter(config Config) (*PluginAdapter, error) {
+	if config.Endpoint == "" {
+		return nil, fmt.Errorf("plugin endpoint is required")
+	}
+
+	creds := insecure.NewCredentials()
+	if config.PluginTLS {
+		tlsConfig := &tls.Config{MinVersion: tls.VersionTLS12}
+		if err := applyTLSMaterial(tlsConfig, config); err != nil {
+			return nil, err
+		}
+		creds = credentials.NewTLS(tlsConfig)
+	}
+
+	conn, err := grpc.NewClient(config.Endpoint, grpc.WithTransportCredentials(creds))
+	if err != nil {
+		return nil, fmt.Errorf("invalid plugin endpoint %q: %w", config.Endpoint, err)
+	}
+
+	timeout := config.Timeout
+	if timeout <= 0 {
+		timeout = defaultPluginTimeout
+	}
+
+	adapter := &PluginAdapter{
+		conn:    conn,
+		table:   config.Table,
+		config:  config.PluginConfig,
+		token:   config.BearerToken,
+		timeout: timeout,
+	}
+
+	// Check reachability like the SQL adapters ping their server
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	if err := adapter.ping(ctx); err != nil {
+		_ = conn.Close() // Best effort close on error
+		return nil, err
+	}
+
+	return adapter, nil
+}
+
+// ping calls the standard gRPC health service; plugins that do not serve it are assumed healthy
+// once the call reaches them
+func (a *PluginAdapter) ping(ctx context.Context) error {
+	response, err := healthpb.NewHealthClient(a.conn).Check(a.outgoingContext(ctx), &healthpb.HealthCheckRequest{})
+	if status.Code(err) == codes.Unimplemented {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to reach datasource plugin: %w", err)
+	}
+	if response.GetStatus() != healthpb.HealthCheckResponse_SERVING {
+		return fmt.Errorf("datasource plugin is %s", response.GetStatus())
+	}
+	return nil
+}
+
+// QueryNodes streams the plugin's rows and maps active rows to node rows
+// Any stream error fails the whole query so that partial results never cause node deletions.
+func (a *PluginAdapter) QueryNodes(ctx context.Context, config QueryConfig) ([]NodeRow, error) {
+	mapping, err := compileHTTPMapping(config)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, a.timeout)
+	defer cancel()
+
+	pluginConfig := make(map[string]interface{}, len(a.config))
+	for key, value := range a.config {
+		pluginConfig[key] = value
+	}
+	request, err := structpb.NewStruct(map[string]interface{}{
+		"table":  a.table,
+		"config": pluginConfig,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to build plugin request: %w", err)
+	}
+
+	stream, err := a.conn.NewStream(a.outgoingContext(ctx), pluginQueryNodesStream, pluginQueryNodesMethod)
+	if err != nil {
+		return nil, fmt.Errorf("datasource plugin query failed: %w", err)
+	}
+	if err := stream.SendMsg(request); err != nil {
+		return nil, fmt.Errorf("datasource plugin query failed: %w", err)
+	}
+	if err := stream.CloseSend(); err != nil {
+		return nil, fmt.Errorf("datasource plugin query failed: %w", err)
+	}
+
+	var nodes []NodeRow
+	for {
+		response := &structpb.Struct{}
+		err := stream.RecvMsg(response)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("datasource plugin query failed: %w", err)
+		}
+
+		for _, value := range response.GetFields()["rows"].GetListValue().GetValues() {
+			item := value.AsInterface()
+			// Filter: only include active nodes
+			if mapping.isActive(item) {
+				nodes = append(nodes, mapping.toRow(item))
+			}
+		}
+	}
+
+	return nodes, nil
+}
+
+// outgoingContext attaches the bearer token, if any, to outgoing calls
+func (a *PluginAdapter) outgoingContext(ctx context.Context) context.Context {
+	if a.token == "" {
+		return ctx
+	}
+	return metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer "+a.token)
+}
+
+// Close closes the connection to the plugin
+func (a *PluginAdapter) Close() error {
+	if a.conn != nil {
+		return a.conn.Close()
+	}
+	return nil
+}
@@ -0,0 +1,194 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package datasource
+
+import (
+	"context"
+	"net"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// testPlugin serves the plugin protocol with fixed row batches
+type testPlugin struct {
+	batches [][]interface{}
+	// err fails the stream after all batches were sent
+	err error
+
+	request       map[string]interface{}
+	authorization []string
+}
+
+func (p *testPlugin) queryNodes(_ interface{}, stream grpc.ServerStream) error {
+	request := &structpb.Struct{}
+	if err := stream.RecvMsg(request); err != nil {
+		return err
+	}
+	p.request = request.AsMap()
+	md, _ := metadata.FromIncomingContext(stream.Context())
+	p.authorization = md.Get("authorization")
+
+	for _, batch := range p.batches {
+		response, err := structpb.NewStruct(map[string]interface{}{"rows": batch})
+		if err != nil {
+			return err
+		}
+		if err := stream.SendMsg(response); err != nil {
+			return err
+		}
+	}
+	return p.err
+}
+
+// startTestPlugin serves plugin on a unix socket and returns its endpoint
+func startTestPlugin(t *testing.T, plugin *testPlugin, healthServer *health.Server) string {
+	t.Helper()
+	socket := filepath.Join(t.TempDir(), "plugin.sock")
+	listener, err := net.Listen("unix", socket)
+	require.NoError(t, err)
+
+	server := grpc.NewServer()
+	server.RegisterService(&grpc.ServiceDesc{
+		ServiceName: PluginServiceName,
+		HandlerType: (*interface{})(nil),
+		Streams: []grpc.StreamDesc{{
+			StreamName:    "QueryNodes",
+			ServerStreams: true,
+			Handler:       plugin.queryNodes,
+		}},
+	}, plugin)
+	if healthServer != nil {
+		healthpb.RegisterHealthServer(server, healthServer)
+	}
+	go func() {
+		_ = server.Serve(listener)
+	}()
+	t.Cleanup(server.Stop)
+
+	return "unix://" + socket
+}
+
+func TestPluginAdapter_QueryNodes(t *testing.T) {
+	plugin := &testPlugin{batches: [][]interface{}{
+		{
+			map[string]interface{}{"id": "acme", "active": true, "plan": "pro", "seats": 10, "settings": map[string]interface{}{"sso": true}},
+			map[string]interface{}{"id": "beta", "active": false, "plan": "free"},
+		},
+		{
+			map[string]interface{}{"id": 1003, "active": "1", "plan": nil, "seats": 2.5},
+		},
+	}}
+	endpoint := startTestPlugin(t, plugin, health.NewServer())
+
+	adapter, err := NewPluginAdapter(Config{
+		Endpoint:     endpoint,
+		Table:        "tenants",
+		PluginConfig: map[string]string{"site": "eu"},
+		BearerToken:  "secret-token",
+	})
+	require.NoError(t, err)
+	defer func() {
+		_ = adapter.Close()
+	}()
+
+	rows, err := adapter.QueryNodes(context.Background(), QueryConfig{
+		ValueMappings: ValueMappings{UID: "id", Activate: "active"},
+		ExtraMappings: map[string]string{"plan": "plan", "seats": "seats", "sso": "settings.sso"},
+		ExtraTypes:    map[string]ValueType{"seats": ValueTypeAuto, "sso": ValueTypeBool},
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, []NodeRow{
+		{
+			UID: "acme", Activate: "true",
+			Extra: map[string]string{"plan": "pro", "seats": "10", "sso": "true"},
+			Typed: map[string]interface{}{"seats": int64(10), "sso": true},
+		},
+		{
+			UID: "1003", Activate: "1",
+			Extra: map[string]string{"plan": "", "seats": "2.5", "sso": ""},
+			Typed: map[string]interface{}{"seats": 2.5},
+		},
+	}, rows)
+	assert.Equal(t, map[string]interface{}{"table": "tenants", "config": map[string]interface{}{"site": "eu"}}, plugin.request)
+	assert.Equal(t, []string{"Bearer secret-token"}, plugin.authorization)
+}
+
+func TestPluginAdapter_QueryNodesStreamError(t *testing.T) {
+	plugin := &testPlugin{
+		batches: [][]interface{}{{map[string]interface{}{"id": "acme", "active": true}}},
+		err:     status.Error(codes.Unavailable, "cmdb is down"),
+	}
+	// Plugins without a health service are accepted
+	endpoint := startTestPlugin(t, plugin, nil)
+
+	adapter, err := NewPluginAdapter(Config{Endpoint: endpoint})
+	require.NoError(t, err)
+	defer func() {
+		_ = adapter.Close()
+	}()
+
+	rows, err := adapter.QueryNodes(context.Background(), QueryConfig{ValueMappings: ValueMappings{UID: "id", Activate: "active"}})
+	require.Error(t, err, "partial results must not be returned")
+	assert.Nil(t, rows)
+	assert.Contains(t, err.Error(), "cmdb is down")
+	assert.Nil(t, plugin.authorization)
+}
+
+func TestNewPluginAdapter_Errors(t *testing.T) {
+	t.Run("endpoint required", func(t *testing.T) {
+		_, err := NewPluginAdapter(Config{})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "plugin endpoint is required")
+	})
+
+	t.Run("not serving", func(t *testing.T) {
+		healthServer := health.NewServer()
+		healthServer.SetServingStatus("", healthpb.HealthCheckResponse_NOT_SERVING)
+		endpoint := startTestPlugin(t, &testPlugin{}, healthServer)
+
+		_, err := NewPluginAdapter(Config{Endpoint: endpoint})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "datasource plugin is NOT_SERVING")
+	})
+
+	t.Run("unreachable", func(t *testing.T) {
+		_, err := NewPluginAdapter(Config{
+			Endpoint: "unix://" + filepath.Join(t.TempDir(), "missing.sock"),
+			Timeout:  time.Second,
+		})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to reach datasource plugin")
+	})
+
+	t.Run("invalid tls material", func(t *testing.T) {
+		_, err := NewPluginAdapter(Config{Endpoint: "localhost:9000", PluginTLS: true, CACert: "not a certificate"})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to parse CA certificate")
+	})
+}
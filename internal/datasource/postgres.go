@@ -0,0 +1,166 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package datasource
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/stdlib"
+)
+
+// defaultPostgreSQLSchema is used when no schema is configured
+const defaultPostgreSQLSchema = "public"
+
+// PostgreSQLAdapter implements the Datasource interface for PostgreSQL
+type PostgreSQLAdapter struct {
+	db     *sql.DB
+	schema string
+}
+
+// NewPostgreSQLAdapter creates a new PostgreSQL datasource adapter
+func NewPostgreSQLAdapter(config Config) (*PostgreSQLAdapter, error) {
+	connConfig, err := pgx.ParseConfig(buildPostgreSQLConnString(config))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse PostgreSQL connection config: %w", err)
+	}
+
+	// Inject CA/client certificates loaded from Secrets into every TLS attempt
+	// (the primary connection plus any sslmode fallbacks such as prefer/allow)
+	if connConfig.TLSConfig != nil {
+		if err := applyTLSMaterial(connConfig.TLSConfig, config); err != nil {
+			return nil, err
+		}
+	}
+	for _, fallback := range connConfig.Fallbacks {
+		if fallback.TLSConfig != nil {
+			if err := applyTLSMaterial(fallback.TLSConfig, config); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	db := stdlib.OpenDB(*connConfig)
+
+	// Set connection pool settings
+	configurePool(db, config)
+
+	// Test connection
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := db.PingContext(ctx); err != nil {
+		_ = db.Close() // Best effort close on error
+		return nil, fmt.Errorf("failed to ping PostgreSQL: %w", err)
+	}
+
+	schema := config.Schema
+	if schema == "" {
+		schema = defaultPostgreSQLSchema
+	}
+
+	return &PostgreSQLAdapter{db: db, schema: schema}, nil
+}
+
+// QueryNodes queries active nodes from the PostgreSQL database
+func (a *PostgreSQLAdapter) QueryNodes(ctx context.Context, config QueryConfig) ([]NodeRow, error) {
+	nodes, err := querySQLNodes(ctx, a.db, postgresDialect, a.qualifiedTable(config.Table), config)
+	if err != nil {
+		return nil, err
+	}
+	if err := loadSQLRelations(ctx, a.db, postgresDialect, a.qualifiedTable, config.Relations, nodes); err != nil {
+		return nil, err
+	}
+	return nodes, nil
+}
+
+// QueryChangedNodes queries rows changed since the given watermark (all rows when since is zero)
+func (a *PostgreSQLAdapter) QueryChangedNodes(ctx context.Context, config QueryConfig, since time.Time) (*ChangeSet, error) {
+	changes, err := queryChangedSQLNodes(ctx, a.db, postgresDialect, a.qualifiedTable(config.Table), config, since)
+	if err != nil {
+		return nil, err
+	}
+	if err := loadSQLRelations(ctx, a.db, postgresDialect, a.qualifiedTable, config.Relations, changes.Active); err != nil {
+		return nil, err
+	}
+	return changes, nil
+}
+
+// Close closes the database connection
+func (a *PostgreSQLAdapter) Close() error {
+	if a.db != nil {
+		return a.db.Close()
+	}
+	return nil
+}
+
+// TableSchema reads the columns of table in the configured schema from information_schema.columns
+// Types are the udt names (int4, varchar, timestamptz, ...).
+func (a *PostgreSQLAdapter) TableSchema(ctx context.Context, table string) (*TableSchema, error) {
+	schema := a.schema
+	if schema == "" {
+		schema = defaultPostgreSQLSchema
+	}
+	rows, err := a.db.QueryContext(ctx,
+		"SELECT column_name, udt_name, is_nullable FROM information_schema.columns "+
+			"WHERE table_schema = $1 AND table_name = $2 ORDER BY ordinal_position",
+		schema, table)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query columns: %w", err)
+	}
+	columns, err := scanColumns(rows)
+	if err != nil {
+		return nil, err
+	}
+	return &TableSchema{Table: table, Columns: columns}, nil
+}
+
+// qualifiedTable returns the schema-qualified, quoted table name
+func (a *PostgreSQLAdapter) qualifiedTable(table string) string {
+	schema := a.schema
+	if schema == "" {
+		schema = defaultPostgreSQLSchema
+	}
+	return postgresDialect.quoteIdentifier(schema) + "." + postgresDialect.quoteIdentifier(table)
+}
+
+// buildPostgreSQLConnString builds a postgres:// URL from the datasource config.
+// Credentials and parameters are URL-encoded so special characters are preserved.
+func buildPostgreSQLConnString(config Config) string {
+	sslMode := config.SSLMode
+	if sslMode == "" {
+		sslMode = "prefer"
+	}
+
+	query := url.Values{}
+	query.Set("sslmode", sslMode)
+
+	u := url.URL{
+		Scheme:   "postgres",
+		User:     url.UserPassword(config.Username, config.Password),
+		Host:     net.JoinHostPort(config.Host, strconv.Itoa(int(config.Port))),
+		Path:     "/" + config.Database,
+		RawQuery: query.Encode(),
+	}
+	return u.String()
+}
@@ -0,0 +1,132 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package datasource
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// relationBatchSize bounds the number of UIDs bound to a single IN (...) list
+const relationBatchSize = 500
+
+// loadSQLRelations queries the child rows of every relation for the given nodes and stores
+// them in NodeRow.Relations. Child tables are qualified with qualify, like the source table.
+func loadSQLRelations(ctx context.Context, db *sql.DB, dialect sqlDialect, qualify func(table string) string, relations []Relation, nodes []NodeRow) error {
+	if len(relations) == 0 || len(nodes) == 0 {
+		return nil
+	}
+
+	uids := make([]string, 0, len(nodes))
+	seen := make(map[string]bool, len(nodes))
+	for _, node := range nodes {
+		if !seen[node.UID] {
+			seen[node.UID] = true
+			uids = append(uids, node.UID)
+		}
+	}
+
+	for _, relation := range relations {
+		children := make(map[string][]map[string]string)
+		for start := 0; start < len(uids); start += relationBatchSize {
+			end := min(start+relationBatchSize, len(uids))
+			if err := selectRelationRows(ctx, db, dialect, qualify(relation.Table), relation, uids[start:end], children); err != nil {
+				return fmt.Errorf("relation %s: %w", relation.Name, err)
+			}
+		}
+
+		for i := range nodes {
+			if nodes[i].Relations == nil {
+				nodes[i].Relations = make(map[string][]map[string]string, len(relations))
+			}
+			items := children[nodes[i].UID]
+			if items == nil {
+				items = []map[string]string{}
+			}
+			nodes[i].Relations[relation.Name] = items
+		}
+	}
+	return nil
+}
+
+// selectRelationRows selects the child rows referencing uids and appends them to children,
+// keyed by foreign key value
+func selectRelationRows(ctx context.Context, db *sql.DB, dialect sqlDialect, table string, relation Relation, uids []string, children map[string][]map[string]string) error {
+	fields := make([]string, 0, len(relation.Mappings))
+	for field := range relation.Mappings {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+
+	columns := []string{relation.ForeignKey}
+	for _, field := range fields {
+		columns = append(columns, relation.Mappings[field])
+	}
+
+	// Order by the foreign key first so each node's items stay grouped, then by the
+	// order column (or all mapped columns) so that the item order is stable across syncs
+	orderBy := []string{relation.ForeignKey}
+	if relation.OrderBy != "" {
+		orderBy = append(orderBy, relation.OrderBy)
+	} else {
+		orderBy = append(orderBy, columns[1:]...)
+	}
+
+	args := make([]interface{}, len(uids))
+	placeholders := make([]string, len(uids))
+	for i, uid := range uids {
+		args[i] = uid
+		placeholders[i] = dialect.placeholder(i + 1)
+	}
+
+	query := fmt.Sprintf("SELECT %s FROM %s WHERE %s IN (%s) ORDER BY %s",
+		dialect.joinColumns(columns), table, dialect.quoteIdentifier(relation.ForeignKey),
+		strings.Join(placeholders, ", "), dialect.joinColumns(orderBy))
+
+	rows, err := db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("failed to query child rows: %w", err)
+	}
+	defer func() {
+		_ = rows.Close() // Best effort close
+	}()
+
+	for rows.Next() {
+		var foreignKey sql.NullString
+		values := make([]sql.NullString, len(fields))
+		scanDest := []interface{}{&foreignKey}
+		for i := range values {
+			scanDest = append(scanDest, &values[i])
+		}
+		if err := rows.Scan(scanDest...); err != nil {
+			return fmt.Errorf("failed to scan child row: %w", err)
+		}
+
+		item := make(map[string]string, len(fields))
+		for i, field := range fields {
+			item[field] = values[i].String // NULL becomes an empty string
+		}
+		children[foreignKey.String] = append(children[foreignKey.String], item)
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("error iterating child rows: %w", err)
+	}
+	return nil
+}
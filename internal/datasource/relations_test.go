@@ -0,0 +1,180 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package datasource
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var domainsRelation = Relation{
+	Name:       "domains",
+	Table:      "tenant_domains",
+	ForeignKey: "tenant_id",
+	Mappings:   map[string]string{"host": "hostname", "primary": "is_primary"},
+}
+
+func TestMySQLAdapter_QueryNodesRelations(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer func() {
+		_ = db.Close()
+	}()
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT `id`, `active` FROM tenants")).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "active"}).
+			AddRow("acme", "1").
+			AddRow("beta", "1").
+			AddRow("gone", "0"))
+	mock.ExpectQuery(regexp.QuoteMeta(
+		"SELECT `tenant_id`, `hostname`, `is_primary` FROM tenant_domains WHERE `tenant_id` IN (?, ?) ORDER BY `tenant_id`, `hostname`, `is_primary`")).
+		WithArgs("acme", "beta").
+		WillReturnRows(sqlmock.NewRows([]string{"tenant_id", "hostname", "is_primary"}).
+			AddRow("acme", "acme.example.com", "1").
+			AddRow("acme", "www.acme.io", nil))
+
+	adapter := &MySQLAdapter{db: db}
+	got, err := adapter.QueryNodes(context.Background(), QueryConfig{
+		Table:         "tenants",
+		ValueMappings: ValueMappings{UID: "id", Activate: "active"},
+		Relations:     []Relation{domainsRelation},
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, []NodeRow{
+		{
+			UID: "acme", Activate: "1", Extra: map[string]string{},
+			Relations: map[string][]map[string]string{"domains": {
+				{"host": "acme.example.com", "primary": "1"},
+				{"host": "www.acme.io", "primary": ""},
+			}},
+		},
+		{
+			UID: "beta", Activate: "1", Extra: map[string]string{},
+			Relations: map[string][]map[string]string{"domains": {}},
+		},
+	}, got)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestPostgreSQLAdapter_QueryChangedNodesRelations(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer func() {
+		_ = db.Close()
+	}()
+
+	since := time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC)
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT "id", "active", "updated_at" FROM "app"."tenants" WHERE "updated_at" >= $1`)).
+		WithArgs(since).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "active", "updated_at"}).
+			AddRow("acme", "true", since).
+			AddRow("beta", "false", since))
+	// Only active changed rows load relations
+	mock.ExpectQuery(regexp.QuoteMeta(
+		`SELECT "tenant_id", "hostname", "is_primary" FROM "app"."tenant_domains" WHERE "tenant_id" IN ($1) ORDER BY "tenant_id", "position"`)).
+		WithArgs("acme").
+		WillReturnRows(sqlmock.NewRows([]string{"tenant_id", "hostname", "is_primary"}).
+			AddRow("acme", "acme.example.com", "true"))
+
+	relation := domainsRelation
+	relation.OrderBy = "position"
+	adapter := &PostgreSQLAdapter{db: db, schema: "app"}
+	changes, err := adapter.QueryChangedNodes(context.Background(), QueryConfig{
+		Table:           "tenants",
+		ValueMappings:   ValueMappings{UID: "id", Activate: "active"},
+		UpdatedAtColumn: "updated_at",
+		Relations:       []Relation{relation},
+	}, since)
+	require.NoError(t, err)
+
+	require.Len(t, changes.Active, 1)
+	assert.Equal(t, map[string][]map[string]string{"domains": {{"host": "acme.example.com", "primary": "true"}}},
+		changes.Active[0].Relations)
+	assert.Equal(t, []string{"beta"}, changes.InactiveUIDs)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestLoadSQLRelations_Batches(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer func() {
+		_ = db.Close()
+	}()
+
+	nodes := make([]NodeRow, relationBatchSize+1)
+	firstBatch := make([]driver.Value, relationBatchSize)
+	for i := range nodes {
+		nodes[i].UID = fmt.Sprintf("node-%d", i)
+		if i < relationBatchSize {
+			firstBatch[i] = nodes[i].UID
+		}
+	}
+	columns := []string{"tenant_id", "hostname", "is_primary"}
+	mock.ExpectQuery("SELECT .* FROM tenant_domains WHERE").
+		WithArgs(firstBatch...).
+		WillReturnRows(sqlmock.NewRows(columns).AddRow("node-0", "a.example.com", "1"))
+	mock.ExpectQuery("SELECT .* FROM tenant_domains WHERE").
+		WithArgs(nodes[relationBatchSize].UID).
+		WillReturnRows(sqlmock.NewRows(columns).AddRow(nodes[relationBatchSize].UID, "b.example.com", "0"))
+
+	err = loadSQLRelations(context.Background(), db, mysqlDialect, mysqlTable, []Relation{domainsRelation}, nodes)
+	require.NoError(t, err)
+	assert.Len(t, nodes[0].Relations["domains"], 1)
+	assert.Empty(t, nodes[1].Relations["domains"])
+	assert.Equal(t, "b.example.com", nodes[relationBatchSize].Relations["domains"][0]["host"])
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestLoadSQLRelations_QueryError(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer func() {
+		_ = db.Close()
+	}()
+
+	mock.ExpectQuery("SELECT .* FROM tenant_domains").WillReturnError(errors.New("table not found"))
+
+	nodes := []NodeRow{{UID: "acme"}}
+	err = loadSQLRelations(context.Background(), db, mysqlDialect, mysqlTable, []Relation{domainsRelation}, nodes)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "relation domains: failed to query child rows: table not found")
+}
+
+func TestNodeRow_ValuesRelations(t *testing.T) {
+	row := NodeRow{
+		Extra: map[string]string{"plan": "pro"},
+		Relations: map[string][]map[string]string{
+			"domains": {{"host": "acme.example.com"}},
+			"regions": {},
+		},
+	}
+	assert.Equal(t, map[string]interface{}{
+		"plan":    "pro",
+		"domains": []interface{}{map[string]interface{}{"host": "acme.example.com"}},
+		"regions": []interface{}{},
+	}, row.Values())
+}
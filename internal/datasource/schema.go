@@ -0,0 +1,277 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package datasource
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// SchemaInspector is implemented by adapters that can describe the columns of a table
+type SchemaInspector interface {
+	// TableSchema returns the columns of table; a table without columns does not exist
+	TableSchema(ctx context.Context, table string) (*TableSchema, error)
+}
+
+// TableSchema describes the columns of a table
+type TableSchema struct {
+	Table   string
+	Columns []Column
+	// CaseInsensitive is true when the database matches column names case-insensitively (MySQL)
+	CaseInsensitive bool
+}
+
+// Column describes a table column
+type Column struct {
+	Name string
+	// Type is the database type name, e.g. VARCHAR, int4 or DATETIME
+	Type     string
+	Nullable bool
+}
+
+// Schema issue reasons
+const (
+	SchemaIssueTableNotFound  = "TableNotFound"
+	SchemaIssueColumnNotFound = "ColumnNotFound"
+	SchemaIssueTypeMismatch   = "TypeMismatch"
+	SchemaIssueNullableUID    = "NullableUID"
+)
+
+// SchemaIssue is a problem found by comparing the hub mappings to the table schema
+type SchemaIssue struct {
+	// Field is the hub field the column comes from, e.g. extraValueMappings.planId
+	Field string
+	// Column is the column name ("" for TableNotFound)
+	Column string
+	// Reason is one of the SchemaIssue* reasons
+	Reason string
+	// Message is a human-readable description
+	Message string
+}
+
+// CheckSchema inspects the source table and the relation tables and returns the mapped columns
+// that are missing, have a type that cannot hold the declared value type, or a nullable UID
+func CheckSchema(ctx context.Context, inspector SchemaInspector, config QueryConfig) ([]SchemaIssue, error) {
+	schema, err := inspector.TableSchema(ctx, config.Table)
+	if err != nil {
+		return nil, fmt.Errorf("failed to inspect table %s: %w", config.Table, err)
+	}
+	issues := validateSourceSchema(schema, config)
+
+	for _, relation := range config.Relations {
+		childSchema, err := inspector.TableSchema(ctx, relation.Table)
+		if err != nil {
+			return nil, fmt.Errorf("failed to inspect table %s: %w", relation.Table, err)
+		}
+		issues = append(issues, validateRelationSchema(childSchema, relation)...)
+	}
+	return issues, nil
+}
+
+// schemaColumn is a mapped column with the hub field it comes from
+type schemaColumn struct {
+	field  string
+	column string
+}
+
+// validateSourceSchema checks the columns read from the source table
+func validateSourceSchema(schema *TableSchema, config QueryConfig) []SchemaIssue {
+	if len(schema.Columns) == 0 {
+		return []SchemaIssue{tableNotFound(schema.Table)}
+	}
+
+	mappings := config.ValueMappings
+	columns := []schemaColumn{{"valueMappings.uid", mappings.UID}}
+	if mappings.HostOrURL != "" {
+		columns = append(columns, schemaColumn{"valueMappings.hostOrUrl", mappings.HostOrURL})
+	}
+	if mappings.Activate != "" {
+		columns = append(columns, schemaColumn{"valueMappings.activate", mappings.Activate})
+	}
+	if rule, err := activationRule(mappings); err == nil {
+		for _, column := range rule.Columns() {
+			columns = append(columns, schemaColumn{"valueMappings.activateExpression", column})
+		}
+	}
+	for _, key := range sortedKeys(config.ExtraMappings) {
+		columns = append(columns, schemaColumn{"extraValueMappings." + key, config.ExtraMappings[key]})
+	}
+	if config.UpdatedAtColumn != "" {
+		columns = append(columns, schemaColumn{"source.updatedAtColumn", config.UpdatedAtColumn})
+	}
+	for _, filter := range config.Filters {
+		columns = append(columns, schemaColumn{"filter", filter.Column})
+	}
+
+	issues := missingColumns(schema, columns)
+
+	if uid, ok := schema.column(mappings.UID); ok && uid.Nullable {
+		issues = append(issues, SchemaIssue{
+			Field:   "valueMappings.uid",
+			Column:  uid.Name,
+			Reason:  SchemaIssueNullableUID,
+			Message: fmt.Sprintf("uid column `%s` in `%s` is nullable; a NULL uid cannot identify a node", uid.Name, schema.Table),
+		})
+	}
+
+	for _, key := range sortedKeys(config.ExtraMappings) {
+		column, ok := schema.column(config.ExtraMappings[key])
+		if !ok {
+			continue
+		}
+		declared := config.ExtraTypes[key]
+		if !valueTypeFits(declared, sqlColumnValueType(column.Type)) {
+			issues = append(issues, SchemaIssue{
+				Field:  "extraValueTypes." + key,
+				Column: column.Name,
+				Reason: SchemaIssueTypeMismatch,
+				Message: fmt.Sprintf("column `%s` in `%s` is %s and cannot hold %s values (extraValueTypes.%s)",
+					column.Name, schema.Table, column.Type, declared, key),
+			})
+		}
+	}
+
+	if column, ok := schema.column(config.UpdatedAtColumn); ok && config.UpdatedAtColumn != "" && !isTimeColumnType(column.Type) {
+		issues = append(issues, SchemaIssue{
+			Field:   "source.updatedAtColumn",
+			Column:  column.Name,
+			Reason:  SchemaIssueTypeMismatch,
+			Message: fmt.Sprintf("column `%s` in `%s` is %s, not a date/time column (source.updatedAtColumn)", column.Name, schema.Table, column.Type),
+		})
+	}
+
+	return issues
+}
+
+// validateRelationSchema checks the columns read from a relation's child table
+func validateRelationSchema(schema *TableSchema, relation Relation) []SchemaIssue {
+	if len(schema.Columns) == 0 {
+		issue := tableNotFound(schema.Table)
+		issue.Field = "relations." + relation.Name
+		issue.Message += " (relations." + relation.Name + ")"
+		return []SchemaIssue{issue}
+	}
+
+	field := "relations." + relation.Name
+	columns := []schemaColumn{{field + ".foreignKey", relation.ForeignKey}}
+	for _, key := range sortedKeys(relation.Mappings) {
+		columns = append(columns, schemaColumn{field + ".valueMappings." + key, relation.Mappings[key]})
+	}
+	if relation.OrderBy != "" {
+		columns = append(columns, schemaColumn{field + ".orderBy", relation.OrderBy})
+	}
+	return missingColumns(schema, columns)
+}
+
+// missingColumns reports every mapped column that is not in the schema, once per column and field
+func missingColumns(schema *TableSchema, columns []schemaColumn) []SchemaIssue {
+	var issues []SchemaIssue
+	seen := make(map[schemaColumn]bool, len(columns))
+	for _, c := range columns {
+		if seen[c] {
+			continue
+		}
+		seen[c] = true
+		if _, ok := schema.column(c.column); !ok {
+			issues = append(issues, SchemaIssue{
+				Field:   c.field,
+				Column:  c.column,
+				Reason:  SchemaIssueColumnNotFound,
+				Message: fmt.Sprintf("column `%s` not found in `%s` (%s)", c.column, schema.Table, c.field),
+			})
+		}
+	}
+	return issues
+}
+
+// tableNotFound reports a table without columns
+func tableNotFound(table string) SchemaIssue {
+	return SchemaIssue{
+		Reason:  SchemaIssueTableNotFound,
+		Message: fmt.Sprintf("table `%s` not found", table),
+	}
+}
+
+// column looks up a column by name
+func (s *TableSchema) column(name string) (Column, bool) {
+	for _, column := range s.Columns {
+		if column.Name == name || (s.CaseInsensitive && strings.EqualFold(column.Name, name)) {
+			return column, true
+		}
+	}
+	return Column{}, false
+}
+
+// valueTypeFits reports whether a column of the native type can hold values of the declared type
+// Text columns can hold anything; numeric columns hold numbers and (TINYINT) booleans.
+func valueTypeFits(declared, native ValueType) bool {
+	switch declared {
+	case "", ValueTypeString, ValueTypeAuto:
+		return true
+	}
+	switch native {
+	case ValueTypeString, declared:
+		return true
+	case ValueTypeInt:
+		return declared == ValueTypeFloat || declared == ValueTypeBool
+	case ValueTypeFloat:
+		return declared == ValueTypeInt
+	default:
+		return false
+	}
+}
+
+// isTimeColumnType reports whether a database type name is a date/time type
+func isTimeColumnType(databaseTypeName string) bool {
+	name := strings.ToUpper(databaseTypeName)
+	return strings.Contains(name, "DATE") || strings.Contains(name, "TIME")
+}
+
+// sortedKeys returns the keys of a string map in sorted order
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// scanColumns reads (name, type, is_nullable) rows of an information_schema query
+func scanColumns(rows *sql.Rows) ([]Column, error) {
+	defer func() {
+		_ = rows.Close() // Best effort close
+	}()
+
+	var columns []Column
+	for rows.Next() {
+		var column Column
+		var nullable string
+		if err := rows.Scan(&column.Name, &column.Type, &nullable); err != nil {
+			return nil, fmt.Errorf("failed to scan column: %w", err)
+		}
+		column.Nullable = strings.EqualFold(nullable, "YES")
+		columns = append(columns, column)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating columns: %w", err)
+	}
+	return columns, nil
+}
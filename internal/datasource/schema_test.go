@@ -0,0 +1,252 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package datasource
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeInspector returns fixed schemas by table name
+type fakeInspector map[string]*TableSchema
+
+func (f fakeInspector) TableSchema(_ context.Context, table string) (*TableSchema, error) {
+	if schema, ok := f[table]; ok {
+		return schema, nil
+	}
+	if table == "broken" {
+		return nil, errors.New("permission denied")
+	}
+	return &TableSchema{Table: table}, nil
+}
+
+func TestCheckSchema(t *testing.T) {
+	nodes := &TableSchema{
+		Table: "nodes",
+		Columns: []Column{
+			{Name: "id", Type: "varchar"},
+			{Name: "active", Type: "bool"},
+			{Name: "plan", Type: "varchar", Nullable: true},
+			{Name: "replicas", Type: "int4", Nullable: true},
+			{Name: "created_at", Type: "timestamptz"},
+		},
+	}
+	domains := &TableSchema{
+		Table:   "domains",
+		Columns: []Column{{Name: "node_id", Type: "varchar"}, {Name: "host", Type: "varchar"}},
+	}
+
+	tests := []struct {
+		name      string
+		inspector fakeInspector
+		config    QueryConfig
+		want      []SchemaIssue
+		errSubstr string
+	}{
+		{
+			name:      "matching schema",
+			inspector: fakeInspector{"nodes": nodes, "domains": domains},
+			config: QueryConfig{
+				Table:           "nodes",
+				ValueMappings:   ValueMappings{UID: "id", Activate: "active"},
+				ExtraMappings:   map[string]string{"plan": "plan", "replicas": "replicas"},
+				ExtraTypes:      map[string]ValueType{"replicas": ValueTypeInt, "plan": ValueTypeString},
+				UpdatedAtColumn: "created_at",
+				Relations:       []Relation{{Name: "domains", Table: "domains", ForeignKey: "node_id", Mappings: map[string]string{"host": "host"}}},
+			},
+		},
+		{
+			name:      "missing columns",
+			inspector: fakeInspector{"nodes": nodes},
+			config: QueryConfig{
+				Table:         "nodes",
+				ValueMappings: ValueMappings{UID: "id", ActivateExpression: "deleted_at IS NULL"},
+				ExtraMappings: map[string]string{"planId": "plan_id"},
+			},
+			want: []SchemaIssue{
+				{
+					Field:   "valueMappings.activateExpression",
+					Column:  "deleted_at",
+					Reason:  SchemaIssueColumnNotFound,
+					Message: "column `deleted_at` not found in `nodes` (valueMappings.activateExpression)",
+				},
+				{
+					Field:   "extraValueMappings.planId",
+					Column:  "plan_id",
+					Reason:  SchemaIssueColumnNotFound,
+					Message: "column `plan_id` not found in `nodes` (extraValueMappings.planId)",
+				},
+			},
+		},
+		{
+			name:      "type mismatches and nullable uid",
+			inspector: fakeInspector{"nodes": nodes},
+			config: QueryConfig{
+				Table:           "nodes",
+				ValueMappings:   ValueMappings{UID: "plan", Activate: "active"},
+				ExtraMappings:   map[string]string{"settings": "replicas"},
+				ExtraTypes:      map[string]ValueType{"settings": ValueTypeJSON},
+				UpdatedAtColumn: "replicas",
+			},
+			want: []SchemaIssue{
+				{
+					Field:   "valueMappings.uid",
+					Column:  "plan",
+					Reason:  SchemaIssueNullableUID,
+					Message: "uid column `plan` in `nodes` is nullable; a NULL uid cannot identify a node",
+				},
+				{
+					Field:   "extraValueTypes.settings",
+					Column:  "replicas",
+					Reason:  SchemaIssueTypeMismatch,
+					Message: "column `replicas` in `nodes` is int4 and cannot hold json values (extraValueTypes.settings)",
+				},
+				{
+					Field:   "source.updatedAtColumn",
+					Column:  "replicas",
+					Reason:  SchemaIssueTypeMismatch,
+					Message: "column `replicas` in `nodes` is int4, not a date/time column (source.updatedAtColumn)",
+				},
+			},
+		},
+		{
+			name:      "missing tables",
+			inspector: fakeInspector{"nodes": nodes},
+			config: QueryConfig{
+				Table:         "nodes",
+				ValueMappings: ValueMappings{UID: "id", Activate: "active"},
+				Relations:     []Relation{{Name: "domains", Table: "domain", ForeignKey: "node_id"}},
+			},
+			want: []SchemaIssue{{
+				Field:   "relations.domains",
+				Reason:  SchemaIssueTableNotFound,
+				Message: "table `domain` not found (relations.domains)",
+			}},
+		},
+		{
+			name:      "case-insensitive column names",
+			inspector: fakeInspector{"nodes": {Table: "nodes", CaseInsensitive: true, Columns: []Column{{Name: "ID", Type: "VARCHAR"}, {Name: "Active", Type: "TINYINT"}}}},
+			config:    QueryConfig{Table: "nodes", ValueMappings: ValueMappings{UID: "id", Activate: "active"}},
+		},
+		{
+			name:      "inspection error",
+			inspector: fakeInspector{},
+			config:    QueryConfig{Table: "broken", ValueMappings: ValueMappings{UID: "id"}},
+			errSubstr: "failed to inspect table broken: permission denied",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			issues, err := CheckSchema(context.Background(), tt.inspector, tt.config)
+			if tt.errSubstr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errSubstr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, issues)
+		})
+	}
+}
+
+func TestValueTypeFits(t *testing.T) {
+	assert.True(t, valueTypeFits(ValueTypeJSON, ValueTypeString), "text columns hold anything")
+	assert.True(t, valueTypeFits(ValueTypeBool, ValueTypeInt), "TINYINT booleans")
+	assert.True(t, valueTypeFits(ValueTypeInt, ValueTypeFloat), "DECIMAL whole numbers")
+	assert.True(t, valueTypeFits(ValueTypeAuto, ValueTypeJSON))
+	assert.False(t, valueTypeFits(ValueTypeInt, ValueTypeBool))
+	assert.False(t, valueTypeFits(ValueTypeJSON, ValueTypeFloat))
+}
+
+func TestMySQLAdapter_TableSchema(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer func() { _ = db.Close() }()
+
+	query := regexp.QuoteMeta("SELECT COLUMN_NAME, DATA_TYPE, IS_NULLABLE FROM INFORMATION_SCHEMA.COLUMNS")
+	mock.ExpectQuery(query).
+		WithArgs("", "nodes").
+		WillReturnRows(sqlmock.NewRows([]string{"COLUMN_NAME", "DATA_TYPE", "IS_NULLABLE"}).
+			AddRow("id", "varchar", "NO").
+			AddRow("plan", "varchar", "YES"))
+	mock.ExpectQuery(query).
+		WithArgs("billing", "accounts").
+		WillReturnRows(sqlmock.NewRows([]string{"COLUMN_NAME", "DATA_TYPE", "IS_NULLABLE"}))
+
+	adapter := &MySQLAdapter{db: db}
+	schema, err := adapter.TableSchema(context.Background(), "nodes")
+	require.NoError(t, err)
+	assert.Equal(t, &TableSchema{
+		Table:           "nodes",
+		CaseInsensitive: true,
+		Columns:         []Column{{Name: "id", Type: "varchar"}, {Name: "plan", Type: "varchar", Nullable: true}},
+	}, schema)
+
+	schema, err = adapter.TableSchema(context.Background(), "billing.accounts")
+	require.NoError(t, err)
+	assert.Empty(t, schema.Columns)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestPostgreSQLAdapter_TableSchema(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer func() { _ = db.Close() }()
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT column_name, udt_name, is_nullable FROM information_schema.columns")).
+		WithArgs("tenants", "nodes").
+		WillReturnRows(sqlmock.NewRows([]string{"column_name", "udt_name", "is_nullable"}).
+			AddRow("id", "int4", "NO").
+			AddRow("updated_at", "timestamptz", "YES"))
+
+	adapter := &PostgreSQLAdapter{db: db, schema: "tenants"}
+	schema, err := adapter.TableSchema(context.Background(), "nodes")
+	require.NoError(t, err)
+	assert.Equal(t, &TableSchema{
+		Table:   "nodes",
+		Columns: []Column{{Name: "id", Type: "int4"}, {Name: "updated_at", Type: "timestamptz", Nullable: true}},
+	}, schema)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSQLAdapter_TableSchema(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer func() { _ = db.Close() }()
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "nodes" WHERE 1 = 0`)).
+		WillReturnRows(sqlmock.NewRowsWithColumnDefinition(
+			sqlmock.NewColumn("id").OfType("TEXT", "").Nullable(false),
+			sqlmock.NewColumn("replicas").OfType("INTEGER", int64(0)).Nullable(true),
+		))
+
+	adapter := &SQLAdapter{db: db, dialect: sqliteDialect}
+	schema, err := adapter.TableSchema(context.Background(), "nodes")
+	require.NoError(t, err)
+	assert.Equal(t, &TableSchema{
+		Table:   "nodes",
+		Columns: []Column{{Name: "id", Type: "TEXT"}, {Name: "replicas", Type: "INTEGER", Nullable: true}},
+	}, schema)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
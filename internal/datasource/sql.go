@@ -0,0 +1,451 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package datasource
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// sqlDialect captures the syntax differences between database/sql based adapters
+type sqlDialect struct {
+	// quoteIdentifier quotes a single column/table identifier
+	quoteIdentifier func(name string) string
+
+	// placeholder returns the bind parameter marker for the n-th (1-based) argument
+	placeholder func(n int) string
+}
+
+// mysqlDialect quotes identifiers with backticks
+var mysqlDialect = sqlDialect{
+	quoteIdentifier: func(name string) string {
+		return "`" + strings.ReplaceAll(name, "`", "``") + "`"
+	},
+	placeholder: func(int) string {
+		return "?"
+	},
+}
+
+// postgresDialect quotes identifiers with double quotes, escaping embedded quotes
+var postgresDialect = sqlDialect{
+	quoteIdentifier: func(name string) string {
+		return `"` + strings.ReplaceAll(name, `"`, `""`) + `"`
+	},
+	placeholder: func(n int) string {
+		return "$" + strconv.Itoa(n)
+	},
+}
+
+// sqliteDialect quotes identifiers with double quotes and binds with question marks
+var sqliteDialect = sqlDialect{
+	quoteIdentifier: postgresDialect.quoteIdentifier,
+	placeholder:     mysqlDialect.placeholder,
+}
+
+// sqlDialects maps dialect names to dialects
+var sqlDialects = map[string]sqlDialect{
+	"mysql":      mysqlDialect,
+	"postgresql": postgresDialect,
+	"sqlite":     sqliteDialect,
+}
+
+// driverDialects maps well-known database/sql driver names to their dialect
+var driverDialects = map[string]string{
+	"mysql":    "mysql",
+	"pgx":      "postgresql",
+	"pgx/v5":   "postgresql",
+	"postgres": "postgresql",
+	"sqlite":   "sqlite",
+	"sqlite3":  "sqlite",
+}
+
+// dialectFor returns the named dialect, or the dialect of a well-known driver when name is empty
+func dialectFor(driver, name string) (sqlDialect, error) {
+	if name == "" {
+		var ok bool
+		if name, ok = driverDialects[driver]; !ok {
+			return sqlDialect{}, fmt.Errorf("dialect is required for driver %q", driver)
+		}
+	}
+	dialect, ok := sqlDialects[name]
+	if !ok {
+		return sqlDialect{}, fmt.Errorf("unsupported SQL dialect %q", name)
+	}
+	return dialect, nil
+}
+
+// joinColumns joins column names into a quoted, comma-separated list
+func (d sqlDialect) joinColumns(columns []string) string {
+	quoted := make([]string, len(columns))
+	for i, col := range columns {
+		quoted[i] = d.quoteIdentifier(col)
+	}
+	return strings.Join(quoted, ", ")
+}
+
+// filterComparisons maps single-operand filter operators to SQL
+var filterComparisons = map[string]string{
+	"eq":   "=",
+	"ne":   "<>",
+	"lt":   "<",
+	"le":   "<=",
+	"gt":   ">",
+	"ge":   ">=",
+	"like": "LIKE",
+}
+
+// filterConditions renders filters as WHERE conditions with bound arguments
+// Column names are quoted and values are always passed as parameters.
+func (d sqlDialect) filterConditions(filters []Filter) ([]string, []interface{}, error) {
+	var conditions []string
+	var args []interface{}
+
+	for _, filter := range filters {
+		if filter.Column == "" {
+			return nil, nil, fmt.Errorf("filter column is required")
+		}
+		column := d.quoteIdentifier(filter.Column)
+
+		switch filter.Operator {
+		case "isNull":
+			conditions = append(conditions, column+" IS NULL")
+		case "isNotNull":
+			conditions = append(conditions, column+" IS NOT NULL")
+		case "in", "notIn":
+			if len(filter.Values) == 0 {
+				return nil, nil, fmt.Errorf("filter on %s: operator %s requires at least one value", filter.Column, filter.Operator)
+			}
+			placeholders := make([]string, len(filter.Values))
+			for i, value := range filter.Values {
+				args = append(args, value)
+				placeholders[i] = d.placeholder(len(args))
+			}
+			keyword := "IN"
+			if filter.Operator == "notIn" {
+				keyword = "NOT IN"
+			}
+			conditions = append(conditions, fmt.Sprintf("%s %s (%s)", column, keyword, strings.Join(placeholders, ", ")))
+		default:
+			comparison, ok := filterComparisons[filter.Operator]
+			if !ok {
+				return nil, nil, fmt.Errorf("filter on %s: unsupported operator %q", filter.Column, filter.Operator)
+			}
+			if len(filter.Values) != 1 {
+				return nil, nil, fmt.Errorf("filter on %s: operator %s requires exactly one value", filter.Column, filter.Operator)
+			}
+			args = append(args, filter.Values[0])
+			conditions = append(conditions, fmt.Sprintf("%s %s %s", column, comparison, d.placeholder(len(args))))
+		}
+	}
+
+	return conditions, args, nil
+}
+
+// configurePool applies connection pool settings, falling back to defaults when unset
+func configurePool(db *sql.DB, config Config) {
+	maxOpenConns := config.MaxOpenConns
+	if maxOpenConns == 0 {
+		maxOpenConns = 25 // Default
+	}
+	db.SetMaxOpenConns(maxOpenConns)
+
+	maxIdleConns := config.MaxIdleConns
+	if maxIdleConns == 0 {
+		maxIdleConns = 5 // Default
+	}
+	db.SetMaxIdleConns(maxIdleConns)
+
+	connMaxLifetime := 5 * time.Minute // Default
+	if config.ConnMaxLifetime != "" {
+		if parsed, err := time.ParseDuration(config.ConnMaxLifetime); err == nil {
+			connMaxLifetime = parsed
+		}
+	}
+	db.SetConnMaxLifetime(connMaxLifetime)
+}
+
+// querySQLNodes selects the mapped columns from the given table expression and scans
+// active rows into NodeRows. The table expression is used verbatim, so callers are
+// responsible for quoting/qualifying it as their dialect requires.
+func querySQLNodes(ctx context.Context, db *sql.DB, dialect sqlDialect, table string, config QueryConfig) ([]NodeRow, error) {
+	rows, _, err := selectSQLRows(ctx, db, dialect, table, config, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	// Filter: only include active nodes
+	// Note: HostOrURL is deprecated since v1.1.11 and no longer required
+	var nodes []NodeRow
+	for _, row := range rows {
+		if row.active {
+			nodes = append(nodes, row.NodeRow)
+		}
+	}
+	return nodes, nil
+}
+
+// queryChangedSQLNodes selects rows whose UpdatedAtColumn is at or after since (all rows when
+// since is zero) and splits them into active rows and inactive UIDs
+func queryChangedSQLNodes(ctx context.Context, db *sql.DB, dialect sqlDialect, table string, config QueryConfig, since time.Time) (*ChangeSet, error) {
+	if config.UpdatedAtColumn == "" {
+		return nil, fmt.Errorf("updatedAtColumn is required for incremental queries")
+	}
+
+	var sincePtr *time.Time
+	if !since.IsZero() {
+		sincePtr = &since
+	}
+
+	rows, watermark, err := selectSQLRows(ctx, db, dialect, table, config, sincePtr)
+	if err != nil {
+		return nil, err
+	}
+
+	changes := &ChangeSet{Watermark: watermark}
+	if changes.Watermark.Before(since) {
+		changes.Watermark = since
+	}
+	for _, row := range rows {
+		if row.active {
+			changes.Active = append(changes.Active, row.NodeRow)
+		} else {
+			changes.InactiveUIDs = append(changes.InactiveUIDs, row.UID)
+		}
+	}
+	return changes, nil
+}
+
+// sqlValueTypes resolves the extra value types; "auto" uses the type of the extra column,
+// found at extraOffset plus its index in colIndex
+func sqlValueTypes(rows *sql.Rows, config QueryConfig, colIndex map[string]int, extraOffset int) (map[string]ValueType, error) {
+	needsColumnTypes := false
+	for _, valueType := range config.ExtraTypes {
+		if valueType == ValueTypeAuto {
+			needsColumnTypes = true
+		}
+	}
+	if !needsColumnTypes {
+		return config.ExtraTypes, nil
+	}
+
+	columnTypes, err := rows.ColumnTypes()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read column types: %w", err)
+	}
+	return resolveValueTypes(config.ExtraTypes, func(key string) ValueType {
+		idx, ok := colIndex[config.ExtraMappings[key]]
+		if !ok || extraOffset+idx >= len(columnTypes) {
+			return ValueTypeString
+		}
+		return sqlColumnValueType(columnTypes[extraOffset+idx].DatabaseTypeName())
+	}), nil
+}
+
+// sqlRow is a scanned row together with its activation result
+type sqlRow struct {
+	NodeRow
+	active bool
+}
+
+// selectSQLRows runs the SELECT for the mapped columns and scans every row, active or not.
+// When config.UpdatedAtColumn is set, the column is selected too and the highest value is
+// returned as the watermark; when since is non-nil, only rows at or after it are selected.
+func selectSQLRows(ctx context.Context, db *sql.DB, dialect sqlDialect, table string, config QueryConfig, since *time.Time) ([]sqlRow, time.Time, error) {
+	var watermark time.Time
+
+	rule, err := activationRule(config.ValueMappings)
+	if err != nil {
+		return nil, watermark, err
+	}
+
+	// Build column list - start with required fields
+	columns := []string{
+		config.ValueMappings.UID,
+	}
+
+	// Add HostOrURL only if specified (deprecated, optional since v1.1.11)
+	includeHostOrURL := config.ValueMappings.HostOrURL != ""
+	if includeHostOrURL {
+		columns = append(columns, config.ValueMappings.HostOrURL)
+	}
+
+	// Add activate column (optional when an activate expression is used)
+	includeActivate := config.ValueMappings.Activate != ""
+	if includeActivate {
+		columns = append(columns, config.ValueMappings.Activate)
+	}
+
+	// Add extra columns in sorted order for stable queries
+	// Sort the keys to ensure consistent column order
+	extraKeys := make([]string, 0, len(config.ExtraMappings))
+	for key := range config.ExtraMappings {
+		extraKeys = append(extraKeys, key)
+	}
+	sort.Strings(extraKeys)
+
+	extraOffset := len(columns)
+	extraColumns := make([]string, 0, len(config.ExtraMappings))
+	for _, key := range extraKeys {
+		col := config.ExtraMappings[key]
+		columns = append(columns, col)
+		extraColumns = append(extraColumns, col)
+	}
+
+	// Add columns referenced by the activate expression
+	exprColumns := rule.Columns()
+	columns = append(columns, exprColumns...)
+
+	// Add the updated-at column last (incremental sync only)
+	includeUpdatedAt := config.UpdatedAtColumn != ""
+	if includeUpdatedAt {
+		columns = append(columns, config.UpdatedAtColumn)
+	}
+
+	// Build query
+	query := fmt.Sprintf("SELECT %s FROM %s", dialect.joinColumns(columns), table)
+	conditions, args, err := dialect.filterConditions(config.Filters)
+	if err != nil {
+		return nil, watermark, err
+	}
+	if includeUpdatedAt && since != nil {
+		args = append(args, since.UTC())
+		conditions = append(conditions, fmt.Sprintf("%s >= %s", dialect.quoteIdentifier(config.UpdatedAtColumn), dialect.placeholder(len(args))))
+	}
+	if len(conditions) > 0 {
+		query += " WHERE " + strings.Join(conditions, " AND ")
+	}
+
+	// Execute query
+	rows, err := db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, watermark, fmt.Errorf("failed to query nodes: %w", err)
+	}
+	defer func() {
+		_ = rows.Close() // Best effort close
+	}()
+
+	// Build column index map once for stable extra value mapping
+	colIndex := make(map[string]int, len(extraColumns))
+	for i, col := range extraColumns {
+		colIndex[col] = i
+	}
+
+	// Resolve "auto" extra types from the column types of the result set
+	valueTypes, err := sqlValueTypes(rows, config, colIndex, extraOffset)
+	if err != nil {
+		return nil, watermark, err
+	}
+
+	// Scan results
+	var nodes []sqlRow
+	for rows.Next() {
+		row := NodeRow{
+			Extra: make(map[string]string),
+		}
+
+		// Use NullString for required fields to handle NULL values
+		var uid, hostOrURL, activate sql.NullString
+		var updatedAt sql.NullTime
+
+		// Prepare scan destinations based on which columns were queried
+		scanDest := []interface{}{&uid}
+		if includeHostOrURL {
+			scanDest = append(scanDest, &hostOrURL)
+		}
+		if includeActivate {
+			scanDest = append(scanDest, &activate)
+		}
+
+		// Add extra column destinations
+		extraValues := make([]sql.NullString, len(extraColumns))
+		for i := range extraValues {
+			scanDest = append(scanDest, &extraValues[i])
+		}
+		exprValues := make([]sql.NullString, len(exprColumns))
+		for i := range exprValues {
+			scanDest = append(scanDest, &exprValues[i])
+		}
+		if includeUpdatedAt {
+			scanDest = append(scanDest, &updatedAt)
+		}
+
+		if err := rows.Scan(scanDest...); err != nil {
+			return nil, watermark, fmt.Errorf("failed to scan row: %w", err)
+		}
+
+		// Convert NullString to string (NULL becomes empty string)
+		if uid.Valid {
+			row.UID = uid.String
+		}
+		if hostOrURL.Valid {
+			row.HostOrURL = hostOrURL.String
+		}
+		if activate.Valid {
+			row.Activate = activate.String
+		} else if !includeActivate {
+			// Activation comes from the expression; expose active rows as activate=true
+			row.Activate = defaultActivateValue
+		}
+
+		// Map extra values using stable indices
+		for key, col := range config.ExtraMappings {
+			idx, ok := colIndex[col]
+			if !ok {
+				// Column not in result set (shouldn't happen)
+				row.Extra[key] = ""
+				continue
+			}
+			if extraValues[idx].Valid {
+				row.Extra[key] = extraValues[idx].String
+			} else {
+				row.Extra[key] = "" // Null values become empty strings
+			}
+		}
+
+		applyValueTypes(&row, valueTypes)
+
+		// Track the highest updated-at value (NULLs never advance the watermark)
+		if updatedAt.Valid && updatedAt.Time.After(watermark) {
+			watermark = updatedAt.Time
+		}
+
+		lookup := func(column string) (string, bool) {
+			for i, col := range exprColumns {
+				if col == column {
+					return exprValues[i].String, exprValues[i].Valid
+				}
+			}
+			return "", false
+		}
+		if !includeActivate {
+			// Without an activate column every row is active, unless an expression says otherwise
+			activate = sql.NullString{String: defaultActivateValue, Valid: true}
+		}
+		nodes = append(nodes, sqlRow{NodeRow: row, active: rule.IsActive(activate.String, activate.Valid, lookup)})
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, watermark, fmt.Errorf("error iterating rows: %w", err)
+	}
+
+	return nodes, watermark, nil
+}
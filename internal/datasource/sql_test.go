@@ -0,0 +1,222 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package datasource
+
+import (
+	"context"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSQLDialect_QuoteIdentifier(t *testing.T) {
+	assert.Equal(t, "`plan`", mysqlDialect.quoteIdentifier("plan"))
+	assert.Equal(t, "`we``ird`", mysqlDialect.quoteIdentifier("we`ird"))
+	assert.Equal(t, `"plan"`, postgresDialect.quoteIdentifier("plan"))
+	assert.Equal(t, `"we""ird"`, postgresDialect.quoteIdentifier(`we"ird`))
+}
+
+func TestSQLDialect_FilterConditions(t *testing.T) {
+	tests := []struct {
+		name       string
+		dialect    sqlDialect
+		filters    []Filter
+		want       []string
+		wantArgs   []interface{}
+		errMessage string
+	}{
+		{
+			name:    "comparisons and like",
+			dialect: mysqlDialect,
+			filters: []Filter{
+				{Column: "region", Operator: "eq", Values: []string{"eu-west-1"}},
+				{Column: "tier", Operator: "ne", Values: []string{"free"}},
+				{Column: "name", Operator: "like", Values: []string{"acme%"}},
+			},
+			want:     []string{"`region` = ?", "`tier` <> ?", "`name` LIKE ?"},
+			wantArgs: []interface{}{"eu-west-1", "free", "acme%"},
+		},
+		{
+			name:    "in, not in and null checks with numbered placeholders",
+			dialect: postgresDialect,
+			filters: []Filter{
+				{Column: "region", Operator: "in", Values: []string{"eu", "us"}},
+				{Column: "plan", Operator: "notIn", Values: []string{"trial"}},
+				{Column: "deleted_at", Operator: "isNull"},
+				{Column: "owner", Operator: "isNotNull"},
+				{Column: "seats", Operator: "ge", Values: []string{"5"}},
+			},
+			want: []string{
+				`"region" IN ($1, $2)`,
+				`"plan" NOT IN ($3)`,
+				`"deleted_at" IS NULL`,
+				`"owner" IS NOT NULL`,
+				`"seats" >= $4`,
+			},
+			wantArgs: []interface{}{"eu", "us", "trial", "5"},
+		},
+		{
+			name:       "in without values",
+			dialect:    mysqlDialect,
+			filters:    []Filter{{Column: "region", Operator: "in"}},
+			errMessage: "requires at least one value",
+		},
+		{
+			name:       "comparison without value",
+			dialect:    mysqlDialect,
+			filters:    []Filter{{Column: "region", Operator: "eq"}},
+			errMessage: "requires exactly one value",
+		},
+		{
+			name:       "unsupported operator",
+			dialect:    mysqlDialect,
+			filters:    []Filter{{Column: "region", Operator: "regexp", Values: []string{".*"}}},
+			errMessage: `unsupported operator "regexp"`,
+		},
+		{
+			name:       "missing column",
+			dialect:    mysqlDialect,
+			filters:    []Filter{{Operator: "isNull"}},
+			errMessage: "filter column is required",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conditions, args, err := tt.dialect.filterConditions(tt.filters)
+			if tt.errMessage != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errMessage)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, conditions)
+			assert.Equal(t, tt.wantArgs, args)
+		})
+	}
+}
+
+func TestQuerySQLNodes_WithFilters(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer func() {
+		_ = db.Close()
+	}()
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT `id`, `active` FROM nodes WHERE `region` = ? AND `plan` IN (?, ?)")).
+		WithArgs("eu-west-1", "pro", "enterprise").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "active"}).AddRow("node1", "1"))
+
+	adapter := &MySQLAdapter{db: db}
+	got, err := adapter.QueryNodes(context.Background(), QueryConfig{
+		Table: "nodes",
+		ValueMappings: ValueMappings{
+			UID:      "id",
+			Activate: "active",
+		},
+		Filters: []Filter{
+			{Column: "region", Operator: "eq", Values: []string{"eu-west-1"}},
+			{Column: "plan", Operator: "in", Values: []string{"pro", "enterprise"}},
+		},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []NodeRow{{UID: "node1", Activate: "1", Extra: map[string]string{}}}, got)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestQuerySQLNodes_Activation(t *testing.T) {
+	t.Run("activate expression without activate column", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		defer func() {
+			_ = db.Close()
+		}()
+
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT "id", "plan", "deleted_at", "status" FROM "public"."nodes"`)).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "plan", "deleted_at", "status"}).
+				AddRow("node1", "pro", nil, "active").
+				AddRow("node2", "pro", "2025-01-01", "active").
+				AddRow("node3", "free", nil, "trial").
+				AddRow("node4", "free", nil, nil))
+
+		adapter := &PostgreSQLAdapter{db: db, schema: "public"}
+		got, err := adapter.QueryNodes(context.Background(), QueryConfig{
+			Table: "nodes",
+			ValueMappings: ValueMappings{
+				UID:                "id",
+				ActivateExpression: "deleted_at IS NULL AND status in ('active','trial')",
+			},
+			ExtraMappings: map[string]string{"plan": "plan"},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, []NodeRow{
+			{UID: "node1", Activate: "true", Extra: map[string]string{"plan": "pro"}},
+			{UID: "node3", Activate: "true", Extra: map[string]string{"plan": "free"}},
+		}, got)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("active values", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		defer func() {
+			_ = db.Close()
+		}()
+
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT `id`, `status` FROM nodes")).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "status"}).
+				AddRow("node1", "active").
+				AddRow("node2", "1").
+				AddRow("node3", nil).
+				AddRow("node4", "trial"))
+
+		adapter := &MySQLAdapter{db: db}
+		got, err := adapter.QueryNodes(context.Background(), QueryConfig{
+			Table: "nodes",
+			ValueMappings: ValueMappings{
+				UID:          "id",
+				Activate:     "status",
+				ActiveValues: []string{"active", "trial"},
+			},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, []NodeRow{
+			{UID: "node1", Activate: "active", Extra: map[string]string{}},
+			{UID: "node4", Activate: "trial", Extra: map[string]string{}},
+		}, got)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("invalid expression", func(t *testing.T) {
+		db, _, err := sqlmock.New()
+		require.NoError(t, err)
+		defer func() {
+			_ = db.Close()
+		}()
+
+		adapter := &MySQLAdapter{db: db}
+		_, err = adapter.QueryNodes(context.Background(), QueryConfig{
+			Table:         "nodes",
+			ValueMappings: ValueMappings{UID: "id", ActivateExpression: "status ="},
+		})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid activate expression")
+	})
+}
@@ -0,0 +1,211 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package datasource
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"sigs.k8s.io/yaml"
+)
+
+// Row formats supported by the static adapter
+const (
+	RowFormatCSV  = "csv"
+	RowFormatJSON = "json"
+	RowFormatYAML = "yaml"
+)
+
+// StaticAdapter implements the Datasource interface for rows held in memory,
+// either parsed from ConfigMap data (CSV/JSON/YAML) or given inline on the LynqHub
+type StaticAdapter struct {
+	records []map[string]string
+	// columns is the CSV header; nil for schemaless (JSON/YAML/inline) rows
+	columns map[string]bool
+}
+
+// NewStaticAdapter creates a new static datasource adapter
+// Inline rows (config.Rows) take precedence over encoded row data (config.RowData).
+func NewStaticAdapter(config Config) (*StaticAdapter, error) {
+	if config.Rows != nil {
+		return &StaticAdapter{records: config.Rows}, nil
+	}
+
+	switch config.RowFormat {
+	case RowFormatCSV:
+		return parseCSVRows(config.RowData)
+	case RowFormatJSON:
+		records, err := parseJSONRows([]byte(config.RowData))
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse JSON rows: %w", err)
+		}
+		return &StaticAdapter{records: records}, nil
+	case RowFormatYAML:
+		data, err := yaml.YAMLToJSON([]byte(config.RowData))
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse YAML rows: %w", err)
+		}
+		records, err := parseJSONRows(data)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse YAML rows: %w", err)
+		}
+		return &StaticAdapter{records: records}, nil
+	default:
+		return nil, fmt.Errorf("unsupported row format: %q", config.RowFormat)
+	}
+}
+
+// QueryNodes maps the stored rows to node rows, returning only active nodes
+func (a *StaticAdapter) QueryNodes(_ context.Context, config QueryConfig) ([]NodeRow, error) {
+	rule, err := activationRule(config.ValueMappings)
+	if err != nil {
+		return nil, err
+	}
+
+	if a.columns != nil {
+		columns := []string{config.ValueMappings.UID}
+		if config.ValueMappings.Activate != "" {
+			columns = append(columns, config.ValueMappings.Activate)
+		}
+		if config.ValueMappings.HostOrURL != "" {
+			columns = append(columns, config.ValueMappings.HostOrURL)
+		}
+		for _, col := range config.ExtraMappings {
+			columns = append(columns, col)
+		}
+		columns = append(columns, rule.Columns()...)
+		for _, col := range columns {
+			if !a.columns[col] {
+				return nil, fmt.Errorf("column %q not found in CSV header", col)
+			}
+		}
+	}
+
+	var nodes []NodeRow
+	for _, record := range a.records {
+		activate, activateValid := record[config.ValueMappings.Activate]
+		if config.ValueMappings.Activate == "" {
+			// Without an activate column every row is active, unless an expression says otherwise
+			activate, activateValid = defaultActivateValue, true
+		}
+		row := NodeRow{
+			UID:      record[config.ValueMappings.UID],
+			Activate: activate,
+			Extra:    make(map[string]string, len(config.ExtraMappings)),
+		}
+		if config.ValueMappings.HostOrURL != "" {
+			row.HostOrURL = record[config.ValueMappings.HostOrURL]
+		}
+		// Missing columns become empty strings, like NULL values in SQL adapters
+		for key, col := range config.ExtraMappings {
+			row.Extra[key] = record[col]
+		}
+		// Static rows are untyped; "auto" keeps strings
+		applyValueTypes(&row, config.ExtraTypes)
+
+		// Filter: only include active nodes
+		lookup := func(column string) (string, bool) {
+			value, ok := record[column]
+			return value, ok
+		}
+		if rule.IsActive(activate, activateValid, lookup) {
+			nodes = append(nodes, row)
+		}
+	}
+
+	return nodes, nil
+}
+
+// Close is a no-op; static rows hold no connections
+func (a *StaticAdapter) Close() error {
+	return nil
+}
+
+// parseCSVRows parses CSV data whose first line is the column header
+func parseCSVRows(data string) (*StaticAdapter, error) {
+	reader := csv.NewReader(strings.NewReader(data))
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err == io.EOF {
+		return &StaticAdapter{columns: map[string]bool{}}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse CSV header: %w", err)
+	}
+
+	columns := make(map[string]bool, len(header))
+	for i, col := range header {
+		header[i] = strings.TrimSpace(col)
+		columns[header[i]] = true
+	}
+
+	var records []map[string]string
+	for {
+		fields, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse CSV rows: %w", err)
+		}
+		// Empty cells are left out so that activate expressions treat them as NULL
+		record := make(map[string]string, len(header))
+		for i, col := range header {
+			if value := strings.TrimSpace(fields[i]); value != "" {
+				record[col] = value
+			}
+		}
+		records = append(records, record)
+	}
+
+	return &StaticAdapter{records: records, columns: columns}, nil
+}
+
+// parseJSONRows parses a JSON array of objects, converting values to strings
+// null values are left out so that activate expressions treat them as NULL
+func parseJSONRows(data []byte) ([]map[string]string, error) {
+	if len(bytes.TrimSpace(data)) == 0 || bytes.Equal(bytes.TrimSpace(data), []byte("null")) {
+		return nil, nil
+	}
+
+	// UseNumber keeps integer IDs intact instead of converting them to float64
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var items []map[string]interface{}
+	if err := decoder.Decode(&items); err != nil {
+		return nil, err
+	}
+
+	records := make([]map[string]string, 0, len(items))
+	for _, item := range items {
+		record := make(map[string]string, len(item))
+		for key, value := range item {
+			if value == nil {
+				continue
+			}
+			record[key] = jsonValueToString(value)
+		}
+		records = append(records, record)
+	}
+	return records, nil
+}
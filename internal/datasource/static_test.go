@@ -0,0 +1,234 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package datasource
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var staticTestQueryConfig = QueryConfig{
+	ValueMappings: ValueMappings{
+		UID:      "id",
+		Activate: "active",
+	},
+	ExtraMappings: map[string]string{
+		"plan": "plan",
+	},
+}
+
+func TestStaticAdapter_QueryNodes(t *testing.T) {
+	wantRows := []NodeRow{
+		{UID: "acme", Activate: "1", Extra: map[string]string{"plan": "pro"}},
+		{UID: "1002", Activate: "true", Extra: map[string]string{"plan": ""}},
+	}
+
+	tests := []struct {
+		name       string
+		config     Config
+		want       []NodeRow
+		errMessage string
+	}{
+		{
+			name: "csv rows",
+			config: Config{
+				RowFormat: RowFormatCSV,
+				RowData:   "id, active, plan\nacme, 1, pro\nbeta, 0, free\n1002, true,\n",
+			},
+			want: wantRows,
+		},
+		{
+			name: "json rows with typed values",
+			config: Config{
+				RowFormat: RowFormatJSON,
+				RowData:   `[{"id":"acme","active":1,"plan":"pro"},{"id":"beta","active":false},{"id":1002,"active":true,"plan":null}]`,
+			},
+			want: wantRows,
+		},
+		{
+			name: "yaml rows",
+			config: Config{
+				RowFormat: RowFormatYAML,
+				RowData:   "- id: acme\n  active: 1\n  plan: pro\n- id: beta\n  active: \"0\"\n- id: 1002\n  active: true\n",
+			},
+			want: wantRows,
+		},
+		{
+			name: "inline rows",
+			config: Config{
+				Rows: []map[string]string{
+					{"id": "acme", "active": "1", "plan": "pro"},
+					{"id": "beta", "active": "no"},
+					{"id": "1002", "active": "true"},
+				},
+			},
+			want: wantRows,
+		},
+		{
+			name:   "empty json",
+			config: Config{RowFormat: RowFormatJSON, RowData: ""},
+			want:   nil,
+		},
+		{
+			name:       "csv missing mapped column",
+			config:     Config{RowFormat: RowFormatCSV, RowData: "id,active\nacme,1\n"},
+			errMessage: `column "plan" not found in CSV header`,
+		},
+		{
+			name:       "csv with inconsistent field count",
+			config:     Config{RowFormat: RowFormatCSV, RowData: "id,active,plan\nacme,1\n"},
+			errMessage: "failed to parse CSV rows",
+		},
+		{
+			name:       "json object instead of list",
+			config:     Config{RowFormat: RowFormatJSON, RowData: `{"id":"acme"}`},
+			errMessage: "failed to parse JSON rows",
+		},
+		{
+			name:       "invalid yaml",
+			config:     Config{RowFormat: RowFormatYAML, RowData: "- id: [unclosed"},
+			errMessage: "failed to parse YAML rows",
+		},
+		{
+			name:       "unsupported format",
+			config:     Config{RowFormat: "xml"},
+			errMessage: `unsupported row format: "xml"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			adapter, err := NewStaticAdapter(tt.config)
+			var rows []NodeRow
+			if err == nil {
+				rows, err = adapter.QueryNodes(context.Background(), staticTestQueryConfig)
+			}
+
+			if tt.errMessage != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errMessage)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, rows)
+			assert.NoError(t, adapter.Close())
+		})
+	}
+}
+
+func TestStaticAdapter_QueryNodesTypedValues(t *testing.T) {
+	adapter, err := NewStaticAdapter(Config{
+		RowFormat: RowFormatJSON,
+		RowData:   `[{"id":"acme","active":1,"replicas":3,"ha":"true","plan":"pro"},{"id":"beta","active":1,"replicas":"two"}]`,
+	})
+	require.NoError(t, err)
+
+	rows, err := adapter.QueryNodes(context.Background(), QueryConfig{
+		ValueMappings: ValueMappings{UID: "id", Activate: "active"},
+		ExtraMappings: map[string]string{"replicas": "replicas", "ha": "ha", "plan": "plan"},
+		ExtraTypes:    map[string]ValueType{"replicas": ValueTypeInt, "ha": ValueTypeBool, "plan": ValueTypeAuto},
+	})
+	require.NoError(t, err)
+	require.Len(t, rows, 2)
+	assert.Equal(t, map[string]interface{}{"replicas": int64(3), "ha": true}, rows[0].Typed)
+	assert.Nil(t, rows[1].Typed, "unconvertible and empty values stay strings")
+	assert.Equal(t, "two", rows[1].Extra["replicas"])
+}
+
+func TestStaticAdapter_QueryNodesActivation(t *testing.T) {
+	tests := []struct {
+		name          string
+		config        Config
+		valueMappings ValueMappings
+		want          []NodeRow
+		errMessage    string
+	}{
+		{
+			name: "active values",
+			config: Config{
+				RowFormat: RowFormatCSV,
+				RowData:   "id,status\nacme,active\nbeta,1\ngamma,trial\ndelta,\n",
+			},
+			valueMappings: ValueMappings{UID: "id", Activate: "status", ActiveValues: []string{"active", "trial"}},
+			want: []NodeRow{
+				{UID: "acme", Activate: "active", Extra: map[string]string{}},
+				{UID: "gamma", Activate: "trial", Extra: map[string]string{}},
+			},
+		},
+		{
+			name: "expression treats empty csv cells as NULL",
+			config: Config{
+				RowFormat: RowFormatCSV,
+				RowData:   "id,deleted_at\nacme,\nbeta,2025-01-01\n",
+			},
+			valueMappings: ValueMappings{UID: "id", ActivateExpression: "deleted_at IS NULL"},
+			want:          []NodeRow{{UID: "acme", Activate: "true", Extra: map[string]string{}}},
+		},
+		{
+			name: "expression treats json null and missing keys as NULL",
+			config: Config{
+				RowFormat: RowFormatJSON,
+				RowData:   `[{"id":"acme","deleted_at":null},{"id":"beta","deleted_at":"2025-01-01"},{"id":"gamma"}]`,
+			},
+			valueMappings: ValueMappings{UID: "id", ActivateExpression: "deleted_at IS NULL"},
+			want: []NodeRow{
+				{UID: "acme", Activate: "true", Extra: map[string]string{}},
+				{UID: "gamma", Activate: "true", Extra: map[string]string{}},
+			},
+		},
+		{
+			name:          "no activate column",
+			config:        Config{Rows: []map[string]string{{"id": "acme"}, {"id": "beta", "active": "0"}}},
+			valueMappings: ValueMappings{UID: "id"},
+			want: []NodeRow{
+				{UID: "acme", Activate: "true", Extra: map[string]string{}},
+				{UID: "beta", Activate: "true", Extra: map[string]string{}},
+			},
+		},
+		{
+			name:          "csv missing expression column",
+			config:        Config{RowFormat: RowFormatCSV, RowData: "id\nacme\n"},
+			valueMappings: ValueMappings{UID: "id", ActivateExpression: "deleted_at IS NULL"},
+			errMessage:    `column "deleted_at" not found in CSV header`,
+		},
+		{
+			name:          "invalid expression",
+			config:        Config{Rows: []map[string]string{{"id": "acme"}}},
+			valueMappings: ValueMappings{UID: "id", ActivateExpression: "deleted_at IS"},
+			errMessage:    "invalid activate expression",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			adapter, err := NewStaticAdapter(tt.config)
+			require.NoError(t, err)
+
+			rows, err := adapter.QueryNodes(context.Background(), QueryConfig{ValueMappings: tt.valueMappings})
+			if tt.errMessage != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errMessage)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, rows)
+		})
+	}
+}
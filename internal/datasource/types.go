@@ -0,0 +1,227 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package datasource
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ValueType is the type an extra value is exposed as to templates
+type ValueType string
+
+const (
+	// ValueTypeString keeps the value as a string (default)
+	ValueTypeString ValueType = "string"
+	// ValueTypeInt converts the value to an int64
+	ValueTypeInt ValueType = "int"
+	// ValueTypeFloat converts the value to a float64
+	ValueTypeFloat ValueType = "float"
+	// ValueTypeBool converts the value to a bool
+	ValueTypeBool ValueType = "bool"
+	// ValueTypeJSON decodes the value as JSON into nested maps and lists
+	ValueTypeJSON ValueType = "json"
+	// ValueTypeAuto uses the datasource's own type: the column type of SQL sources and
+	// the JSON type of http and plugin sources (objects and arrays become json). Other sources treat it as string.
+	ValueTypeAuto ValueType = "auto"
+)
+
+// Values returns the extra values keyed like Extra, with typed values in place of their string form,
+// and the relations as lists of items
+func (r NodeRow) Values() map[string]interface{} {
+	values := make(map[string]interface{}, len(r.Extra)+len(r.Relations))
+	for key, value := range r.Extra {
+		values[key] = value
+	}
+	for key, value := range r.Typed {
+		values[key] = value
+	}
+	for name, children := range r.Relations {
+		items := make([]interface{}, len(children))
+		for i, child := range children {
+			item := make(map[string]interface{}, len(child))
+			for field, value := range child {
+				item[field] = value
+			}
+			items[i] = item
+		}
+		values[name] = items
+	}
+	return values
+}
+
+// ConvertValue converts the string form of a value to the given type
+// Empty values (NULL) are not converted.
+func ConvertValue(value string, valueType ValueType) (interface{}, error) {
+	if value == "" {
+		return nil, fmt.Errorf("empty value")
+	}
+	switch valueType {
+	case ValueTypeInt:
+		if i, err := strconv.ParseInt(value, 10, 64); err == nil {
+			return i, nil
+		}
+		// DECIMAL columns scan as "3.00"; accept whole numbers
+		if f, err := strconv.ParseFloat(value, 64); err == nil && f == float64(int64(f)) {
+			return int64(f), nil
+		}
+		return nil, fmt.Errorf("%q is not an integer", value)
+	case ValueTypeFloat:
+		f, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return nil, fmt.Errorf("%q is not a number", value)
+		}
+		return f, nil
+	case ValueTypeBool:
+		switch strings.ToLower(value) {
+		case "1", "t", "true", "y", "yes", "on":
+			return true, nil
+		case "0", "f", "false", "n", "no", "off":
+			return false, nil
+		}
+		return nil, fmt.Errorf("%q is not a boolean", value)
+	case ValueTypeJSON:
+		decoded, err := DecodeJSON(value)
+		if err != nil {
+			return nil, err
+		}
+		if decoded == nil {
+			return nil, fmt.Errorf("null value")
+		}
+		return decoded, nil
+	default:
+		return nil, fmt.Errorf("unsupported value type %q", valueType)
+	}
+}
+
+// DecodeJSON decodes a JSON document into maps, lists, strings, bools and numbers
+// Whole numbers become int64 and other numbers float64, so that templates and comparisons
+// see the same values whether the document was just read from the datasource or round-tripped
+// through an annotation.
+func DecodeJSON(data string) (interface{}, error) {
+	decoder := json.NewDecoder(strings.NewReader(data))
+	decoder.UseNumber()
+	var decoded interface{}
+	if err := decoder.Decode(&decoded); err != nil {
+		return nil, fmt.Errorf("invalid JSON: %w", err)
+	}
+	if decoder.More() {
+		return nil, fmt.Errorf("invalid JSON: unexpected data after the top-level value")
+	}
+	return normalizeJSON(decoded), nil
+}
+
+// normalizeJSON replaces json.Number values with int64 or float64, recursively
+func normalizeJSON(value interface{}) interface{} {
+	switch v := value.(type) {
+	case json.Number:
+		if i, err := v.Int64(); err == nil {
+			return i
+		}
+		if f, err := v.Float64(); err == nil {
+			return f
+		}
+		return v.String()
+	case map[string]interface{}:
+		for key, item := range v {
+			v[key] = normalizeJSON(item)
+		}
+		return v
+	case []interface{}:
+		for i, item := range v {
+			v[i] = normalizeJSON(item)
+		}
+		return v
+	default:
+		return v
+	}
+}
+
+// applyValueTypes sets row.Typed from row.Extra for every key with a concrete non-string type
+// Values that are empty or cannot be converted stay strings, so templates still render.
+func applyValueTypes(row *NodeRow, types map[string]ValueType) {
+	for key, valueType := range types {
+		if valueType == ValueTypeString || valueType == ValueTypeAuto || valueType == "" {
+			continue
+		}
+		typed, err := ConvertValue(row.Extra[key], valueType)
+		if err != nil {
+			continue
+		}
+		if row.Typed == nil {
+			row.Typed = make(map[string]interface{}, len(types))
+		}
+		row.Typed[key] = typed
+	}
+}
+
+// resolveValueTypes replaces ValueTypeAuto with the type returned by native for that key
+func resolveValueTypes(types map[string]ValueType, native func(key string) ValueType) map[string]ValueType {
+	if len(types) == 0 {
+		return nil
+	}
+	resolved := make(map[string]ValueType, len(types))
+	for key, valueType := range types {
+		if valueType == ValueTypeAuto {
+			valueType = native(key)
+		}
+		resolved[key] = valueType
+	}
+	return resolved
+}
+
+// sqlColumnValueType maps a database column type name to a value type
+func sqlColumnValueType(databaseTypeName string) ValueType {
+	name := strings.TrimPrefix(strings.ToUpper(databaseTypeName), "UNSIGNED ")
+	switch name {
+	case "TINYINT", "SMALLINT", "MEDIUMINT", "INT", "INTEGER", "BIGINT", "INT2", "INT4", "INT8":
+		return ValueTypeInt
+	case "DECIMAL", "NUMERIC", "FLOAT", "DOUBLE", "REAL", "FLOAT4", "FLOAT8":
+		return ValueTypeFloat
+	case "BOOL", "BOOLEAN":
+		return ValueTypeBool
+	case "JSON", "JSONB":
+		return ValueTypeJSON
+	default:
+		return ValueTypeString
+	}
+}
+
+// jsonValueType returns the value type of a decoded JSON value (decoded with UseNumber, or
+// from a protobuf Struct, whose numbers are float64)
+func jsonValueType(value interface{}) ValueType {
+	switch v := value.(type) {
+	case json.Number:
+		if _, err := v.Int64(); err == nil {
+			return ValueTypeInt
+		}
+		return ValueTypeFloat
+	case float64:
+		if v == float64(int64(v)) {
+			return ValueTypeInt
+		}
+		return ValueTypeFloat
+	case bool:
+		return ValueTypeBool
+	case map[string]interface{}, []interface{}:
+		return ValueTypeJSON
+	default:
+		return ValueTypeString
+	}
+}
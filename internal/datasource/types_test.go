@@ -0,0 +1,132 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package datasource
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConvertValue(t *testing.T) {
+	tests := []struct {
+		name       string
+		value      string
+		valueType  ValueType
+		want       interface{}
+		errMessage string
+	}{
+		{name: "int", value: "42", valueType: ValueTypeInt, want: int64(42)},
+		{name: "negative int", value: "-7", valueType: ValueTypeInt, want: int64(-7)},
+		{name: "whole decimal as int", value: "3.00", valueType: ValueTypeInt, want: int64(3)},
+		{name: "fractional decimal as int", value: "3.5", valueType: ValueTypeInt, errMessage: "not an integer"},
+		{name: "float", value: "1.5", valueType: ValueTypeFloat, want: 1.5},
+		{name: "int as float", value: "2", valueType: ValueTypeFloat, want: float64(2)},
+		{name: "invalid float", value: "fast", valueType: ValueTypeFloat, errMessage: "not a number"},
+		{name: "bool true", value: "TRUE", valueType: ValueTypeBool, want: true},
+		{name: "bool 1", value: "1", valueType: ValueTypeBool, want: true},
+		{name: "bool no", value: "no", valueType: ValueTypeBool, want: false},
+		{name: "invalid bool", value: "maybe", valueType: ValueTypeBool, errMessage: "not a boolean"},
+		{
+			name:      "json object",
+			value:     `{"features":{"sso":true},"seats":10,"ratio":0.5,"regions":["eu","us"]}`,
+			valueType: ValueTypeJSON,
+			want: map[string]interface{}{
+				"features": map[string]interface{}{"sso": true},
+				"seats":    int64(10),
+				"ratio":    0.5,
+				"regions":  []interface{}{"eu", "us"},
+			},
+		},
+		{name: "json list", value: `[1, "two"]`, valueType: ValueTypeJSON, want: []interface{}{int64(1), "two"}},
+		{name: "json null", value: "null", valueType: ValueTypeJSON, errMessage: "null value"},
+		{name: "invalid json", value: `{"a":`, valueType: ValueTypeJSON, errMessage: "invalid JSON"},
+		{name: "trailing json data", value: `{} {}`, valueType: ValueTypeJSON, errMessage: "unexpected data"},
+		{name: "empty value", value: "", valueType: ValueTypeInt, errMessage: "empty value"},
+		{name: "unsupported type", value: "x", valueType: "date", errMessage: "unsupported value type"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ConvertValue(tt.value, tt.valueType)
+			if tt.errMessage != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errMessage)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestApplyValueTypes(t *testing.T) {
+	row := NodeRow{
+		UID:   "acme",
+		Extra: map[string]string{"replicas": "3", "ratio": "0.5", "enabled": "yes", "plan": "pro", "seats": "", "port": "http"},
+	}
+	applyValueTypes(&row, map[string]ValueType{
+		"replicas": ValueTypeInt,
+		"ratio":    ValueTypeFloat,
+		"enabled":  ValueTypeBool,
+		"plan":     ValueTypeString,
+		"seats":    ValueTypeInt,
+		"port":     ValueTypeInt,
+	})
+
+	assert.Equal(t, map[string]interface{}{"replicas": int64(3), "ratio": 0.5, "enabled": true}, row.Typed,
+		"string, empty and unconvertible values stay untyped")
+	assert.Equal(t, "3", row.Extra["replicas"], "extra keeps the string form")
+	assert.Equal(t, map[string]interface{}{
+		"replicas": int64(3), "ratio": 0.5, "enabled": true, "plan": "pro", "seats": "", "port": "http",
+	}, row.Values())
+
+	untyped := NodeRow{Extra: map[string]string{"plan": "pro"}}
+	applyValueTypes(&untyped, nil)
+	assert.Nil(t, untyped.Typed)
+}
+
+func TestSQLColumnValueType(t *testing.T) {
+	tests := map[string]ValueType{
+		"INT":             ValueTypeInt,
+		"UNSIGNED BIGINT": ValueTypeInt,
+		"int4":            ValueTypeInt,
+		"DECIMAL":         ValueTypeFloat,
+		"FLOAT8":          ValueTypeFloat,
+		"BOOL":            ValueTypeBool,
+		"JSON":            ValueTypeJSON,
+		"jsonb":           ValueTypeJSON,
+		"VARCHAR":         ValueTypeString,
+		"TIMESTAMP":       ValueTypeString,
+		"":                ValueTypeString,
+	}
+	for name, want := range tests {
+		assert.Equal(t, want, sqlColumnValueType(name), name)
+	}
+}
+
+func TestJSONValueType(t *testing.T) {
+	assert.Equal(t, ValueTypeInt, jsonValueType(json.Number("3")))
+	assert.Equal(t, ValueTypeFloat, jsonValueType(json.Number("3.5")))
+	assert.Equal(t, ValueTypeBool, jsonValueType(false))
+	assert.Equal(t, ValueTypeJSON, jsonValueType(map[string]interface{}{"a": "b"}))
+	assert.Equal(t, ValueTypeJSON, jsonValueType([]interface{}{}))
+	assert.Equal(t, ValueTypeString, jsonValueType("3"))
+	assert.Equal(t, ValueTypeString, jsonValueType(nil))
+}
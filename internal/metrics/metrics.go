@@ -86,6 +86,33 @@ var (
 		[]string{"hub", "namespace"},
 	)
 
+	// HubLastSuccessfulSync tracks when each hub last queried its datasource successfully
+	HubLastSuccessfulSync = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "hub_last_successful_sync_timestamp_seconds",
+			Help: "Unix timestamp of the last successful datasource sync for a hub",
+		},
+		[]string{"hub", "namespace"},
+	)
+
+	// HubConsecutiveFailures tracks failed datasource syncs since the last success per hub
+	HubConsecutiveFailures = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "hub_datasource_consecutive_failures",
+			Help: "Number of consecutive failed datasource syncs for a hub",
+		},
+		[]string{"hub", "namespace"},
+	)
+
+	// HubCircuitOpen tracks whether the circuit breaker of a hub's datasource is open
+	HubCircuitOpen = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "hub_datasource_circuit_open",
+			Help: "Whether the circuit breaker of a hub's datasource is open (1) or closed (0)",
+		},
+		[]string{"hub", "namespace"},
+	)
+
 	// ApplyAttemptsTotal counts resource apply attempts
 	ApplyAttemptsTotal = prometheus.NewCounterVec(
 		prometheus.CounterOpts{
@@ -171,6 +198,9 @@ func init() {
 		HubDesired,
 		HubReady,
 		HubFailed,
+		HubLastSuccessfulSync,
+		HubConsecutiveFailures,
+		HubCircuitOpen,
 		ApplyAttemptsTotal,
 		LynqNodeConditionStatus,
 		LynqNodeConflictsTotal,
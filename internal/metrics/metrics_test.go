@@ -170,6 +170,57 @@ hub_failed{hub="mysql-prod",namespace="default"} 5
 	assert.NoError(t, err)
 }
 
+func TestHubDatasourceStaleness(t *testing.T) {
+	HubLastSuccessfulSync.Reset()
+	HubConsecutiveFailures.Reset()
+
+	// Datasource has been failing since the last successful sync
+	HubLastSuccessfulSync.WithLabelValues("mysql-prod", "default").Set(1735689600)
+	HubConsecutiveFailures.WithLabelValues("mysql-prod", "default").Set(3)
+
+	expected := `
+# HELP hub_last_successful_sync_timestamp_seconds Unix timestamp of the last successful datasource sync for a hub
+# TYPE hub_last_successful_sync_timestamp_seconds gauge
+hub_last_successful_sync_timestamp_seconds{hub="mysql-prod",namespace="default"} 1.7356896e+09
+`
+	err := testutil.CollectAndCompare(HubLastSuccessfulSync, strings.NewReader(expected))
+	assert.NoError(t, err)
+
+	expected = `
+# HELP hub_datasource_consecutive_failures Number of consecutive failed datasource syncs for a hub
+# TYPE hub_datasource_consecutive_failures gauge
+hub_datasource_consecutive_failures{hub="mysql-prod",namespace="default"} 3
+`
+	err = testutil.CollectAndCompare(HubConsecutiveFailures, strings.NewReader(expected))
+	assert.NoError(t, err)
+
+	for _, metric := range []prometheus.Collector{HubLastSuccessfulSync, HubConsecutiveFailures} {
+		problems, err := testutil.CollectAndLint(metric)
+		assert.NoError(t, err)
+		assert.Empty(t, problems)
+	}
+}
+
+func TestHubCircuitOpen(t *testing.T) {
+	HubCircuitOpen.Reset()
+
+	HubCircuitOpen.WithLabelValues("mysql-prod", "default").Set(1)
+	HubCircuitOpen.WithLabelValues("billing", "default").Set(0)
+
+	expected := `
+# HELP hub_datasource_circuit_open Whether the circuit breaker of a hub's datasource is open (1) or closed (0)
+# TYPE hub_datasource_circuit_open gauge
+hub_datasource_circuit_open{hub="billing",namespace="default"} 0
+hub_datasource_circuit_open{hub="mysql-prod",namespace="default"} 1
+`
+	err := testutil.CollectAndCompare(HubCircuitOpen, strings.NewReader(expected))
+	assert.NoError(t, err)
+
+	problems, err := testutil.CollectAndLint(HubCircuitOpen)
+	assert.NoError(t, err)
+	assert.Empty(t, problems)
+}
+
 func TestApplyAttemptsTotal(t *testing.T) {
 	ApplyAttemptsTotal.Reset()
 
@@ -285,6 +336,9 @@ func TestMetricsRegistration(t *testing.T) {
 		HubDesired,
 		HubReady,
 		HubFailed,
+		HubLastSuccessfulSync,
+		HubConsecutiveFailures,
+		HubCircuitOpen,
 		ApplyAttemptsTotal,
 		LynqNodeConditionStatus,
 		LynqNodeConflictsTotal,
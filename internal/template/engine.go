@@ -27,6 +27,7 @@ import (
 	"strings"
 	"sync"
 	"text/template"
+	"text/template/parse"
 
 	"github.com/Masterminds/sprig/v3"
 )
@@ -51,6 +52,10 @@ type Variables map[string]interface{}
 // safe for concurrent use after parsing.
 var globalTemplateCache sync.Map // map[string]*template.Template
 
+// globalTypedTemplateCache caches the typed variant of single-action templates used by RenderTyped.
+// Templates that are not a single action are stored as nil.
+var globalTypedTemplateCache sync.Map // map[string]*template.Template
+
 // bufPool is a pool of bytes.Buffer to reduce GC pressure from template.Execute calls.
 var bufPool = sync.Pool{
 	New: func() interface{} { return new(bytes.Buffer) },
@@ -79,6 +84,9 @@ func NewEngine() *Engine {
 	engine.funcMap["float"] = toFloat
 	engine.funcMap["bool"] = toBool
 
+	// Appended to single-action pipelines by RenderTyped
+	engine.funcMap[typedFuncName] = typedValue
+
 	return engine
 }
 
@@ -97,26 +105,100 @@ func (e *Engine) Render(templateStr string, vars Variables) (string, error) {
 		return templateStr, nil
 	}
 
-	// Look up cached parsed template (process-wide cache shared across all Engine instances)
-	cached, ok := globalTemplateCache.Load(templateStr)
+	parsed, err := e.parse(templateStr)
+	if err != nil {
+		return "", err
+	}
+	return execute(parsed, vars)
+}
+
+// RenderTyped renders a template like Render, but keeps the type of typed row variables when
+// the template is a single reference to one, e.g. "{{ .replicas }}" or "{{ .replicas | default 2 }}".
+// Integer, float and boolean variables carry a type marker for ParseTypedValue; all other
+// results, including computed ones such as "{{ add 1 2 }}", render exactly as with Render.
+func (e *Engine) RenderTyped(templateStr string, vars Variables) (string, error) {
+	if !strings.Contains(templateStr, "{{") {
+		return templateStr, nil
+	}
+
+	typed, ok := globalTypedTemplateCache.Load(templateStr)
 	if !ok {
-		// Parse and cache the template
-		parsed, err := template.New("template").
-			Option("missingkey=error").
-			Funcs(e.funcMap).
-			Parse(templateStr)
+		parsed, err := e.parse(templateStr)
+		if err != nil {
+			return "", err
+		}
+		variant, err := e.typedVariant(parsed)
 		if err != nil {
-			return "", fmt.Errorf("failed to parse template: %w", err)
+			return "", err
 		}
-		cached, _ = globalTemplateCache.LoadOrStore(templateStr, parsed)
+		typed, _ = globalTypedTemplateCache.LoadOrStore(templateStr, variant)
 	}
+	if typed.(*template.Template) == nil {
+		return e.Render(templateStr, vars)
+	}
+	return execute(typed.(*template.Template), vars)
+}
+
+// Validate parses a template string without rendering it, for templates whose variables
+// are only known at render time (e.g. extra values of hub rows)
+func (e *Engine) Validate(templateStr string) error {
+	_, err := e.parse(templateStr)
+	return err
+}
+
+// parse returns the parsed template from the process-wide cache, parsing it on first use
+func (e *Engine) parse(templateStr string) (*template.Template, error) {
+	if cached, ok := globalTemplateCache.Load(templateStr); ok {
+		return cached.(*template.Template), nil
+	}
+	parsed, err := template.New("template").
+		Option("missingkey=error").
+		Funcs(e.funcMap).
+		Parse(templateStr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse template: %w", err)
+	}
+	cached, _ := globalTemplateCache.LoadOrStore(templateStr, parsed)
+	return cached.(*template.Template), nil
+}
 
-	// Execute template using pooled buffer to reduce GC pressure
+// typedVariant returns "{{ <pipeline> | _lynqTyped <field> }}" for a template that consists of
+// exactly one action reading a variable, optionally followed by default, e.g. "{{ .replicas }}".
+// It returns nil for any other template: the results of other functions keep rendering as strings.
+func (e *Engine) typedVariant(parsed *template.Template) (*template.Template, error) {
+	if parsed.Tree == nil || parsed.Tree.Root == nil || len(parsed.Tree.Root.Nodes) != 1 {
+		return nil, nil
+	}
+	action, ok := parsed.Tree.Root.Nodes[0].(*parse.ActionNode)
+	if !ok || len(action.Pipe.Decl) > 0 || len(action.Pipe.Cmds[0].Args) != 1 {
+		return nil, nil
+	}
+	field, ok := action.Pipe.Cmds[0].Args[0].(*parse.FieldNode)
+	if !ok {
+		return nil, nil
+	}
+	for _, cmd := range action.Pipe.Cmds[1:] {
+		if fn, ok := cmd.Args[0].(*parse.IdentifierNode); !ok || fn.Ident != "default" {
+			return nil, nil
+		}
+	}
+	variant, err := template.New("typed").
+		Option("missingkey=error").
+		Funcs(e.funcMap).
+		Parse("{{ " + action.Pipe.String() + " | " + typedFuncName + " " + field.String() + " }}")
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse template: %w", err)
+	}
+	return variant, nil
+}
+
+// execute runs a parsed template using a pooled buffer to reduce GC pressure
+func execute(parsed *template.Template, vars Variables) (string, error) {
 	buf := bufPool.Get().(*bytes.Buffer)
 	buf.Reset()
 	defer bufPool.Put(buf)
 
-	if err := cached.(*template.Template).Execute(buf, vars); err != nil {
+	if err := parsed.Execute(buf, vars); err != nil {
 		return "", fmt.Errorf("failed to execute template: %w", err)
 	}
 
@@ -307,6 +389,33 @@ func toBool(value interface{}) string {
 	return fmt.Sprintf("%s%t", MarkerBool, result)
 }
 
+// typedFuncName is the internal function RenderTyped appends to single-variable pipelines
+const typedFuncName = "_lynqTyped"
+
+// typedValue wraps integer, float and boolean values with a type marker when the variable they
+// come from is typed, and prints everything else like the template engine would. A default
+// replacing an empty string variable therefore still renders as a string.
+func typedValue(variable, value interface{}) string {
+	if value == nil {
+		return "<no value>"
+	}
+	switch variable.(type) {
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64, float32, float64, bool:
+	default:
+		return fmt.Sprint(value)
+	}
+	switch v := value.(type) {
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64:
+		return fmt.Sprintf("%s%d", MarkerInt, v)
+	case float32, float64:
+		return fmt.Sprintf("%s%v", MarkerFloat, v)
+	case bool:
+		return fmt.Sprintf("%s%t", MarkerBool, v)
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
 // StripTypeMarker removes type markers from a rendered string and returns the raw value as a string
 // This allows YAML parsers to automatically convert string numbers to integers where needed,
 // while keeping values as strings in string-only fields (e.g., ConfigMap data)
@@ -377,8 +486,10 @@ func ParseTypedValue(s string) interface{} {
 }
 
 // BuildVariables creates Variables from database row data
+// Extra values keep their type (string, int64, float64 or bool), so typed columns render
+// with their type in single-action template fields.
 // Note: hostOrURL and host are deprecated since v1.1.11 and will be removed in v1.3.0
-func BuildVariables(uid, hostOrURL, activate string, extraMappings map[string]string) Variables {
+func BuildVariables(uid, hostOrURL, activate string, extraValues map[string]interface{}) Variables {
 	vars := Variables{
 		"uid":      uid,
 		"activate": activate,
@@ -392,8 +503,8 @@ func BuildVariables(uid, hostOrURL, activate string, extraMappings map[string]st
 		vars["host"] = toHost(hostOrURL)
 	}
 
-	// Add extra mappings
-	for k, v := range extraMappings {
+	// Add extra values
+	for k, v := range extraValues {
 		vars[k] = v
 	}
 
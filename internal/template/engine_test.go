@@ -88,6 +88,17 @@ func TestEngine_Render(t *testing.T) {
 	}
 }
 
+func TestEngine_Validate(t *testing.T) {
+	engine := NewEngine()
+
+	if err := engine.Validate(`{{ eq .plan "enterprise" }}`); err != nil {
+		t.Errorf("Validate() error = %v, want nil for unknown variables", err)
+	}
+	if err := engine.Validate("{{ .plan"); err == nil {
+		t.Error("Validate() error = nil, want a parse error")
+	}
+}
+
 func TestEngine_RenderMap(t *testing.T) {
 	engine := NewEngine()
 
@@ -464,7 +475,7 @@ func TestBuildVariables(t *testing.T) {
 		uid           string
 		hostOrURL     string
 		activate      string
-		extraMappings map[string]string
+		extraMappings map[string]interface{}
 		wantKeys      []string
 	}{
 		{
@@ -472,11 +483,22 @@ func TestBuildVariables(t *testing.T) {
 			uid:       "42",
 			hostOrURL: "https://example.com",
 			activate:  "true",
-			extraMappings: map[string]string{
+			extraMappings: map[string]interface{}{
 				"deployImage": "my-image:latest",
 			},
 			wantKeys: []string{"uid", "hostOrUrl", "activate", "host", "deployImage"},
 		},
+		{
+			name:      "typed values",
+			uid:       "43",
+			hostOrURL: "example.com",
+			activate:  "1",
+			extraMappings: map[string]interface{}{
+				"replicas": int64(3),
+				"ha":       true,
+			},
+			wantKeys: []string{"uid", "hostOrUrl", "activate", "replicas", "ha"},
+		},
 	}
 
 	for _, tt := range tests {
@@ -501,6 +523,56 @@ func TestBuildVariables(t *testing.T) {
 			if result["host"] != "example.com" {
 				t.Errorf("BuildVariables() host = %v, want example.com", result["host"])
 			}
+			// extra values keep their type
+			for key, want := range tt.extraMappings {
+				if result[key] != want {
+					t.Errorf("BuildVariables() %s = %v (%T), want %v (%T)", key, result[key], result[key], want, want)
+				}
+			}
+		})
+	}
+}
+
+func TestEngine_Render_NestedVariables(t *testing.T) {
+	engine := NewEngine()
+	vars := BuildVariables("acme", "", "1", map[string]interface{}{
+		"settings": map[string]interface{}{
+			"features": map[string]interface{}{"sso": true},
+			"seats":    int64(10),
+		},
+		"domains": []interface{}{
+			map[string]interface{}{"host": "acme.example.com", "primary": true},
+			map[string]interface{}{"host": "www.acme.io", "primary": false},
+		},
+	})
+
+	tests := []struct {
+		name     string
+		template string
+		want     string
+	}{
+		{"nested field", `{{ .settings.features.sso }}`, "true"},
+		{"nested number", `{{ .settings.seats }}`, "10"},
+		{"range over list", `{{ range .domains }}{{ .host }};{{ end }}`, "acme.example.com;www.acme.io;"},
+		{"index into list", `{{ (index .domains 0).host }}`, "acme.example.com"},
+		{"toJson of nested value", `{{ .settings.features | toJson }}`, `{"sso":true}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := engine.Render(tt.template, vars)
+			if err != nil {
+				t.Fatalf("Render() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("Render() = %q, want %q", got, tt.want)
+			}
 		})
 	}
+
+	t.Run("missing nested key fails", func(t *testing.T) {
+		if _, err := engine.Render(`{{ .settings.features.scim }}`, vars); err == nil {
+			t.Error("Render() expected error for missing nested key")
+		}
+	})
 }
@@ -432,3 +432,75 @@ func TestKubernetesResourceScenarios(t *testing.T) {
 		}
 	})
 }
+
+// TestRenderTyped tests that single-action templates keep the type of typed variables
+func TestRenderTyped(t *testing.T) {
+	engine := NewEngine()
+	vars := Variables{
+		"replicas": int64(3),
+		"cpu":      0.5,
+		"enabled":  true,
+		"port":     "8080",
+		"name":     "acme",
+		"empty":    "",
+	}
+
+	tests := []struct {
+		name     string
+		template string
+		expected interface{}
+	}{
+		{"int variable", `{{ .replicas }}`, int64(3)},
+		{"float variable", `{{ .cpu }}`, 0.5},
+		{"bool variable", `{{ .enabled }}`, true},
+		{"trimmed action", `{{- .replicas -}}`, int64(3)},
+		{"typed variable with default", `{{ .replicas | default 2 }}`, int64(3)},
+		{"default for an untyped variable stays string", `{{ .empty | default 2 }}`, "2"},
+		{"string variable stays string", `{{ .port }}`, "8080"},
+		{"computed int stays string", `{{ add 1 2 }}`, "3"},
+		{"computed length stays string", `{{ len .name }}`, "4"},
+		{"comparison stays string", `{{ eq .name "acme" }}`, "true"},
+		{"function of a typed variable stays string", `{{ add .replicas 1 }}`, "4"},
+		{"explicit int function", `{{ .port | int }}`, int64(8080)},
+		{"embedded in text renders untyped", `replicas-{{ .replicas }}`, "replicas-3"},
+		{"multiple actions render untyped", `{{ .replicas }}{{ .cpu }}`, "30.5"},
+		{"control structure renders untyped", `{{ if .enabled }}{{ .replicas }}{{ end }}`, "3"},
+		{"variable declaration renders untyped", `{{ $r := .replicas }}{{ $r }}`, "3"},
+		{"plain string", `acme`, "acme"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rendered, err := engine.RenderTyped(tt.template, vars)
+			if err != nil {
+				t.Fatalf("RenderTyped() error = %v", err)
+			}
+			parsed := ParseTypedValue(rendered)
+			if parsed != tt.expected {
+				t.Errorf("Parsed value = %v (%T), want %v (%T)", parsed, parsed, tt.expected, tt.expected)
+			}
+		})
+	}
+
+	t.Run("same output as Render after stripping markers", func(t *testing.T) {
+		for _, tmpl := range []string{`{{ .replicas }}`, `{{ .cpu }}`, `{{ .enabled }}`, `{{ .name | upper }}`} {
+			typed, err := engine.RenderTyped(tmpl, vars)
+			if err != nil {
+				t.Fatalf("RenderTyped(%q) error = %v", tmpl, err)
+			}
+			plain, err := engine.Render(tmpl, vars)
+			if err != nil {
+				t.Fatalf("Render(%q) error = %v", tmpl, err)
+			}
+			if StripTypeMarker(typed) != plain {
+				t.Errorf("RenderTyped(%q) = %q, Render = %q", tmpl, typed, plain)
+			}
+		}
+	})
+
+	t.Run("missing variable fails like Render", func(t *testing.T) {
+		if _, err := engine.RenderTyped(`{{ .missing }}`, vars); err == nil {
+			t.Error("RenderTyped() expected error for missing variable")
+		}
+	})
+}